@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/pkg/adapters/input/native"
+)
+
+// Prints the resolved configuration (file < env < flags) and exits with
+// status 1 when a value was rejected.
+func main() {
+	provider, err := native.NewProvider(os.Args[0], os.Args[1:], os.Environ(), os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+
+	cfg := provider.Load()
+	for _, t := range cfg.Trace() {
+		fmt.Printf("%-12s %-6s %s\n", t.Param, t.Value, t.Origin)
+	}
+
+	failed := false
+	for _, issue := range cfg.Issues {
+		level := "warning"
+		if issue.Severity == app.SeverityError {
+			level = "error"
+			failed = true
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s (%s)\n", level, issue.Error(), issue.Origin)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/native"
+	inputweb "github.com/psaraiva/squash/pkg/adapters/input/web"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+	"github.com/psaraiva/squash/pkg/adapters/output/svg"
+)
+
+// Exports the first frame of a game, a preview of the configured level and
+// theme (with the overlay under -debug), as a vector or bitmap image:
+//
+//	squash-frame [flags] [out.svg|out.png]
+func main() {
+	provider, err := native.NewProvider(os.Args[0], os.Args[1:], os.Environ(), os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+
+	cfg := provider.Load()
+	cfg.DeltaTime = float64(app.FrameMillis(cfg.Fps)) / 1000.0
+	for _, issue := range cfg.Issues {
+		fmt.Fprintf(os.Stderr, "warning: %s (%s)\n", issue.Error(), issue.Origin)
+	}
+
+	path := "squash.svg"
+	if args := provider.Flags.Args(); len(args) > 0 {
+		path = args[0]
+	}
+
+	squash := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	squash.State = app.StatePlaying
+	theme := inputweb.LookupTheme(inputweb.Themes, cfg.Theme)
+
+	if err := write(path, func(r ports.Renderer) { inputweb.PaintGame(r, squash, theme, nil) }); err != nil {
+		fmt.Fprintf(os.Stderr, "squash-frame: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// write picks the renderer from the file extension.
+func write(path string, paint func(r ports.Renderer)) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		r := raster.NewScaled(app.CourtWidth, app.CourtHeight, 1)
+		paint(r)
+		err = r.EncodePNG(f)
+	} else {
+		r := svg.NewRenderer(app.CourtWidth, app.CourtHeight)
+		paint(r)
+		_, err = r.WriteTo(f)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/native"
+	inputweb "github.com/psaraiva/squash/pkg/adapters/input/web"
+	"github.com/psaraiva/squash/pkg/adapters/output/clip"
+)
+
+// Simulates a session headlessly and exports it as an animated GIF:
+//
+//	squash-gif [flags] [out.gif]
+//
+// It is not a replay of a game someone played: no input is recorded. The
+// paddle follows the ball, so the same configuration and seed always give the
+// same clip; with the -seed and settings of a challenge, the bot plays its
+// court. The length, frame rate, scale and palette are the clip* parameters.
+func main() {
+	provider, err := native.NewProvider(os.Args[0], os.Args[1:], os.Environ(), os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+
+	cfg := provider.Load()
+	cfg.DeltaTime = float64(app.FrameMillis(cfg.Fps)) / 1000.0
+	for _, issue := range cfg.Issues {
+		fmt.Fprintf(os.Stderr, "warning: %s (%s)\n", issue.Error(), issue.Origin)
+	}
+
+	path := "squash.gif"
+	if args := provider.Flags.Args(); len(args) > 0 {
+		path = args[0]
+	}
+
+	frames := simulate(cfg)
+	theme := inputweb.LookupTheme(inputweb.Themes, cfg.Theme)
+	paint := func(r ports.Renderer, p *app.Squash) {
+		inputweb.PaintGame(r, p, theme, nil)
+	}
+
+	if err := write(path, frames, paint, clip.NewOptions(cfg, theme.Colors())); err != nil {
+		fmt.Fprintf(os.Stderr, "squash-gif: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s: %d frames, %d fps\n", path, len(frames), cfg.ClipFps)
+}
+
+// simulate plays ClipSeconds of a game with the paddle following the ball.
+func simulate(cfg app.Config) []app.Squash {
+	squash := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	squash.State = app.StatePlaying
+
+	rec := clip.NewRecorder(cfg.ClipSeconds, cfg.ClipFps)
+	for tick := 0; tick < cfg.ClipSeconds*cfg.Fps; tick++ {
+		squash.CalcMovePaddle(squash.BallY + squash.BallSize/2)
+		squash.Update()
+		squash.DrainEvents()
+		rec.Record(squash)
+
+		if squash.State == app.StateGameOver {
+			break
+		}
+	}
+
+	return rec.Frames()
+}
+
+func write(path string, frames []app.Squash, paint clip.Paint, opts clip.Options) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := clip.Encode(w, frames, paint, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/pkg/adapters/input/controller"
+	"github.com/psaraiva/squash/pkg/adapters/input/native"
+	inputterminal "github.com/psaraiva/squash/pkg/adapters/input/terminal"
+	inputweb "github.com/psaraiva/squash/pkg/adapters/input/web"
+	outputterminal "github.com/psaraiva/squash/pkg/adapters/output/terminal"
+)
+
+// Plays Squash in the terminal: keyboard from raw stdin, ANSI cells on stdout.
+// The configuration is the native one (file < env < flags).
+func main() {
+	provider, err := native.NewProvider(os.Args[0], os.Args[1:], os.Environ(), os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+
+	cfg := provider.Load()
+	cfg.DeltaTime = float64(app.FrameMillis(cfg.Fps)) / 1000.0
+	cfg.PointerLock = false
+
+	token, err := play(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "squash-tui: stdin is not a terminal: %v\n", err)
+		os.Exit(1)
+	}
+
+	// printed once the terminal is back to normal, so the line is not mangled
+	if token != "" {
+		fmt.Printf("challenge: ?challenge=%s\n", token)
+	}
+}
+
+// play runs a game with the terminal in raw mode and returns the challenge
+// token left by the game over screen. The terminal is restored however the
+// game ends, a panic included.
+func play(cfg app.Config) (string, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := inputterminal.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = inputterminal.Restore(fd, state) }()
+
+	squash := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	run(squash, controller.NewInputController(squash, cfg), fd)
+	return squash.ShareToken, nil
+}
+
+func run(squash *app.Squash, ctrl *controller.InputController, fd int) {
+	out := bufio.NewWriterSize(os.Stdout, 1<<16)
+	screen := outputterminal.NewScreen(squash.Width, squash.Height, 80, 24)
+	resize(screen, fd)
+
+	_ = screen.Open(out)
+	defer func() {
+		_ = screen.Close(out)
+		_ = out.Flush()
+	}()
+
+	keys := make(chan []controller.Key)
+	go inputterminal.ReadKeys(os.Stdin, keys)
+
+	fps := squash.Fps
+	ticker := time.NewTicker(frameDuration(fps))
+	defer func() { ticker.Stop() }()
+
+	fx := inputweb.NewEffects(time.Now().UnixNano())
+	var painter inputweb.Painter
+	for {
+		select {
+		case batch, ok := <-keys:
+			if !ok {
+				return
+			}
+			for _, key := range batch {
+				ctrl.Dispatch(ctrl.KeyPress(key))
+			}
+
+		case <-ticker.C:
+			resize(screen, fd)
+			squash.Update()
+			theme := cellTheme(inputweb.LookupTheme(inputweb.Themes, squash.Theme), screen)
+
+			fx.Update(squash, squash.DrainEvents(), theme)
+			painter.Paint(screen, squash, theme, fx)
+			_ = screen.Flush(out)
+			_ = out.Flush()
+
+			// FPS changed in the settings screen
+			if squash.Fps != fps {
+				fps = squash.Fps
+				ticker.Stop()
+				ticker = time.NewTicker(frameDuration(fps))
+			}
+		}
+	}
+}
+
+// resize follows the terminal size; it is polled each frame instead of
+// watching SIGWINCH, which is not portable.
+func resize(screen *outputterminal.Screen, fd int) {
+	if cols, rows, err := inputterminal.Size(fd); err == nil {
+		screen.Resize(cols, rows)
+	}
+}
+
+// cellTheme sizes the fonts to one cell row, so the layout puts each line of
+// text on its own row.
+func cellTheme(t inputweb.Theme, screen *outputterminal.Screen) inputweb.Theme {
+	_, h := screen.CellSize()
+	t.TextFont.Size = h
+	t.DebugFont.Size = h
+	return t
+}
+
+func frameDuration(fps int) time.Duration {
+	return time.Duration(app.FrameMillis(fps)) * time.Millisecond
+}
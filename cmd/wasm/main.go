@@ -1,13 +1,21 @@
+//go:build js && wasm
+
 package main
 
 import (
+	"bytes"
 	"syscall/js"
 	"time"
 
 	"github.com/psaraiva/squash/internal/app"
 	"github.com/psaraiva/squash/internal/ports"
+	inputconfig "github.com/psaraiva/squash/pkg/adapters/input/config"
+	"github.com/psaraiva/squash/pkg/adapters/input/controller"
 	inputwasm "github.com/psaraiva/squash/pkg/adapters/input/wasm"
 	inputweb "github.com/psaraiva/squash/pkg/adapters/input/web"
+	"github.com/psaraiva/squash/pkg/adapters/output/audio"
+	"github.com/psaraiva/squash/pkg/adapters/output/clip"
+	"github.com/psaraiva/squash/pkg/adapters/output/crt"
 	outputweb "github.com/psaraiva/squash/pkg/adapters/output/web"
 )
 
@@ -15,40 +23,152 @@ func main() {
 	doc := js.Global().Get("document")
 	canvasElement := doc.Call("getElementById", "gameCanvas")
 	if canvasElement.IsNull() {
-		panic("Canvas não encontrado")
+		panic("canvas not found")
 	}
 
-	w := canvasElement.Get("width").Float()
-	h := canvasElement.Get("height").Float()
+	storage := inputwasm.NewStorageSource()
 
-	var loader ports.ConfigProvider = inputwasm.NewConfigLoader()
+	// Priority: defaults < browser preferences < stored < server < url < challenge link
+	var loader ports.ConfigProvider = inputconfig.NewChainProvider(
+		inputconfig.NewDefaultsSource(),
+		inputwasm.NewBrowserSource(),
+		storage,
+		inputwasm.NewServerSource("config.json"),
+		inputwasm.NewConfigLoader(),
+		inputwasm.NewChallengeSource(),
+	)
 	cfg := loader.Load()
+	cfg.DeltaTime = float64(app.FrameMillis(cfg.Fps)) / 1000.0
+
+	themes, err := inputwasm.LoadThemes("theme.json")
+	if err != nil {
+		cfg.Issues = append(cfg.Issues, app.ConfigIssue{
+			Param:    app.ParamTheme,
+			Value:    app.ThemeCustom,
+			Origin:   inputconfig.SourceServer,
+			Severity: app.SeverityError,
+			Kind:     app.IssueSource,
+			Reason:   err.Error(),
+		})
+	}
 
-	deltaTime := getDeltaTime(cfg.Fps)
-	cfg.DeltaTime = float64(deltaTime) / 1000.0
+	var player ports.AudioPlayer = audio.NewNop()
+	if webAudio, err := outputweb.NewWebAudio(); err == nil {
+		player = webAudio
+	}
+	audio.Configure(player, cfg)
+
+	squash := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	ctrl := controller.NewInputController(squash, cfg).WithStore(storage)
+	inputwasm.SetupMouseHandlers(ctrl, canvasElement)
 
-	squash := app.NewSquash(w, h, cfg)
-	inputwasm.SetupMouseHandlers(squash, canvasElement, cfg)
+	clipRequests := make(chan struct{}, 1)
+	inputwasm.SetupClipKey(clipRequests)
 
 	done := make(chan struct{})
 	go func() {
-		ticker := time.NewTicker(time.Duration(deltaTime) * time.Millisecond)
-		defer ticker.Stop()
+		fps := squash.Fps
+		ticker := time.NewTicker(frameDuration(fps))
+		defer func() { ticker.Stop() }()
 
-		var renderer ports.Renderer = outputweb.NewRenderer()
+		renderer, flush := newRenderer(cfg.Renderer, squash.Width, squash.Height)
+		screen := crt.New(renderer, squash.Width, squash.Height, crt.DefaultOptions())
+		fx := inputweb.NewEffects(time.Now().UnixNano())
+		var painter inputweb.Painter
+		rec := clip.NewRecorder(cfg.ClipSeconds, cfg.ClipFps)
+		var export *clip.Encoder
 		for range ticker.C {
 			squash.Update()
-			inputweb.PaintGame(renderer, squash)
+			rec.Record(squash)
+			theme := inputweb.LookupTheme(themes, squash.Theme)
+
+			events := squash.DrainEvents()
+			audio.PlayEvents(player, events)
+			fx.Update(squash, events, theme)
+			screen.SetEnabled(inputweb.CRTEnabled(squash, theme))
+			painter.Paint(screen, squash, theme, fx)
+			screen.EndFrame()
+			flush()
+
+			// a clip is drawn one frame per tick: wasm has a single thread,
+			// and encoding it all at once would stop the game
+			select {
+			case <-clipRequests:
+				if export == nil {
+					export = newClipExport(rec.Frames(), theme, clip.NewOptions(cfg, theme.Colors()))
+				}
+			default:
+			}
+			if export != nil && !export.Step() {
+				saveClip(export)
+				export = nil
+			}
+			painter.Exporting = export != nil
+			if export != nil {
+				painter.ExportProgress = export.Progress()
+			}
+
+			// FPS changed in the settings screen
+			if squash.Fps != fps {
+				fps = squash.Fps
+				ticker.Stop()
+				ticker = time.NewTicker(frameDuration(fps))
+			}
 		}
 	}()
 	<-done
 }
 
-func getDeltaTime(fps int) int {
-	deltaTime := 33 // 30 fps
-	if fps == 60 {
-		deltaTime = 16
+// newRenderer draws the frames with WebGL when asked and available, or on the
+// 2D canvas. Either way a frame is drawn with one call into JavaScript, on
+// flush; on the 2D canvas the court is kept on its own canvas when the page
+// stacks one behind.
+func newRenderer(name string, w, h float64) (ports.Renderer, func()) {
+	if name == app.RendererWebGL {
+		gl, err := outputweb.NewPageWebGL(w, h)
+		if err == nil {
+			gl.WatchResize()
+			return gl, gl.Flush
+		}
+		js.Global().Get("console").Call("warn", err.Error()+", drawing on the 2D canvas")
+	}
+
+	canvas := outputweb.NewRenderer(w, h)
+	if layers := outputweb.NewPageLayers(canvas); layers != nil {
+		layers.WithBatch().WatchResize()
+		return layers, layers.Flush
 	}
 
-	return deltaTime
+	canvas.WatchResize()
+	batch := outputweb.NewBatch(canvas)
+	return batch, batch.Flush
+}
+
+// newClipExport starts the export of the recorded frames; nil when there is
+// nothing to export.
+func newClipExport(frames []app.Squash, theme inputweb.Theme, opts clip.Options) *clip.Encoder {
+	paint := func(r ports.Renderer, p *app.Squash) {
+		inputweb.PaintGame(r, p, theme, nil)
+	}
+
+	export, err := clip.NewEncoder(frames, paint, opts)
+	if err != nil {
+		js.Global().Get("console").Call("warn", err.Error())
+		return nil
+	}
+	return export
+}
+
+// saveClip writes the drawn clip and downloads it as squash.gif.
+func saveClip(export *clip.Encoder) {
+	var buf bytes.Buffer
+	if err := export.Finish(&buf); err != nil {
+		js.Global().Get("console").Call("warn", err.Error())
+		return
+	}
+	inputwasm.Download("squash.gif", "image/gif", buf.Bytes())
+}
+
+func frameDuration(fps int) time.Duration {
+	return time.Duration(app.FrameMillis(fps)) * time.Millisecond
 }
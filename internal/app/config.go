@@ -1,13 +1,240 @@
 package app
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Configuration parameter names, shared by every ConfigProvider.
+const (
+	ParamDebug       = "debug"
+	ParamLives       = "lives"
+	ParamLevel       = "level"
+	ParamBoost       = "boost"
+	ParamBallSize    = "ballsize"
+	ParamFps         = "fps"
+	ParamPointerLock = "pointerlock"
+	ParamSensitivity = "sensitivity"
+	ParamCurve       = "curve"
+	ParamSeed        = "seed"
+	ParamMode        = "mode"
+	ParamTheme       = "theme"
+	ParamBallShape   = "ballshape"
+	ParamLang        = "lang"
+	ParamVolume      = "volume"
+	ParamMute        = "mute"
+	ParamMotion      = "reducedmotion"
+	ParamClipSeconds = "clipseconds"
+	ParamClipFps     = "clipfps"
+	ParamClipScale   = "clipscale"
+	ParamClipPalette = "clippalette"
+	ParamRenderer    = "renderer"
+	ParamCRT         = "crt"
+)
+
+const (
+	ModeClassic   = "classic"
+	ModeChallenge = "challenge"
+)
+
+// Ball shapes: square keeps the classic AABB physics, round uses circle collisions.
+const (
+	BallSquare = "square"
+	BallRound  = "round"
+)
+
+// Theme names; ThemeCustom is the theme loaded from JSON by the frontend.
+const (
+	ThemeClassic      = "classic"
+	ThemeNeon         = "neon"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeCustom       = "custom"
+)
+
+var ThemeNames = []string{ThemeClassic, ThemeNeon, ThemeLight, ThemeHighContrast, ThemeCustom}
+
+// Languages of the player-facing text, as BCP 47 tags.
+const (
+	LangEnglish    = "en"
+	LangPortuguese = "pt-BR"
+)
+
+var Languages = []string{LangEnglish, LangPortuguese}
+
+// Palettes of the exported GIF clips: the theme colors with their fades, or a
+// fixed palette from image/color/palette.
+const (
+	PaletteTheme   = "theme"
+	PalettePlan9   = "plan9"
+	PaletteWebSafe = "websafe"
+)
+
+var PaletteNames = []string{PaletteTheme, PalettePlan9, PaletteWebSafe}
+
+// Browser renderers; the frontend falls back to RendererCanvas when WebGL is
+// not available.
+const (
+	RendererCanvas = "canvas"
+	RendererWebGL  = "webgl"
+)
+
+var RendererNames = []string{RendererCanvas, RendererWebGL}
+
+// OriginDefault marks values that come from NewDefaultConfig.
+const OriginDefault = "default"
+
+var ErrUnknownParam = errors.New("unknown parameter")
+
+var ConfigParams = []string{
+	ParamDebug,
+	ParamLives,
+	ParamLevel,
+	ParamBoost,
+	ParamBallSize,
+	ParamFps,
+	ParamPointerLock,
+	ParamSensitivity,
+	ParamCurve,
+	ParamSeed,
+	ParamMode,
+	ParamTheme,
+	ParamBallShape,
+	ParamLang,
+	ParamVolume,
+	ParamMute,
+	ParamMotion,
+	ParamClipSeconds,
+	ParamClipFps,
+	ParamClipScale,
+	ParamClipPalette,
+	ParamRenderer,
+	ParamCRT,
+}
+
 type Config struct {
 	Debug          bool
 	InitialLives   int
 	InitialLevel   int
 	SpeedIncrement float64
 	BallScale      float64
+	BallShape      string
 	Fps            int
 	DeltaTime      float64
+
+	// Pointer lock (relative mouse mode)
+	PointerLock      bool
+	MouseSensitivity float64
+	MouseCurve       float64
+
+	// Seed of the ball spawns (0 = random); Mode is classic or challenge (shared link).
+	Seed int64
+	Mode string
+
+	// Theme is the name of the color and font theme.
+	Theme string
+
+	// Lang is the language of the player-facing text.
+	Lang string
+
+	// Sound effects volume in [0, 1]; Mute silences them without losing the volume.
+	Volume float64
+	Mute   bool
+
+	// ReducedMotion turns off particles, screen shake and ball trails.
+	ReducedMotion bool
+
+	// GIF clips: the last ClipSeconds of play at ClipFps frames per second,
+	// ClipScale pixels per court unit and the ClipPalette colors.
+	ClipSeconds int
+	ClipFps     int
+	ClipScale   float64
+	ClipPalette string
+
+	// Renderer draws the frames in the browser: Canvas 2D or WebGL.
+	Renderer string
+
+	// CRT adds scanlines, glow, screen curvature and afterimages, unless
+	// ReducedMotion is on.
+	CRT bool
+
+	// Preset is the name of the difficulty preset the values started from.
+	Preset string
+
+	// Origin maps a parameter name to the provider that set it.
+	Origin map[string]string
+	// Issues lists the values rejected or ignored while loading.
+	Issues []ConfigIssue
+}
+
+type ConfigTrace struct {
+	Param  string
+	Value  string
+	Origin string
+}
+
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+// IssueKind is why a value was rejected, so that the frontends can word the
+// issue in the language of the player; Reason keeps the details in English.
+type IssueKind int
+
+const (
+	IssueMalformed    IssueKind = iota // not a number, an integer or a boolean
+	IssueOutOfRange                    // a number outside its range
+	IssueNotAnOption                   // a name that is not one of the choices
+	IssueUnknownParam                  // a parameter the game does not have
+	IssueSource                        // a whole source, such as a file or a link, failed to load
+)
+
+// issueError is a rejected value: its English message and its kind.
+type issueError struct {
+	kind IssueKind
+	msg  string
+}
+
+func (e issueError) Error() string {
+	return e.msg
+}
+
+func issuef(kind IssueKind, format string, args ...any) error {
+	return issueError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+// IssueKindOf is the kind of an error returned by Set, ValidateParam or
+// ApplyPreset; other errors are malformed values.
+func IssueKindOf(err error) IssueKind {
+	var e issueError
+	if errors.As(err, &e) {
+		return e.kind
+	}
+	if errors.Is(err, ErrUnknownParam) {
+		return IssueUnknownParam
+	}
+
+	return IssueMalformed
+}
+
+// ConfigIssue describes a parameter that was rejected (error) or ignored (warning).
+type ConfigIssue struct {
+	Param    string
+	Value    string
+	Origin   string
+	Severity Severity
+	Kind     IssueKind
+	Reason   string
+}
+
+func (i ConfigIssue) Error() string {
+	return fmt.Sprintf("%s=%s: %s", i.Param, i.Value, i.Reason)
 }
 
 func NewDefaultConfig() Config {
@@ -17,6 +244,323 @@ func NewDefaultConfig() Config {
 		InitialLevel:   0,
 		SpeedIncrement: 0.25,
 		BallScale:      0.0,
+		BallShape:      BallSquare,
 		Fps:            30,
+
+		PointerLock:      false,
+		MouseSensitivity: 1.0,
+		MouseCurve:       1.0,
+
+		Seed: 0,
+		Mode: ModeClassic,
+
+		Theme: ThemeClassic,
+
+		Lang: LangEnglish,
+
+		Volume: 0.5,
+		Mute:   false,
+
+		ReducedMotion: false,
+
+		ClipSeconds: 10,
+		ClipFps:     15,
+		ClipScale:   0.5,
+		ClipPalette: PaletteTheme,
+
+		Renderer: RendererCanvas,
+		CRT:      false,
+
+		Preset: PresetNormal,
+	}
+}
+
+// Set parses a raw parameter value; ranges are checked by Validate/ValidateParam.
+func (c *Config) Set(param, value string) error {
+	switch param {
+	case ParamDebug:
+		return setBool(&c.Debug, value)
+	case ParamLives:
+		return setInt(&c.InitialLives, value)
+	case ParamLevel:
+		return setInt(&c.InitialLevel, value)
+	case ParamBoost:
+		return setFloat(&c.SpeedIncrement, value)
+	case ParamBallSize:
+		return setFloat(&c.BallScale, value)
+	case ParamFps:
+		return setInt(&c.Fps, value)
+	case ParamPointerLock:
+		return setBool(&c.PointerLock, value)
+	case ParamSensitivity:
+		return setFloat(&c.MouseSensitivity, value)
+	case ParamCurve:
+		return setFloat(&c.MouseCurve, value)
+	case ParamSeed:
+		val, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return issuef(IssueMalformed, "%q is not an integer", value)
+		}
+		c.Seed = val
+		return nil
+	case ParamMode:
+		c.Mode = value
+		return nil
+	case ParamTheme:
+		c.Theme = value
+		return nil
+	case ParamBallShape:
+		c.BallShape = value
+		return nil
+	case ParamLang:
+		c.Lang = value
+		if lang, ok := MatchLang(value); ok {
+			c.Lang = lang
+		}
+		return nil
+	case ParamVolume:
+		return setFloat(&c.Volume, value)
+	case ParamMute:
+		return setBool(&c.Mute, value)
+	case ParamMotion:
+		return setBool(&c.ReducedMotion, value)
+	case ParamClipSeconds:
+		return setInt(&c.ClipSeconds, value)
+	case ParamClipFps:
+		return setInt(&c.ClipFps, value)
+	case ParamClipScale:
+		return setFloat(&c.ClipScale, value)
+	case ParamClipPalette:
+		c.ClipPalette = value
+		return nil
+	case ParamRenderer:
+		c.Renderer = value
+		return nil
+	case ParamCRT:
+		return setBool(&c.CRT, value)
+	case ParamPreset:
+		return c.ApplyPreset(value)
+	}
+
+	return ErrUnknownParam
+}
+
+// ValidateParam checks a single parameter against its allowed range.
+func (c Config) ValidateParam(param string) error {
+	switch param {
+	case ParamLives:
+		return checkIntRange(c.InitialLives, 1, 99)
+	case ParamLevel:
+		return checkIntRange(c.InitialLevel, 0, 50)
+	case ParamBoost:
+		return checkFloatRange(c.SpeedIncrement, 0.0, 1.0)
+	case ParamBallSize:
+		return checkFloatRange(c.BallScale, 0.0, 1.0)
+	case ParamFps:
+		if c.Fps != 30 && c.Fps != 60 {
+			return issuef(IssueNotAnOption, "%d is not 30 or 60", c.Fps)
+		}
+	case ParamSensitivity:
+		return checkFloatRange(c.MouseSensitivity, 0.1, 5.0)
+	case ParamCurve:
+		return checkFloatRange(c.MouseCurve, 1.0, 3.0)
+	case ParamVolume:
+		return checkFloatRange(c.Volume, 0.0, 1.0)
+	case ParamSeed:
+		if c.Seed < 0 {
+			return issuef(IssueOutOfRange, "%d is negative", c.Seed)
+		}
+	case ParamMode:
+		if c.Mode != ModeClassic && c.Mode != ModeChallenge {
+			return issuef(IssueNotAnOption, "%q is not %s or %s", c.Mode, ModeClassic, ModeChallenge)
+		}
+	case ParamTheme:
+		for _, name := range ThemeNames {
+			if c.Theme == name {
+				return nil
+			}
+		}
+		return issuef(IssueNotAnOption, "unknown theme %q", c.Theme)
+	case ParamBallShape:
+		if c.BallShape != BallSquare && c.BallShape != BallRound {
+			return issuef(IssueNotAnOption, "%q is not %s or %s", c.BallShape, BallSquare, BallRound)
+		}
+	case ParamLang:
+		for _, lang := range Languages {
+			if c.Lang == lang {
+				return nil
+			}
+		}
+		return issuef(IssueNotAnOption, "unsupported language %q", c.Lang)
+	case ParamClipSeconds:
+		return checkIntRange(c.ClipSeconds, 1, 30)
+	case ParamClipFps:
+		return checkIntRange(c.ClipFps, 1, 30)
+	case ParamClipScale:
+		return checkFloatRange(c.ClipScale, 0.1, 1.0)
+	case ParamClipPalette:
+		for _, name := range PaletteNames {
+			if c.ClipPalette == name {
+				return nil
+			}
+		}
+		return issuef(IssueNotAnOption, "unknown palette %q", c.ClipPalette)
+	case ParamRenderer:
+		for _, name := range RendererNames {
+			if c.Renderer == name {
+				return nil
+			}
+		}
+		return issuef(IssueNotAnOption, "unknown renderer %q", c.Renderer)
+	}
+
+	return nil
+}
+
+// Validate checks every parameter; rejected values fall back to their defaults
+// and are reported (together with the issues already collected by the provider).
+func (c *Config) Validate() []ConfigIssue {
+	defaults := NewDefaultConfig().Values()
+	values := c.Values()
+
+	for _, param := range ConfigParams {
+		err := c.ValidateParam(param)
+		if err == nil {
+			continue
+		}
+
+		c.Issues = append(c.Issues, ConfigIssue{
+			Param:    param,
+			Value:    values[param],
+			Origin:   c.Origin[param],
+			Severity: SeverityError,
+			Kind:     IssueKindOf(err),
+			Reason:   err.Error(),
+		})
+
+		_ = c.Set(param, defaults[param])
+		if c.Origin != nil {
+			c.Origin[param] = OriginDefault
+		}
+	}
+
+	return c.Issues
+}
+
+// Values returns the configuration as raw parameter values (inverse of Set).
+func (c Config) Values() map[string]string {
+	return map[string]string{
+		ParamDebug:       strconv.FormatBool(c.Debug),
+		ParamLives:       strconv.Itoa(c.InitialLives),
+		ParamLevel:       strconv.Itoa(c.InitialLevel),
+		ParamBoost:       formatFloat(c.SpeedIncrement),
+		ParamBallSize:    formatFloat(c.BallScale),
+		ParamFps:         strconv.Itoa(c.Fps),
+		ParamPointerLock: strconv.FormatBool(c.PointerLock),
+		ParamSensitivity: formatFloat(c.MouseSensitivity),
+		ParamCurve:       formatFloat(c.MouseCurve),
+		ParamSeed:        strconv.FormatInt(c.Seed, 10),
+		ParamMode:        c.Mode,
+		ParamTheme:       c.Theme,
+		ParamBallShape:   c.BallShape,
+		ParamLang:        c.Lang,
+		ParamVolume:      formatFloat(c.Volume),
+		ParamMute:        strconv.FormatBool(c.Mute),
+		ParamMotion:      strconv.FormatBool(c.ReducedMotion),
+		ParamClipSeconds: strconv.Itoa(c.ClipSeconds),
+		ParamClipFps:     strconv.Itoa(c.ClipFps),
+		ParamClipScale:   formatFloat(c.ClipScale),
+		ParamClipPalette: c.ClipPalette,
+		ParamRenderer:    c.Renderer,
+		ParamCRT:         strconv.FormatBool(c.CRT),
+	}
+}
+
+// MatchLang finds the supported language of a tag such as "pt", "pt_br" or "en-US":
+// an exact match (ignoring case) first, then the first language with the same primary subtag.
+func MatchLang(tag string) (string, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	for _, lang := range Languages {
+		if strings.EqualFold(tag, lang) {
+			return lang, true
+		}
+	}
+
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, lang := range Languages {
+		if base, _, _ := strings.Cut(lang, "-"); strings.EqualFold(primary, base) {
+			return lang, true
+		}
+	}
+
+	return "", false
+}
+
+// Trace lists every parameter with its value and origin, sorted by name.
+func (c Config) Trace() []ConfigTrace {
+	if c.Origin == nil {
+		return nil
+	}
+
+	values := c.Values()
+	trace := make([]ConfigTrace, 0, len(values))
+	for param, value := range values {
+		trace = append(trace, ConfigTrace{Param: param, Value: value, Origin: c.Origin[param]})
+	}
+
+	sort.Slice(trace, func(i, j int) bool { return trace[i].Param < trace[j].Param })
+	return trace
+}
+
+func setBool(dst *bool, value string) error {
+	switch value {
+	case "true", "1":
+		*dst = true
+	case "false", "0":
+		*dst = false
+	default:
+		return issuef(IssueMalformed, "%q is not a boolean", value)
 	}
+
+	return nil
+}
+
+func setInt(dst *int, value string) error {
+	val, err := strconv.Atoi(value)
+	if err != nil {
+		return issuef(IssueMalformed, "%q is not an integer", value)
+	}
+
+	*dst = val
+	return nil
+}
+
+func setFloat(dst *float64, value string) error {
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return issuef(IssueMalformed, "%q is not a number", value)
+	}
+
+	*dst = val
+	return nil
+}
+
+func checkIntRange(val, lo, hi int) error {
+	if val < lo || val > hi {
+		return issuef(IssueOutOfRange, "%d is out of range [%d, %d]", val, lo, hi)
+	}
+
+	return nil
+}
+
+func checkFloatRange(val, lo, hi float64) error {
+	if !(val >= lo && val <= hi) { // also rejects NaN
+		return issuef(IssueOutOfRange, "%s is out of range [%s, %s]", formatFloat(val), formatFloat(lo), formatFloat(hi))
+	}
+
+	return nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
 }
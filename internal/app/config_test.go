@@ -0,0 +1,550 @@
+package app
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConfigSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		value   string
+		want    func(c Config) bool
+		wantErr bool
+	}{
+		{
+			name:  "Debug true",
+			param: ParamDebug,
+			value: "true",
+			want:  func(c Config) bool { return c.Debug },
+		},
+		{
+			name:  "Lives in range",
+			param: ParamLives,
+			value: "5",
+			want:  func(c Config) bool { return c.InitialLives == 5 },
+		},
+		{
+			name:  "Lives out of range is parsed (checked by Validate)",
+			param: ParamLives,
+			value: "100",
+			want:  func(c Config) bool { return c.InitialLives == 100 },
+		},
+		{
+			name:    "Level not a number",
+			param:   ParamLevel,
+			value:   "abc",
+			wantErr: true,
+		},
+		{
+			name:  "Boost in range",
+			param: ParamBoost,
+			value: "0.8",
+			want:  func(c Config) bool { return c.SpeedIncrement == 0.8 },
+		},
+		{
+			name:    "Debug not a boolean",
+			param:   ParamDebug,
+			value:   "yes",
+			wantErr: true,
+		},
+		{
+			name:  "FPS 60",
+			param: ParamFps,
+			value: "60",
+			want:  func(c Config) bool { return c.Fps == 60 },
+		},
+		{
+			name:  "Sensitivity in range",
+			param: ParamSensitivity,
+			value: "2.5",
+			want:  func(c Config) bool { return c.MouseSensitivity == 2.5 },
+		},
+		{
+			name:    "Curve not a number",
+			param:   ParamCurve,
+			value:   "fast",
+			wantErr: true,
+		},
+		{
+			name:  "Seed",
+			param: ParamSeed,
+			value: "1234",
+			want:  func(c Config) bool { return c.Seed == 1234 },
+		},
+		{
+			name:  "Challenge mode",
+			param: ParamMode,
+			value: ModeChallenge,
+			want:  func(c Config) bool { return c.Mode == ModeChallenge },
+		},
+		{
+			name:  "Theme",
+			param: ParamTheme,
+			value: ThemeNeon,
+			want:  func(c Config) bool { return c.Theme == ThemeNeon },
+		},
+		{
+			name:  "Round ball",
+			param: ParamBallShape,
+			value: BallRound,
+			want:  func(c Config) bool { return c.BallShape == BallRound },
+		},
+		{
+			name:  "Language tag is normalized",
+			param: ParamLang,
+			value: "pt_br",
+			want:  func(c Config) bool { return c.Lang == LangPortuguese },
+		},
+		{
+			name:  "Volume",
+			param: ParamVolume,
+			value: "0.8",
+			want:  func(c Config) bool { return c.Volume == 0.8 },
+		},
+		{
+			name:  "Mute",
+			param: ParamMute,
+			value: "true",
+			want:  func(c Config) bool { return c.Mute },
+		},
+		{
+			name:  "Reduced motion",
+			param: ParamMotion,
+			value: "1",
+			want:  func(c Config) bool { return c.ReducedMotion },
+		},
+		{
+			name:  "Clip frame rate",
+			param: ParamClipFps,
+			value: "10",
+			want:  func(c Config) bool { return c.ClipFps == 10 },
+		},
+		{
+			name:  "Clip scale",
+			param: ParamClipScale,
+			value: "0.25",
+			want:  func(c Config) bool { return c.ClipScale == 0.25 },
+		},
+		{
+			name:  "Clip palette",
+			param: ParamClipPalette,
+			value: PalettePlan9,
+			want:  func(c Config) bool { return c.ClipPalette == PalettePlan9 },
+		},
+		{
+			name:  "CRT",
+			param: ParamCRT,
+			value: "true",
+			want:  func(c Config) bool { return c.CRT },
+		},
+		{
+			name:  "Renderer",
+			param: ParamRenderer,
+			value: RendererWebGL,
+			want:  func(c Config) bool { return c.Renderer == RendererWebGL },
+		},
+		{
+			name:    "Unknown parameter",
+			param:   "speed",
+			value:   "1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			before := cfg
+
+			err := cfg.Set(tt.param, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if !reflect.DeepEqual(cfg, before) {
+					t.Errorf("Set() changed config on error = %+v, want %+v", cfg, before)
+				}
+				return
+			}
+
+			if !tt.want(cfg) {
+				t.Errorf("Set(%q, %q) not applied: %+v", tt.param, tt.value, cfg)
+			}
+		})
+	}
+}
+
+func TestConfigValidateParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		param   string
+		wantErr bool
+	}{
+		{
+			name:   "Default lives valid",
+			modify: func(c *Config) {},
+			param:  ParamLives,
+		},
+		{
+			name:    "Lives above 99",
+			modify:  func(c *Config) { c.InitialLives = 100 },
+			param:   ParamLives,
+			wantErr: true,
+		},
+		{
+			name:    "Lives below 1",
+			modify:  func(c *Config) { c.InitialLives = 0 },
+			param:   ParamLives,
+			wantErr: true,
+		},
+		{
+			name:    "Level above 50",
+			modify:  func(c *Config) { c.InitialLevel = 51 },
+			param:   ParamLevel,
+			wantErr: true,
+		},
+		{
+			name:    "Boost above 1.0",
+			modify:  func(c *Config) { c.SpeedIncrement = 1.1 },
+			param:   ParamBoost,
+			wantErr: true,
+		},
+		{
+			name:    "Ball size negative",
+			modify:  func(c *Config) { c.BallScale = -0.1 },
+			param:   ParamBallSize,
+			wantErr: true,
+		},
+		{
+			name:   "FPS 60 valid",
+			modify: func(c *Config) { c.Fps = 60 },
+			param:  ParamFps,
+		},
+		{
+			name:    "FPS 45 invalid",
+			modify:  func(c *Config) { c.Fps = 45 },
+			param:   ParamFps,
+			wantErr: true,
+		},
+		{
+			name:    "Boost NaN",
+			modify:  func(c *Config) { c.SpeedIncrement = math.NaN() },
+			param:   ParamBoost,
+			wantErr: true,
+		},
+		{
+			name:    "Sensitivity below 0.1",
+			modify:  func(c *Config) { c.MouseSensitivity = 0.05 },
+			param:   ParamSensitivity,
+			wantErr: true,
+		},
+		{
+			name:    "Curve above 3.0",
+			modify:  func(c *Config) { c.MouseCurve = 3.5 },
+			param:   ParamCurve,
+			wantErr: true,
+		},
+		{
+			name:    "Negative seed",
+			modify:  func(c *Config) { c.Seed = -1 },
+			param:   ParamSeed,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown mode",
+			modify:  func(c *Config) { c.Mode = "survival" },
+			param:   ParamMode,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown theme",
+			modify:  func(c *Config) { c.Theme = "vaporwave" },
+			param:   ParamTheme,
+			wantErr: true,
+		},
+		{
+			name:   "Custom theme",
+			modify: func(c *Config) { c.Theme = ThemeCustom },
+			param:  ParamTheme,
+		},
+		{
+			name:    "Unknown ball shape",
+			modify:  func(c *Config) { c.BallShape = "oval" },
+			param:   ParamBallShape,
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported language",
+			modify:  func(c *Config) { c.Lang = "fr" },
+			param:   ParamLang,
+			wantErr: true,
+		},
+		{
+			name:    "Volume out of range",
+			modify:  func(c *Config) { c.Volume = 1.5 },
+			param:   ParamVolume,
+			wantErr: true,
+		},
+		{
+			name:    "Clip longer than 30 seconds",
+			modify:  func(c *Config) { c.ClipSeconds = 31 },
+			param:   ParamClipSeconds,
+			wantErr: true,
+		},
+		{
+			name:    "Clip frame rate zero",
+			modify:  func(c *Config) { c.ClipFps = 0 },
+			param:   ParamClipFps,
+			wantErr: true,
+		},
+		{
+			name:    "Clip scale above 1",
+			modify:  func(c *Config) { c.ClipScale = 2 },
+			param:   ParamClipScale,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown clip palette",
+			modify:  func(c *Config) { c.ClipPalette = "sepia" },
+			param:   ParamClipPalette,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown renderer",
+			modify:  func(c *Config) { c.Renderer = "vulkan" },
+			param:   ParamRenderer,
+			wantErr: true,
+		},
+		{
+			name:   "Booleans are always valid",
+			modify: func(c *Config) { c.Debug = true },
+			param:  ParamDebug,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tt.modify(&cfg)
+
+			err := cfg.ValidateParam(tt.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateParam(%q) error = %v, wantErr %v", tt.param, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(c *Config)
+		wantIssues []string
+		wantLives  int
+		wantFps    int
+	}{
+		{
+			name:      "Valid config - no issues",
+			modify:    func(c *Config) {},
+			wantLives: 3,
+			wantFps:   30,
+		},
+		{
+			name: "Invalid values fall back to defaults",
+			modify: func(c *Config) {
+				c.InitialLives = 500
+				c.Fps = 45
+			},
+			wantIssues: []string{ParamLives, ParamFps},
+			wantLives:  3,
+			wantFps:    30,
+		},
+		{
+			name: "Provider issues are kept",
+			modify: func(c *Config) {
+				c.Issues = []ConfigIssue{{Param: "speed", Severity: SeverityWarning}}
+				c.InitialLives = 5
+			},
+			wantIssues: []string{"speed"},
+			wantLives:  5,
+			wantFps:    30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Origin = map[string]string{ParamLives: "url", ParamFps: "url"}
+			tt.modify(&cfg)
+
+			issues := cfg.Validate()
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.Param)
+			}
+			if !reflect.DeepEqual(got, tt.wantIssues) {
+				t.Errorf("Validate() issues = %v, want %v", got, tt.wantIssues)
+			}
+			if cfg.InitialLives != tt.wantLives {
+				t.Errorf("Validate() InitialLives = %v, want %v", cfg.InitialLives, tt.wantLives)
+			}
+			if cfg.Fps != tt.wantFps {
+				t.Errorf("Validate() Fps = %v, want %v", cfg.Fps, tt.wantFps)
+			}
+			for _, issue := range issues {
+				if issue.Severity == SeverityError && cfg.Origin[issue.Param] != OriginDefault {
+					t.Errorf("Validate() Origin[%s] = %v, want %v", issue.Param, cfg.Origin[issue.Param], OriginDefault)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueKindOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+		value string
+		want  IssueKind
+	}{
+		{name: "Not a number", param: ParamBoost, value: "fast", want: IssueMalformed},
+		{name: "Not a boolean", param: ParamDebug, value: "maybe", want: IssueMalformed},
+		{name: "Out of range", param: ParamLives, value: "500", want: IssueOutOfRange},
+		{name: "Not an option", param: ParamBallShape, value: "star", want: IssueNotAnOption},
+		{name: "Unsupported fps", param: ParamFps, value: "45", want: IssueNotAnOption},
+		{name: "Unknown parameter", param: "speed", value: "9", want: IssueUnknownParam},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			err := cfg.Set(tt.param, tt.value)
+			if err == nil {
+				err = cfg.ValidateParam(tt.param)
+			}
+			if err == nil {
+				t.Fatalf("Set(%s, %s) error = nil, want one", tt.param, tt.value)
+			}
+			if got := IssueKindOf(err); got != tt.want {
+				t.Errorf("IssueKindOf(%v) = %v, want %v", err, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := LookupPreset("nightmare"); IssueKindOf(err) != IssueNotAnOption {
+		t.Errorf("IssueKindOf(%v) = %v, want %v", err, IssueKindOf(err), IssueNotAnOption)
+	}
+}
+
+func TestConfigValuesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "Default config",
+			cfg:  NewDefaultConfig(),
+		},
+		{
+			name: "Custom config",
+			cfg: Config{
+				Debug:            true,
+				InitialLives:     7,
+				InitialLevel:     12,
+				SpeedIncrement:   0.35,
+				BallScale:        0.7,
+				Fps:              60,
+				PointerLock:      true,
+				MouseSensitivity: 1.5,
+				MouseCurve:       2.0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := tt.cfg.Values()
+			if len(values) != len(ConfigParams) {
+				t.Errorf("Values() len = %v, want %v", len(values), len(ConfigParams))
+			}
+
+			got := Config{Preset: tt.cfg.Preset}
+			for param, value := range values {
+				if err := got.Set(param, value); err != nil {
+					t.Fatalf("Set(%q, %q) error = %v", param, value, err)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.cfg) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.cfg)
+			}
+		})
+	}
+}
+
+func TestConfigTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		origin    map[string]string
+		wantLen   int
+		wantFirst ConfigTrace
+	}{
+		{
+			name:    "No origin - no trace",
+			origin:  nil,
+			wantLen: 0,
+		},
+		{
+			name:      "Origin sorted by parameter",
+			origin:    map[string]string{ParamBallShape: "url"},
+			wantLen:   len(ConfigParams),
+			wantFirst: ConfigTrace{Param: ParamBallShape, Value: BallSquare, Origin: "url"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Origin = tt.origin
+
+			got := cfg.Trace()
+			if len(got) != tt.wantLen {
+				t.Fatalf("Trace() len = %v, want %v", len(got), tt.wantLen)
+			}
+			if tt.wantLen > 0 && got[0] != tt.wantFirst {
+				t.Errorf("Trace()[0] = %+v, want %+v", got[0], tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestMatchLang(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{name: "Exact", tag: "pt-BR", want: LangPortuguese, wantOK: true},
+		{name: "Case and separator", tag: " PT_br ", want: LangPortuguese, wantOK: true},
+		{name: "Primary subtag", tag: "pt", want: LangPortuguese, wantOK: true},
+		{name: "Other region", tag: "en-GB", want: LangEnglish, wantOK: true},
+		{name: "Unsupported", tag: "fr-FR", wantOK: false},
+		{name: "Empty", tag: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MatchLang(tt.tag)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MatchLang(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
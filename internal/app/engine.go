@@ -1,5 +1,7 @@
 package app
 
+import "math"
+
 func (p *Squash) Update() {
 	if p.State != StatePlaying {
 		return
@@ -27,6 +29,15 @@ func (p *Squash) CalcMovePaddle(axisY float64) {
 	}
 }
 
+// CalcMovePaddleRelative moves the paddle by a relative mouse delta (pointer lock mode).
+func (p *Squash) CalcMovePaddleRelative(deltaY float64) {
+	if p.State != StatePlaying {
+		return
+	}
+
+	p.SetPaddlePosition(p.PaddleY + calcSensitivityCurve(deltaY, p.MouseSensitivity, p.MouseCurve))
+}
+
 func (p *Squash) SetPaddlePosition(y float64) {
 	if y < 0 {
 		p.PaddleY = 0
@@ -38,35 +49,103 @@ func (p *Squash) SetPaddlePosition(y float64) {
 }
 
 func (p *Squash) calcCollisionWithWalls() {
+	if p.BallShape == BallRound {
+		p.calcCollisionWithWallsRound()
+		return
+	}
+
 	// Top and Bottom walls
 	if p.BallY <= 0 || p.BallY >= p.Height-p.BallSize {
 		p.BallDY = -p.BallDY
+		p.emit(EventWallBounce)
 	}
 
 	// Right wall
 	if p.BallX >= p.Width-p.BallSize {
 		p.BallDX = -p.BallDX
 		p.BallX = p.Width - p.BallSize - 1
+		p.emit(EventWallBounce)
 	}
 }
 
 func (p *Squash) calcCollisionPaddle() {
+	if p.BallShape == BallRound {
+		p.calcCollisionPaddleRound()
+		return
+	}
+
 	impactZone := p.PaddleX + p.PaddleW
 	if p.BallX+p.BallSize >= p.PaddleX && p.BallX <= impactZone {
 		if p.BallY+p.BallSize >= p.PaddleY && p.BallY <= p.PaddleY+p.PaddleH {
 			p.BallDX = -p.BallDX
 			p.BallX = impactZone + 1
 			p.Score += PointsPerCollision
+			p.emit(EventPaddleHit)
 		}
 	}
 }
 
+// calcCollisionWithWallsRound reflects the ball on the top, bottom and right walls
+// when the circle (inscribed in the BallSize box) touches them while moving towards them.
+func (p *Squash) calcCollisionWithWallsRound() {
+	radius := p.BallSize / 2
+
+	if p.BallY <= 0 && p.BallDY < 0 {
+		p.BallDY = -p.BallDY
+		p.BallY = 0
+		p.emit(EventWallBounce)
+	} else if p.BallY+2*radius >= p.Height && p.BallDY > 0 {
+		p.BallDY = -p.BallDY
+		p.BallY = p.Height - 2*radius
+		p.emit(EventWallBounce)
+	}
+
+	if p.BallX+2*radius >= p.Width && p.BallDX > 0 {
+		p.BallDX = -p.BallDX
+		p.BallX = p.Width - 2*radius
+		p.emit(EventWallBounce)
+	}
+}
+
+// calcCollisionPaddleRound reflects the ball on the paddle face or corner it hits
+// and pushes it out of the paddle along the contact normal.
+func (p *Squash) calcCollisionPaddleRound() {
+	radius := p.BallSize / 2
+	cx, cy := p.BallX+radius, p.BallY+radius
+
+	nx, ny, hit := calcCircleRectNormal(cx, cy, radius, p.PaddleX, p.PaddleY, p.PaddleW, p.PaddleH)
+	if !hit {
+		return
+	}
+
+	dx, dy := calcReflect(p.BallDX, p.BallDY, nx, ny)
+	if dx == p.BallDX && dy == p.BallDY {
+		return // already moving away
+	}
+
+	p.BallDX, p.BallDY = dx, dy
+
+	// Move the center to the contact point plus the radius along the normal
+	px := math.Max(p.PaddleX, math.Min(cx, p.PaddleX+p.PaddleW))
+	py := math.Max(p.PaddleY, math.Min(cy, p.PaddleY+p.PaddleH))
+	if px == cx && py == cy {
+		px = p.PaddleX + p.PaddleW // center inside the paddle
+	}
+	p.BallX = px + nx*(radius+1) - radius
+	p.BallY = py + ny*(radius+1) - radius
+
+	p.Score += PointsPerCollision
+	p.emit(EventPaddleHit)
+}
+
 func (p *Squash) calcLostLive() {
 	if p.BallX+p.BallSize <= 0 {
 		p.Lives--
 		if p.Lives <= 0 {
 			p.State = StateGameOver
+			p.emit(EventGameOver)
 		} else {
+			p.emit(EventLifeLost)
 			p.respawnBall()
 		}
 	}
@@ -77,6 +156,7 @@ func (p *Squash) calcNextLevel() {
 	if currentLevel > p.LastLevel {
 
 		p.LastLevel = currentLevel
+		p.emit(EventLevelUp)
 		increment := BaseSpeedBall * p.SpeedIncrement
 
 		if p.BallDX > 0 {
@@ -97,3 +177,40 @@ func (p *Squash) calcMoveBall() {
 	p.BallX += p.BallDX * p.DeltaTime
 	p.BallY += p.BallDY * p.DeltaTime
 }
+
+// calcSensitivityCurve scales a mouse delta: sign(delta) * sensitivity * |delta|^curve.
+// Config.Validate checks the sensitivity and the curve.
+func calcSensitivityCurve(delta, sensitivity, curve float64) float64 {
+	return math.Copysign(sensitivity*math.Pow(math.Abs(delta), curve), delta)
+}
+
+// calcCircleRectNormal tests a circle against a rectangle and returns the unit
+// normal pointing from the closest point of the rectangle to the circle center.
+// A center inside the rectangle gets the normal of the paddle face (+x).
+func calcCircleRectNormal(cx, cy, radius, rx, ry, rw, rh float64) (float64, float64, bool) {
+	px := math.Max(rx, math.Min(cx, rx+rw))
+	py := math.Max(ry, math.Min(cy, ry+rh))
+
+	dx, dy := cx-px, cy-py
+	dist := math.Hypot(dx, dy)
+	if dist > radius {
+		return 0, 0, false
+	}
+
+	if dist == 0 {
+		return 1, 0, true
+	}
+
+	return dx / dist, dy / dist, true
+}
+
+// calcReflect mirrors the velocity on the surface with normal (nx, ny);
+// a velocity already leaving the surface is returned unchanged.
+func calcReflect(dx, dy, nx, ny float64) (float64, float64) {
+	dot := dx*nx + dy*ny
+	if dot >= 0 {
+		return dx, dy
+	}
+
+	return dx - 2*dot*nx, dy - 2*dot*ny
+}
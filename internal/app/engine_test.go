@@ -1,6 +1,7 @@
 package app
 
 import (
+	"math"
 	"testing"
 )
 
@@ -137,6 +138,139 @@ func TestCalcMovePaddle(t *testing.T) {
 	}
 }
 
+func TestCalcMovePaddleRelative(t *testing.T) {
+	tests := []struct {
+		name        string
+		deltaY      float64
+		sensitivity float64
+		curve       float64
+		state       GameState
+		wantPaddleY float64
+	}{
+		{
+			name:        "Move down with linear sensitivity",
+			deltaY:      10.0,
+			sensitivity: 1.0,
+			curve:       1.0,
+			state:       StatePlaying,
+			wantPaddleY: 280.0, // 270 + 10
+		},
+		{
+			name:        "Move up with double sensitivity",
+			deltaY:      -10.0,
+			sensitivity: 2.0,
+			curve:       1.0,
+			state:       StatePlaying,
+			wantPaddleY: 250.0, // 270 - 20
+		},
+		{
+			name:        "Move down with quadratic curve",
+			deltaY:      4.0,
+			sensitivity: 1.0,
+			curve:       2.0,
+			state:       StatePlaying,
+			wantPaddleY: 286.0, // 270 + 4^2
+		},
+		{
+			name:        "Move beyond top - should clamp",
+			deltaY:      -500.0,
+			sensitivity: 1.0,
+			curve:       1.0,
+			state:       StatePlaying,
+			wantPaddleY: 0.0,
+		},
+		{
+			name:        "Move beyond bottom - should clamp",
+			deltaY:      500.0,
+			sensitivity: 1.0,
+			curve:       1.0,
+			state:       StatePlaying,
+			wantPaddleY: 540.0,
+		},
+		{
+			name:        "Move when paused - should not move",
+			deltaY:      10.0,
+			sensitivity: 1.0,
+			curve:       1.0,
+			state:       StatePaused,
+			wantPaddleY: 270.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				Debug:            false,
+				Fps:              60,
+				DeltaTime:        0.016,
+				InitialLives:     3,
+				InitialLevel:     0,
+				SpeedIncrement:   0.5,
+				BallScale:        0.5,
+				PointerLock:      true,
+				MouseSensitivity: tt.sensitivity,
+				MouseCurve:       tt.curve,
+			}
+			game := NewSquash(800, 600, cfg)
+			game.State = tt.state
+
+			game.CalcMovePaddleRelative(tt.deltaY)
+
+			if game.PaddleY != tt.wantPaddleY {
+				t.Errorf("CalcMovePaddleRelative() PaddleY = %v, want %v", game.PaddleY, tt.wantPaddleY)
+			}
+		})
+	}
+}
+
+func TestCalcSensitivityCurve(t *testing.T) {
+	tests := []struct {
+		name        string
+		delta       float64
+		sensitivity float64
+		curve       float64
+		want        float64
+	}{
+		{
+			name:        "Linear curve keeps delta",
+			delta:       5.0,
+			sensitivity: 1.0,
+			curve:       1.0,
+			want:        5.0,
+		},
+		{
+			name:        "Sensitivity scales delta",
+			delta:       5.0,
+			sensitivity: 0.5,
+			curve:       1.0,
+			want:        2.5,
+		},
+		{
+			name:        "Curve keeps sign of negative delta",
+			delta:       -3.0,
+			sensitivity: 1.0,
+			curve:       2.0,
+			want:        -9.0,
+		},
+		{
+			name:        "Zero delta",
+			delta:       0.0,
+			sensitivity: 2.0,
+			curve:       2.0,
+			want:        0.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calcSensitivityCurve(tt.delta, tt.sensitivity, tt.curve)
+			if got != tt.want {
+				t.Errorf("calcSensitivityCurve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSetPaddlePosition(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -759,3 +893,223 @@ func TestCalcMoveBall(t *testing.T) {
 		})
 	}
 }
+
+func TestCalcCollisionWithWallsRound(t *testing.T) {
+	tests := []struct {
+		name       string
+		ballX      float64
+		ballY      float64
+		ballDX     float64
+		ballDY     float64
+		wantBallX  float64
+		wantBallY  float64
+		wantBallDX float64
+		wantBallDY float64
+	}{
+		{
+			name:       "Top wall reflects and clamps",
+			ballX:      400.0,
+			ballY:      -2.0,
+			ballDX:     -300.0,
+			ballDY:     -300.0,
+			wantBallX:  400.0,
+			wantBallY:  0.0,
+			wantBallDX: -300.0,
+			wantBallDY: 300.0,
+		},
+		{
+			name:       "Top wall while leaving it is ignored",
+			ballX:      400.0,
+			ballY:      -2.0,
+			ballDX:     -300.0,
+			ballDY:     300.0,
+			wantBallX:  400.0,
+			wantBallY:  -2.0,
+			wantBallDX: -300.0,
+			wantBallDY: 300.0,
+		},
+		{
+			name:       "Bottom wall reflects and clamps",
+			ballX:      400.0,
+			ballY:      590.0,
+			ballDX:     -300.0,
+			ballDY:     300.0,
+			wantBallX:  400.0,
+			wantBallY:  585.0,
+			wantBallDX: -300.0,
+			wantBallDY: -300.0,
+		},
+		{
+			name:       "Right wall reflects and clamps",
+			ballX:      790.0,
+			ballY:      300.0,
+			ballDX:     300.0,
+			ballDY:     300.0,
+			wantBallX:  785.0,
+			wantBallY:  300.0,
+			wantBallDX: -300.0,
+			wantBallDY: 300.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.BallScale = 0.5 // radius 7.5
+			cfg.BallShape = BallRound
+			game := NewSquash(800, 600, cfg)
+			game.BallX, game.BallY = tt.ballX, tt.ballY
+			game.BallDX, game.BallDY = tt.ballDX, tt.ballDY
+
+			game.calcCollisionWithWalls()
+
+			if game.BallX != tt.wantBallX || game.BallY != tt.wantBallY {
+				t.Errorf("calcCollisionWithWalls() ball = (%v, %v), want (%v, %v)", game.BallX, game.BallY, tt.wantBallX, tt.wantBallY)
+			}
+			if game.BallDX != tt.wantBallDX || game.BallDY != tt.wantBallDY {
+				t.Errorf("calcCollisionWithWalls() velocity = (%v, %v), want (%v, %v)", game.BallDX, game.BallDY, tt.wantBallDX, tt.wantBallDY)
+			}
+		})
+	}
+}
+
+func TestCalcCollisionPaddleRound(t *testing.T) {
+	// Paddle [10, 20] x [270, 330]; ball radius 7.5, positioned by its center.
+	tests := []struct {
+		name       string
+		centerX    float64
+		centerY    float64
+		ballDX     float64
+		ballDY     float64
+		wantBallDX float64
+		wantBallDY float64
+		wantScore  int
+	}{
+		{
+			name:       "Face hit reflects on the x axis",
+			centerX:    25.0,
+			centerY:    300.0,
+			ballDX:     -300.0,
+			ballDY:     200.0,
+			wantBallDX: 300.0,
+			wantBallDY: 200.0,
+			wantScore:  10,
+		},
+		{
+			name:       "Corner hit reflects on the diagonal",
+			centerX:    25.0,
+			centerY:    265.0,
+			ballDX:     -300.0,
+			ballDY:     300.0,
+			wantBallDX: 300.0,
+			wantBallDY: -300.0,
+			wantScore:  10,
+		},
+		{
+			name:       "Top edge hit reflects on the y axis",
+			centerX:    15.0,
+			centerY:    263.0,
+			ballDX:     -300.0,
+			ballDY:     300.0,
+			wantBallDX: -300.0,
+			wantBallDY: -300.0,
+			wantScore:  10,
+		},
+		{
+			name:       "Ball leaving the paddle is ignored",
+			centerX:    25.0,
+			centerY:    300.0,
+			ballDX:     300.0,
+			ballDY:     200.0,
+			wantBallDX: 300.0,
+			wantBallDY: 200.0,
+		},
+		{
+			name:       "Miss - out of reach",
+			centerX:    30.0,
+			centerY:    300.0,
+			ballDX:     -300.0,
+			ballDY:     200.0,
+			wantBallDX: -300.0,
+			wantBallDY: 200.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.BallScale = 0.5
+			cfg.BallShape = BallRound
+			game := NewSquash(800, 600, cfg)
+			game.PaddleY = 270.0
+			game.BallX, game.BallY = tt.centerX-7.5, tt.centerY-7.5
+			game.BallDX, game.BallDY = tt.ballDX, tt.ballDY
+
+			game.calcCollisionPaddle()
+
+			if math.Abs(game.BallDX-tt.wantBallDX) > 1e-9 || math.Abs(game.BallDY-tt.wantBallDY) > 1e-9 {
+				t.Errorf("calcCollisionPaddle() velocity = (%v, %v), want (%v, %v)", game.BallDX, game.BallDY, tt.wantBallDX, tt.wantBallDY)
+			}
+			if game.Score != tt.wantScore {
+				t.Errorf("calcCollisionPaddle() Score = %v, want %v", game.Score, tt.wantScore)
+			}
+
+			// After a hit the circle no longer overlaps the paddle
+			if tt.wantScore > 0 {
+				_, _, hit := calcCircleRectNormal(game.BallX+7.5, game.BallY+7.5, 7.5, game.PaddleX, game.PaddleY, game.PaddleW, game.PaddleH)
+				if hit {
+					t.Errorf("calcCollisionPaddle() ball still overlaps the paddle at (%v, %v)", game.BallX, game.BallY)
+				}
+			}
+		})
+	}
+}
+
+func TestCalcReflect(t *testing.T) {
+	tests := []struct {
+		name   string
+		dx     float64
+		dy     float64
+		nx     float64
+		ny     float64
+		wantDX float64
+		wantDY float64
+	}{
+		{
+			name:   "Head-on",
+			dx:     -300.0,
+			dy:     0.0,
+			nx:     1.0,
+			ny:     0.0,
+			wantDX: 300.0,
+			wantDY: 0.0,
+		},
+		{
+			name:   "Glancing keeps the tangent",
+			dx:     -300.0,
+			dy:     100.0,
+			nx:     1.0,
+			ny:     0.0,
+			wantDX: 300.0,
+			wantDY: 100.0,
+		},
+		{
+			name:   "Leaving the surface",
+			dx:     300.0,
+			dy:     100.0,
+			nx:     1.0,
+			ny:     0.0,
+			wantDX: 300.0,
+			wantDY: 100.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dx, dy := calcReflect(tt.dx, tt.dy, tt.nx, tt.ny)
+			if dx != tt.wantDX || dy != tt.wantDY {
+				t.Errorf("calcReflect() = (%v, %v), want (%v, %v)", dx, dy, tt.wantDX, tt.wantDY)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+// EventKind is something that happened in the court during Update.
+type EventKind int
+
+const (
+	EventPaddleHit EventKind = iota
+	EventWallBounce
+	EventLifeLost
+	EventLevelUp
+	EventGameOver
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventPaddleHit:
+		return "paddle-hit"
+	case EventWallBounce:
+		return "wall-bounce"
+	case EventLifeLost:
+		return "life-lost"
+	case EventLevelUp:
+		return "level-up"
+	case EventGameOver:
+		return "game-over"
+	}
+
+	return "unknown"
+}
+
+// Event is emitted by the engine with the ball center at that moment, so
+// adapters (audio, effects) react to what happened instead of polling the state.
+type Event struct {
+	Kind EventKind
+	X, Y float64
+}
+
+// DrainEvents returns the events emitted since the last call and clears them.
+func (p *Squash) DrainEvents() []Event {
+	events := p.events
+	p.events = nil
+	return events
+}
+
+func (p *Squash) emit(kind EventKind) {
+	radius := p.BallSize / 2
+	p.events = append(p.events, Event{Kind: kind, X: p.BallX + radius, Y: p.BallY + radius})
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpdateEmitsEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape string
+		setup func(p *Squash)
+		want  []EventKind
+	}{
+		{
+			name: "Quiet frame",
+			setup: func(p *Squash) {
+				p.BallX, p.BallY = 400, 300
+			},
+			want: nil,
+		},
+		{
+			name: "Top wall",
+			setup: func(p *Squash) {
+				p.BallX, p.BallY, p.BallDY = 400, -1, -200
+			},
+			want: []EventKind{EventWallBounce},
+		},
+		{
+			name:  "Right wall with round ball",
+			shape: BallRound,
+			setup: func(p *Squash) {
+				p.BallX, p.BallY, p.BallDX, p.BallDY = 800, 300, 200, 0
+			},
+			want: []EventKind{EventWallBounce},
+		},
+		{
+			name: "Paddle hit",
+			setup: func(p *Squash) {
+				p.BallX, p.BallY, p.BallDX, p.BallDY = p.PaddleX+p.PaddleW, p.PaddleY+10, -200, 0
+			},
+			want: []EventKind{EventPaddleHit},
+		},
+		{
+			name:  "Paddle hit with round ball",
+			shape: BallRound,
+			setup: func(p *Squash) {
+				p.BallX, p.BallY, p.BallDX, p.BallDY = p.PaddleX+p.PaddleW, p.PaddleY+10, -200, 0
+			},
+			want: []EventKind{EventPaddleHit},
+		},
+		{
+			name: "Level up",
+			setup: func(p *Squash) {
+				p.BallX, p.BallY = 400, 300
+				p.Score = PointsPerLevel
+			},
+			want: []EventKind{EventLevelUp},
+		},
+		{
+			name: "Life lost",
+			setup: func(p *Squash) {
+				p.BallX, p.BallY, p.BallDX = -50, 300, -200
+			},
+			want: []EventKind{EventLifeLost},
+		},
+		{
+			name: "Last life lost",
+			setup: func(p *Squash) {
+				p.BallX, p.BallY, p.BallDX = -50, 300, -200
+				p.Lives = 1
+			},
+			want: []EventKind{EventGameOver},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.DeltaTime = 0.016
+			if tt.shape != "" {
+				cfg.BallShape = tt.shape
+			}
+			game := NewSquash(800, 600, cfg)
+			game.State = StatePlaying
+			game.BallDX, game.BallDY = 200, 200
+			tt.setup(game)
+
+			game.Update()
+
+			var got []EventKind
+			for _, e := range game.DrainEvents() {
+				got = append(got, e.Kind)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Update() events = %v, want %v", got, tt.want)
+			}
+
+			if again := game.DrainEvents(); again != nil {
+				t.Errorf("DrainEvents() after drain = %v, want none", again)
+			}
+		})
+	}
+}
+
+func TestEventPosition(t *testing.T) {
+	game := NewSquash(800, 600, NewDefaultConfig())
+	game.BallX, game.BallY, game.BallSize = 100, 200, 10
+
+	game.emit(EventWallBounce)
+
+	want := []Event{{Kind: EventWallBounce, X: 105, Y: 205}}
+	if got := game.DrainEvents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DrainEvents() = %v, want %v", got, want)
+	}
+}
+
+func TestResetClearsEvents(t *testing.T) {
+	game := NewSquash(800, 600, NewDefaultConfig())
+	game.emit(EventGameOver)
+
+	game.Reset(NewDefaultConfig())
+
+	if got := game.DrainEvents(); got != nil {
+		t.Errorf("DrainEvents() after Reset = %v, want none", got)
+	}
+}
@@ -10,6 +10,13 @@ const (
 	PointsPerCollision int     = 10
 )
 
+// Logical court size: the engine always plays on 800x600 units and the
+// renderers scale it to the screen, so speed and fairness do not depend on the window.
+const (
+	CourtWidth  float64 = 800.0
+	CourtHeight float64 = 600.0
+)
+
 type GameState int
 
 const (
@@ -17,6 +24,7 @@ const (
 	StatePlaying
 	StatePaused
 	StateGameOver
+	StateSettings
 )
 
 type Squash struct {
@@ -33,13 +41,39 @@ type Squash struct {
 
 	// elements
 	BallSize               float64
+	BallShape              string
 	BallX, BallY           float64
 	BallDX, BallDY         float64 // direction
 	BallSpawnX, BallSpawnY float64
 	PaddleX, PaddleY       float64
 	PaddleW, PaddleH       float64
 
-	DebugMode bool
+	// Pointer lock (relative mouse mode)
+	PointerLock      bool
+	MouseSensitivity float64
+	MouseCurve       float64
+
+	Preset   string
+	Theme    string
+	Lang     string
+	Settings Settings
+
+	// ReducedMotion asks the frontends to skip motion effects.
+	ReducedMotion bool
+	// CRT asks the frontends for the CRT look.
+	CRT bool
+
+	// Challenge
+	Seed       int64
+	Mode       string
+	ShareToken string
+	rng        *rand.Rand
+
+	DebugMode    bool
+	ConfigTrace  []ConfigTrace
+	ConfigIssues []ConfigIssue
+
+	events []Event
 }
 
 func NewSquash(w, h float64, cfg Config) *Squash {
@@ -59,6 +93,7 @@ func NewSquash(w, h float64, cfg Config) *Squash {
 
 func (p *Squash) Reset(cfg Config) {
 	p.loadConfigDefaul(cfg)
+	p.seedRandom(cfg.Seed)
 	p.BallSize = calcBallSize(cfg.BallScale)
 	p.PaddleY = calcRespawPaddleY(p.Height, p.PaddleH)
 	p.respawnBall()
@@ -72,11 +107,25 @@ func (p *Squash) loadConfigDefaul(cfg Config) {
 	p.LastLevel = cfg.InitialLevel
 	p.SpeedIncrement = cfg.SpeedIncrement
 	p.Score = cfg.InitialLevel * 100
+	p.PointerLock = cfg.PointerLock
+	p.MouseSensitivity = cfg.MouseSensitivity
+	p.MouseCurve = cfg.MouseCurve
+	p.Preset = cfg.Preset
+	p.Theme = cfg.Theme
+	p.Lang = cfg.Lang
+	p.ReducedMotion = cfg.ReducedMotion
+	p.CRT = cfg.CRT
+	p.BallShape = cfg.BallShape
+	p.Mode = cfg.Mode
+	p.ShareToken = ""
+	p.events = nil
+	p.ConfigTrace = cfg.Trace()
+	p.ConfigIssues = cfg.Issues
 }
 
 func (p *Squash) respawnBall() {
 	p.BallX = p.Width / 2
-	p.BallY = calcBallStartY(p.Height, rand.Float64())
+	p.BallY = calcBallStartY(p.Height, p.random())
 
 	p.BallSpawnX = p.BallX
 	p.BallSpawnY = p.BallY
@@ -85,27 +134,36 @@ func (p *Squash) respawnBall() {
 	p.BallDX = BaseSpeedBall * factor
 	p.BallDY = BaseSpeedBall * factor
 
-	p.BallDX, p.BallDY = calcRandomDirectionStartBall(p.BallDX, p.BallDY)
+	p.BallDX, p.BallDY = calcRandomDirectionStartBall(p.BallDX, p.BallDY, p.random(), p.random())
 }
 
-func calcSpeedFactor(level int, increment float64) float64 {
-	if level < 0 || level > 99 {
-		level = 0
+// seedRandom makes the ball spawns reproducible; without a seed one is picked,
+// so the game just played can still be shared.
+func (p *Squash) seedRandom(seed int64) {
+	if seed == 0 {
+		seed = rand.Int63n(1<<31-1) + 1
 	}
 
-	if increment < 0 || increment > 1.0 {
-		return 1.0
+	p.Seed = seed
+	p.rng = rand.New(rand.NewSource(seed))
+}
+
+func (p *Squash) random() float64 {
+	if p.rng == nil {
+		return rand.Float64()
 	}
 
+	return p.rng.Float64()
+}
+
+// calcSpeedFactor trusts the level and the boost: Config.Validate checks them.
+func calcSpeedFactor(level int, increment float64) float64 {
 	return 1.0 + (float64(level) * increment)
 }
 
+// calcBallSize trusts scale: Config.Validate checks it.
 func calcBallSize(scale float64) float64 {
 	baseSize := 10.0
-	if scale < 0 || scale > 1.0 {
-		return baseSize
-	}
-
 	return baseSize * (1.0 + scale)
 }
 
@@ -119,12 +177,12 @@ func calcRespawPaddleY(height, paddleH float64) float64 {
 	return (height / 2) - (paddleH / 2)
 }
 
-func calcRandomDirectionStartBall(ballDX, ballDY float64) (float64, float64) {
-	if rand.Float64() > 0.5 {
+func calcRandomDirectionStartBall(ballDX, ballDY, randomX, randomY float64) (float64, float64) {
+	if randomX > 0.5 {
 		ballDX *= -1
 	}
 
-	if rand.Float64() > 0.5 {
+	if randomY > 0.5 {
 		ballDY *= -1
 	}
 
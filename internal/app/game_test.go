@@ -1,6 +1,7 @@
 package app
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -35,30 +36,6 @@ func TestCalcSpeedFactor(t *testing.T) {
 			increment: 0.5,
 			want:      6.0,
 		},
-		{
-			name:      "Level negative - Should clamp to 0",
-			level:     -1,
-			increment: 0.5,
-			want:      1.0,
-		},
-		{
-			name:      "Level 100 - Should clamp to 99",
-			level:     100,
-			increment: 0.5,
-			want:      1.0,
-		},
-		{
-			name:      "Increment negative - Should return 1.0",
-			level:     5,
-			increment: -0.1,
-			want:      1.0,
-		},
-		{
-			name:      "Increment above 1.0 - Should return 1.0",
-			level:     5,
-			increment: 1.5,
-			want:      1.0,
-		},
 		{
 			name:      "Zero increment - No speed increase",
 			level:     10,
@@ -109,16 +86,6 @@ func TestCalcBallSize(t *testing.T) {
 			scale: 0.25,
 			want:  12.5,
 		},
-		{
-			name:  "Negative scale - Should return base",
-			scale: -0.5,
-			want:  10.0,
-		},
-		{
-			name:  "Above max scale - Should return base",
-			scale: 1.5,
-			want:  10.0,
-		},
 	}
 
 	for _, tt := range tests {
@@ -259,7 +226,7 @@ func TestCalcRandomDirectionStartBall(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotDX, gotDY := calcRandomDirectionStartBall(tt.ballDX, tt.ballDY)
+			gotDX, gotDY := calcRandomDirectionStartBall(tt.ballDX, tt.ballDY, rand.Float64(), rand.Float64())
 
 			// Check that the absolute values are preserved
 			if absFloat(gotDX) != absFloat(tt.ballDX) {
@@ -553,6 +520,54 @@ func TestNewDefaultConfig(t *testing.T) {
 			if cfg.Fps != 30 {
 				t.Errorf("NewDefaultConfig() Fps = %v, want %v", cfg.Fps, 30)
 			}
+			if cfg.PointerLock != false {
+				t.Errorf("NewDefaultConfig() PointerLock = %v, want %v", cfg.PointerLock, false)
+			}
+			if cfg.MouseSensitivity != 1.0 {
+				t.Errorf("NewDefaultConfig() MouseSensitivity = %v, want %v", cfg.MouseSensitivity, 1.0)
+			}
+			if cfg.MouseCurve != 1.0 {
+				t.Errorf("NewDefaultConfig() MouseCurve = %v, want %v", cfg.MouseCurve, 1.0)
+			}
+		})
+	}
+}
+
+func TestSquashSeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     int64
+		wantSeed bool
+	}{
+		{
+			name:     "Fixed seed replays the same serve",
+			seed:     1234,
+			wantSeed: true,
+		},
+		{
+			name: "Zero seed picks a shareable seed",
+			seed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Seed = tt.seed
+
+			first := NewSquash(800, 600, cfg)
+			if first.Seed <= 0 {
+				t.Fatalf("Seed = %d, want a positive seed", first.Seed)
+			}
+			if tt.wantSeed && first.Seed != tt.seed {
+				t.Errorf("Seed = %d, want %d", first.Seed, tt.seed)
+			}
+
+			cfg.Seed = first.Seed
+			second := NewSquash(800, 600, cfg)
+			if first.BallY != second.BallY || first.BallDX != second.BallDX || first.BallDY != second.BallDY {
+				t.Errorf("serve = (%v, %v), want (%v, %v)", second.BallDX, second.BallDY, first.BallDX, first.BallDY)
+			}
 		})
 	}
 }
@@ -0,0 +1,129 @@
+package app
+
+import "fmt"
+
+const ParamPreset = "preset"
+
+const (
+	PresetEasy   = "easy"
+	PresetNormal = "normal"
+	PresetHard   = "hard"
+	PresetInsane = "insane"
+	PresetCustom = "custom"
+)
+
+// Preset is a named set of parameter values; individual parameters may still override it.
+type Preset struct {
+	Name   string
+	Values map[string]string
+}
+
+// Presets in menu order. Custom keeps the values set by the player.
+var Presets = []Preset{
+	{
+		Name: PresetEasy,
+		Values: map[string]string{
+			ParamLives:    "5",
+			ParamLevel:    "0",
+			ParamBoost:    "0.1",
+			ParamBallSize: "0.5",
+		},
+	},
+	{
+		Name: PresetNormal,
+		Values: map[string]string{
+			ParamLives:    "3",
+			ParamLevel:    "0",
+			ParamBoost:    "0.25",
+			ParamBallSize: "0",
+		},
+	},
+	{
+		Name: PresetHard,
+		Values: map[string]string{
+			ParamLives:    "2",
+			ParamLevel:    "2",
+			ParamBoost:    "0.5",
+			ParamBallSize: "0",
+		},
+	},
+	{
+		Name: PresetInsane,
+		Values: map[string]string{
+			ParamLives:    "1",
+			ParamLevel:    "5",
+			ParamBoost:    "1",
+			ParamBallSize: "0",
+			ParamFps:      "60",
+		},
+	},
+	{
+		Name:   PresetCustom,
+		Values: map[string]string{},
+	},
+}
+
+// ParamNames lists every accepted parameter: the preset first, then ConfigParams.
+func ParamNames() []string {
+	return append([]string{ParamPreset}, ConfigParams...)
+}
+
+func LookupPreset(name string) (Preset, error) {
+	for _, preset := range Presets {
+		if preset.Name == name {
+			return preset, nil
+		}
+	}
+
+	return Preset{}, issuef(IssueNotAnOption, "unknown preset %q", name)
+}
+
+// NextPreset returns the preset after (step > 0) or before (step < 0) name, wrapping around.
+func NextPreset(name string, step int) string {
+	idx := 0
+	for i, preset := range Presets {
+		if preset.Name == name {
+			idx = i
+		}
+	}
+
+	n := len(Presets)
+	return Presets[((idx+step)%n+n)%n].Name
+}
+
+func (c *Config) ApplyPreset(name string) error {
+	preset, err := LookupPreset(name)
+	if err != nil {
+		return err
+	}
+
+	for param, value := range preset.Values {
+		if err := c.Set(param, value); err != nil {
+			return fmt.Errorf("preset %s: %w", name, err)
+		}
+	}
+
+	c.Preset = name
+	return nil
+}
+
+// MatchesPreset reports whether the values still equal those of c.Preset.
+func (c Config) MatchesPreset() bool {
+	if c.Preset == PresetCustom {
+		return true
+	}
+
+	want := c
+	if err := want.ApplyPreset(c.Preset); err != nil {
+		return false
+	}
+
+	got, expected := c.Values(), want.Values()
+	for param := range got {
+		if got[param] != expected[param] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,166 @@
+package app
+
+import "testing"
+
+func TestApplyPreset(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    string
+		wantLives int
+		wantBoost float64
+		wantFps   int
+		wantErr   bool
+	}{
+		{
+			name:      "Easy preset",
+			preset:    PresetEasy,
+			wantLives: 5,
+			wantBoost: 0.1,
+			wantFps:   30,
+		},
+		{
+			name:      "Insane preset",
+			preset:    PresetInsane,
+			wantLives: 1,
+			wantBoost: 1.0,
+			wantFps:   60,
+		},
+		{
+			name:      "Custom preset keeps values",
+			preset:    PresetCustom,
+			wantLives: 3,
+			wantBoost: 0.25,
+			wantFps:   30,
+		},
+		{
+			name:      "Unknown preset",
+			preset:    "nightmare",
+			wantLives: 3,
+			wantBoost: 0.25,
+			wantFps:   30,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+
+			err := cfg.ApplyPreset(tt.preset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyPreset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cfg.InitialLives != tt.wantLives {
+				t.Errorf("ApplyPreset() InitialLives = %v, want %v", cfg.InitialLives, tt.wantLives)
+			}
+			if cfg.SpeedIncrement != tt.wantBoost {
+				t.Errorf("ApplyPreset() SpeedIncrement = %v, want %v", cfg.SpeedIncrement, tt.wantBoost)
+			}
+			if cfg.Fps != tt.wantFps {
+				t.Errorf("ApplyPreset() Fps = %v, want %v", cfg.Fps, tt.wantFps)
+			}
+			if !tt.wantErr && cfg.Preset != tt.preset {
+				t.Errorf("ApplyPreset() Preset = %v, want %v", cfg.Preset, tt.preset)
+			}
+		})
+	}
+}
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, preset := range Presets {
+		t.Run(preset.Name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			if err := cfg.ApplyPreset(preset.Name); err != nil {
+				t.Fatalf("ApplyPreset() error = %v", err)
+			}
+			if issues := cfg.Validate(); len(issues) > 0 {
+				t.Errorf("preset %s is invalid: %v", preset.Name, issues)
+			}
+		})
+	}
+}
+
+func TestNextPreset(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		step    int
+		want    string
+	}{
+		{
+			name:    "Next after normal",
+			current: PresetNormal,
+			step:    1,
+			want:    PresetHard,
+		},
+		{
+			name:    "Previous before normal",
+			current: PresetNormal,
+			step:    -1,
+			want:    PresetEasy,
+		},
+		{
+			name:    "Wrap after custom",
+			current: PresetCustom,
+			step:    1,
+			want:    PresetEasy,
+		},
+		{
+			name:    "Wrap before easy",
+			current: PresetEasy,
+			step:    -1,
+			want:    PresetCustom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextPreset(tt.current, tt.step); got != tt.want {
+				t.Errorf("NextPreset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesPreset(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   bool
+	}{
+		{
+			name:   "Defaults match normal",
+			modify: func(c *Config) {},
+			want:   true,
+		},
+		{
+			name:   "Overridden lives",
+			modify: func(c *Config) { c.InitialLives = 9 },
+			want:   false,
+		},
+		{
+			name:   "Non preset parameter does not matter",
+			modify: func(c *Config) { c.Debug = true },
+			want:   true,
+		},
+		{
+			name: "Custom always matches",
+			modify: func(c *Config) {
+				c.Preset = PresetCustom
+				c.InitialLives = 9
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tt.modify(&cfg)
+
+			if got := cfg.MatchesPreset(); got != tt.want {
+				t.Errorf("MatchesPreset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package app
+
+import "strconv"
+
+// SettingsField is an editable parameter of the settings screen.
+type SettingsField struct {
+	Param   string
+	Label   string
+	Options []string
+}
+
+var SettingsFields = []SettingsField{
+	{Param: ParamLives, Label: "LIVES", Options: []string{"1", "2", "3", "5", "10", "20", "99"}},
+	{Param: ParamBoost, Label: "BOOST", Options: []string{"0", "0.1", "0.25", "0.5", "0.75", "1"}},
+	{Param: ParamBallSize, Label: "BALL SIZE", Options: []string{"0", "0.25", "0.5", "0.75", "1"}},
+	{Param: ParamBallShape, Label: "BALL SHAPE", Options: []string{BallSquare, BallRound}},
+	{Param: ParamFps, Label: "FPS", Options: []string{"30", "60"}},
+	{Param: ParamTheme, Label: "THEME", Options: []string{ThemeClassic, ThemeNeon, ThemeLight, ThemeHighContrast}},
+	{Param: ParamMotion, Label: "REDUCED MOTION", Options: []string{"false", "true"}},
+}
+
+// Settings is the state of the settings screen: the selected field and the edited copy of the config.
+type Settings struct {
+	Selected int
+	Draft    Config
+}
+
+func (s *Settings) Select(step int) {
+	n := len(SettingsFields)
+	s.Selected = ((s.Selected+step)%n + n) % n
+}
+
+// Adjust moves the selected field to the next (step > 0) or previous option.
+func (s *Settings) Adjust(step int) {
+	field := SettingsFields[s.Selected]
+	current := s.Draft.Values()[field.Param]
+
+	idx := -1
+	for i, option := range field.Options {
+		if sameValue(option, current) {
+			idx = i
+		}
+	}
+
+	n := len(field.Options)
+	if idx < 0 && step < 0 {
+		idx = 0 // unlisted value: step back to the last option
+	}
+	next := field.Options[((idx+step)%n+n)%n]
+
+	if err := s.Draft.Set(field.Param, next); err != nil {
+		return
+	}
+
+	if !s.Draft.MatchesPreset() {
+		s.Draft.Preset = PresetCustom
+	}
+}
+
+// Values returns the edited fields (the ones persisted between sessions).
+func (s *Settings) Values() map[string]string {
+	all := s.Draft.Values()
+	values := make(map[string]string, len(SettingsFields))
+	for _, field := range SettingsFields {
+		values[field.Param] = all[field.Param]
+	}
+
+	return values
+}
+
+func (p *Squash) OpenSettings(cfg Config) {
+	p.Settings = Settings{Draft: cfg}
+	p.State = StateSettings
+}
+
+// CloseSettings returns to the menu; the draft is applied on the next Reset.
+func (p *Squash) CloseSettings() Config {
+	cfg := p.Settings.Draft
+	cfg.DeltaTime = float64(FrameMillis(cfg.Fps)) / 1000.0
+	p.State = StateMenu
+	return cfg
+}
+
+// FrameMillis is the game loop tick interval for the given fps.
+func FrameMillis(fps int) int {
+	if fps == 60 {
+		return 16
+	}
+
+	return 33 // 30 fps
+}
+
+func sameValue(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return fa == fb
+}
@@ -0,0 +1,162 @@
+package app
+
+import "testing"
+
+func TestSettingsSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected int
+		step     int
+		want     int
+	}{
+		{
+			name:     "Next field",
+			selected: 0,
+			step:     1,
+			want:     1,
+		},
+		{
+			name:     "Wrap to first",
+			selected: len(SettingsFields) - 1,
+			step:     1,
+			want:     0,
+		},
+		{
+			name:     "Wrap to last",
+			selected: 0,
+			step:     -1,
+			want:     len(SettingsFields) - 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Settings{Selected: tt.selected, Draft: NewDefaultConfig()}
+			s.Select(tt.step)
+			if s.Selected != tt.want {
+				t.Errorf("Select() Selected = %v, want %v", s.Selected, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsAdjust(t *testing.T) {
+	tests := []struct {
+		name       string
+		selected   int
+		modify     func(c *Config)
+		step       int
+		wantValue  string
+		wantPreset string
+	}{
+		{
+			name:       "Next lives option",
+			selected:   0,
+			modify:     func(c *Config) {},
+			step:       1,
+			wantValue:  "5",
+			wantPreset: PresetCustom,
+		},
+		{
+			name:       "Previous boost option",
+			selected:   1,
+			modify:     func(c *Config) {},
+			step:       -1,
+			wantValue:  "0.1",
+			wantPreset: PresetCustom,
+		},
+		{
+			name:       "Last fps option wraps",
+			selected:   4,
+			modify:     func(c *Config) { c.Fps = 60 },
+			step:       1,
+			wantValue:  "30",
+			wantPreset: PresetNormal,
+		},
+		{
+			name:       "Round ball shape keeps the preset",
+			selected:   3,
+			modify:     func(c *Config) {},
+			step:       1,
+			wantValue:  BallRound,
+			wantPreset: PresetNormal,
+		},
+		{
+			name:       "Unlisted value steps to the first option",
+			selected:   0,
+			modify:     func(c *Config) { c.InitialLives = 42 },
+			step:       1,
+			wantValue:  "1",
+			wantPreset: PresetCustom,
+		},
+		{
+			name:       "Unlisted value steps back to the last option",
+			selected:   0,
+			modify:     func(c *Config) { c.InitialLives = 42 },
+			step:       -1,
+			wantValue:  "99",
+			wantPreset: PresetCustom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tt.modify(&cfg)
+			s := Settings{Selected: tt.selected, Draft: cfg}
+
+			s.Adjust(tt.step)
+
+			param := SettingsFields[tt.selected].Param
+			if got := s.Values()[param]; got != tt.wantValue {
+				t.Errorf("Adjust() %s = %v, want %v", param, got, tt.wantValue)
+			}
+			if s.Draft.Preset != tt.wantPreset {
+				t.Errorf("Adjust() Preset = %v, want %v", s.Draft.Preset, tt.wantPreset)
+			}
+		})
+	}
+}
+
+func TestSquashOpenCloseSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		fps           int
+		wantDeltaTime float64
+	}{
+		{
+			name:          "30 fps",
+			fps:           30,
+			wantDeltaTime: 0.033,
+		},
+		{
+			name:          "60 fps",
+			fps:           60,
+			wantDeltaTime: 0.016,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewSquash(800, 600, NewDefaultConfig())
+
+			cfg := NewDefaultConfig()
+			cfg.Fps = tt.fps
+			game.OpenSettings(cfg)
+			if game.State != StateSettings {
+				t.Errorf("OpenSettings() State = %v, want %v", game.State, StateSettings)
+			}
+
+			got := game.CloseSettings()
+			if game.State != StateMenu {
+				t.Errorf("CloseSettings() State = %v, want %v", game.State, StateMenu)
+			}
+			if got.DeltaTime != tt.wantDeltaTime {
+				t.Errorf("CloseSettings() DeltaTime = %v, want %v", got.DeltaTime, tt.wantDeltaTime)
+			}
+			if game.Lives != 3 {
+				t.Errorf("CloseSettings() must not reset the game, Lives = %v", game.Lives)
+			}
+		})
+	}
+}
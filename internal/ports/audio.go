@@ -0,0 +1,36 @@
+package ports
+
+// SoundEffect is a short sound played in response to a game event.
+type SoundEffect int
+
+const (
+	SoundPaddleHit SoundEffect = iota
+	SoundWallBounce
+	SoundLifeLost
+	SoundLevelUp
+	SoundGameOver
+)
+
+func (s SoundEffect) String() string {
+	switch s {
+	case SoundPaddleHit:
+		return "paddle-hit"
+	case SoundWallBounce:
+		return "wall-bounce"
+	case SoundLifeLost:
+		return "life-lost"
+	case SoundLevelUp:
+		return "level-up"
+	case SoundGameOver:
+		return "game-over"
+	}
+
+	return "unknown"
+}
+
+// AudioPlayer plays sound effects; volume is in [0, 1] and muting keeps the volume.
+type AudioPlayer interface {
+	Play(effect SoundEffect)
+	SetVolume(volume float64)
+	SetMuted(muted bool)
+}
@@ -5,3 +5,14 @@ import game "github.com/psaraiva/squash/internal/app"
 type ConfigProvider interface {
 	Load() game.Config
 }
+
+// ConfigSource provides raw parameter values (same names as the URL parameters).
+type ConfigSource interface {
+	Name() string
+	Values() map[string]string
+}
+
+// ConfigStore persists raw parameter values for future sessions.
+type ConfigStore interface {
+	Save(values map[string]string) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+// Layer is a plane of the frame; layers are composed bottom to top.
+type Layer int
+
+const (
+	LayerBackground Layer = iota // court and its markings
+	LayerEntities                // ball, paddle and effects
+	LayerHUD                     // score, lives and messages
+	LayerDebug                   // debug overlay
+)
+
+// LayeredRenderer keeps layers on their own surfaces, so a static layer is
+// drawn once and kept until it changes.
+//
+// BeginLayer directs the following calls to layer and reports whether they
+// must be made. key identifies the content of a static layer and must be
+// comparable: while it is the same as when the layer was last drawn, and the
+// layer was not invalidated, BeginLayer returns false and the layer is kept.
+// A nil key marks a dynamic layer, drawn every frame. The background layer is
+// begun first and starts the frame; its calls start with Clear.
+type LayeredRenderer interface {
+	Renderer
+	BeginLayer(layer Layer, key any) bool
+}
@@ -2,49 +2,65 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	image "image"
+
+	mock "github.com/stretchr/testify/mock"
+
+	ports "github.com/psaraiva/squash/internal/ports"
+)
 
 // Renderer is an autogenerated mock type for the Renderer type
 type Renderer struct {
 	mock.Mock
 }
 
-// Clear provides a mock function with no fields
-func (_m *Renderer) Clear() {
-	_m.Called()
+// Clear provides a mock function with given fields: color
+func (_m *Renderer) Clear(color ports.Color) {
+	_m.Called(color)
 }
 
-// DrawBall provides a mock function with given fields: x, y, radius
-func (_m *Renderer) DrawBall(x float64, y float64, radius float64) {
-	_m.Called(x, y, radius)
+// DrawCircle provides a mock function with given fields: x, y, radius, style
+func (_m *Renderer) DrawCircle(x float64, y float64, radius float64, style ports.Style) {
+	_m.Called(x, y, radius, style)
 }
 
-// DrawDebugText provides a mock function with given fields: text, x, y
-func (_m *Renderer) DrawDebugText(text string, x float64, y float64) {
-	_m.Called(text, x, y)
+// DrawImage provides a mock function with given fields: img, x, y, w, h
+func (_m *Renderer) DrawImage(img image.Image, x float64, y float64, w float64, h float64) {
+	_m.Called(img, x, y, w, h)
 }
 
-// DrawPaddle provides a mock function with given fields: x, y, w, h
-func (_m *Renderer) DrawPaddle(x float64, y float64, w float64, h float64) {
-	_m.Called(x, y, w, h)
+// DrawLine provides a mock function with given fields: x1, y1, x2, y2, style
+func (_m *Renderer) DrawLine(x1 float64, y1 float64, x2 float64, y2 float64, style ports.Style) {
+	_m.Called(x1, y1, x2, y2, style)
 }
 
-// DrawText provides a mock function with given fields: text, x, y
-func (_m *Renderer) DrawText(text string, x float64, y float64) {
-	_m.Called(text, x, y)
+// DrawPolygon provides a mock function with given fields: points, style
+func (_m *Renderer) DrawPolygon(points []ports.Point, style ports.Style) {
+	_m.Called(points, style)
 }
 
-// MeasureText provides a mock function with given fields: text
-func (_m *Renderer) MeasureText(text string) float64 {
-	ret := _m.Called(text)
+// DrawRect provides a mock function with given fields: x, y, w, h, style
+func (_m *Renderer) DrawRect(x float64, y float64, w float64, h float64, style ports.Style) {
+	_m.Called(x, y, w, h, style)
+}
+
+// DrawText provides a mock function with given fields: text, x, y, style
+func (_m *Renderer) DrawText(text string, x float64, y float64, style ports.TextStyle) {
+	_m.Called(text, x, y, style)
+}
+
+// MeasureText provides a mock function with given fields: text, font
+func (_m *Renderer) MeasureText(text string, font ports.Font) float64 {
+	ret := _m.Called(text, font)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MeasureText")
 	}
 
 	var r0 float64
-	if rf, ok := ret.Get(0).(func(string) float64); ok {
-		r0 = rf(text)
+	if rf, ok := ret.Get(0).(func(string, ports.Font) float64); ok {
+		r0 = rf(text, font)
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
@@ -52,6 +68,36 @@ func (_m *Renderer) MeasureText(text string) float64 {
 	return r0
 }
 
+// Restore provides a mock function with no fields
+func (_m *Renderer) Restore() {
+	_m.Called()
+}
+
+// Rotate provides a mock function with given fields: angle
+func (_m *Renderer) Rotate(angle float64) {
+	_m.Called(angle)
+}
+
+// Save provides a mock function with no fields
+func (_m *Renderer) Save() {
+	_m.Called()
+}
+
+// Scale provides a mock function with given fields: x, y
+func (_m *Renderer) Scale(x float64, y float64) {
+	_m.Called(x, y)
+}
+
+// SetAlpha provides a mock function with given fields: alpha
+func (_m *Renderer) SetAlpha(alpha float64) {
+	_m.Called(alpha)
+}
+
+// Translate provides a mock function with given fields: x, y
+func (_m *Renderer) Translate(x float64, y float64) {
+	_m.Called(x, y)
+}
+
 // NewRenderer creates a new instance of Renderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRenderer(t interface {
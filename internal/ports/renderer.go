@@ -1,10 +1,66 @@
 package ports
 
+import "image"
+
+// Color is a straight (non-premultiplied) 8-bit RGBA color.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// RGB returns an opaque color.
+func RGB(r, g, b uint8) Color {
+	return Color{R: r, G: g, B: b, A: 255}
+}
+
+// Point is a vertex of a polygon.
+type Point struct {
+	X, Y float64
+}
+
+// Style fills and/or strokes a shape; a transparent color disables that part.
+type Style struct {
+	Fill      Color
+	Stroke    Color
+	LineWidth float64
+}
+
+type TextAlign int
+
+const (
+	AlignLeft TextAlign = iota
+	AlignCenter
+	AlignRight
+)
+
+// Font is a font family at a size in pixels.
+type Font struct {
+	Family string
+	Size   float64
+	Bold   bool
+}
+
+// TextStyle draws text with the baseline at y and aligned on x.
+type TextStyle struct {
+	Font  Font
+	Color Color
+	Align TextAlign
+}
+
+// Renderer draws generic primitives; game objects are composed of them.
+// SetAlpha and the transforms apply to the following calls until Restore.
 type Renderer interface {
-	Clear()
-	DrawBall(x, y, radius float64)
-	DrawDebugText(text string, x, y float64)
-	DrawPaddle(x, y, w, h float64)
-	DrawText(text string, x, y float64)
-	MeasureText(text string) float64
+	Clear(color Color)
+	DrawCircle(x, y, radius float64, style Style)
+	DrawImage(img image.Image, x, y, w, h float64)
+	DrawLine(x1, y1, x2, y2 float64, style Style)
+	DrawPolygon(points []Point, style Style)
+	DrawRect(x, y, w, h float64, style Style)
+	DrawText(text string, x, y float64, style TextStyle)
+	MeasureText(text string, font Font) float64
+	Restore()
+	Rotate(angle float64)
+	Save()
+	Scale(x, y float64)
+	SetAlpha(alpha float64)
+	Translate(x, y float64)
 }
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// errSource is a source that may fail to load (unreadable file, corrupted token...).
+type errSource interface {
+	Err() error
+}
+
+// ChainProvider merges sources in priority order: later sources override earlier ones.
+type ChainProvider struct {
+	sources []ports.ConfigSource
+}
+
+func NewChainProvider(sources ...ports.ConfigSource) *ChainProvider {
+	return &ChainProvider{sources: sources}
+}
+
+func (c *ChainProvider) Load() app.Config {
+	cfg := app.NewDefaultConfig()
+	cfg.Origin = make(map[string]string, len(app.ConfigParams))
+	for _, param := range app.ConfigParams {
+		cfg.Origin[param] = SourceDefaults
+	}
+
+	for _, src := range c.sources {
+		values := src.Values()
+		if e, ok := src.(errSource); ok && e.Err() != nil {
+			cfg.Issues = append(cfg.Issues, app.ConfigIssue{
+				Param:    src.Name(),
+				Origin:   src.Name(),
+				Severity: app.SeverityError,
+				Kind:     app.IssueSource,
+				Reason:   e.Err().Error(),
+			})
+		}
+
+		if name, ok := values[app.ParamPreset]; ok {
+			cfg = applyPreset(cfg, src.Name(), name)
+		}
+
+		for _, param := range sortedParams(values) {
+			if param == app.ParamPreset {
+				continue
+			}
+			cfg = apply(cfg, src.Name(), param, values[param])
+		}
+	}
+
+	cfg.Validate()
+	if !cfg.MatchesPreset() {
+		cfg.Preset = app.PresetCustom
+	}
+
+	return cfg
+}
+
+// applyPreset sets the preset values first, so the other values of the same
+// source (and of higher priority sources) override them.
+func applyPreset(cfg app.Config, origin, name string) app.Config {
+	preset, err := app.LookupPreset(name)
+	if err != nil {
+		cfg.Issues = append(cfg.Issues, app.ConfigIssue{
+			Param:    app.ParamPreset,
+			Value:    name,
+			Origin:   origin,
+			Severity: app.SeverityError,
+			Kind:     app.IssueKindOf(err),
+			Reason:   err.Error(),
+		})
+		return cfg
+	}
+
+	for _, param := range sortedParams(preset.Values) {
+		cfg = apply(cfg, origin+":"+name, param, preset.Values[param])
+	}
+
+	cfg.Preset = name
+	return cfg
+}
+
+// apply sets one value; a rejected value is reported and the lower layer is kept.
+func apply(cfg app.Config, origin, param, value string) app.Config {
+	candidate := cfg
+	err := candidate.Set(param, value)
+	if err == nil {
+		err = candidate.ValidateParam(param)
+	}
+
+	if err != nil {
+		severity := app.SeverityError
+		if errors.Is(err, app.ErrUnknownParam) {
+			severity = app.SeverityWarning
+		}
+
+		cfg.Issues = append(cfg.Issues, app.ConfigIssue{
+			Param:    param,
+			Value:    value,
+			Origin:   origin,
+			Severity: severity,
+			Kind:     app.IssueKindOf(err),
+			Reason:   err.Error(),
+		})
+		return cfg
+	}
+
+	candidate.Origin[param] = origin
+	return candidate
+}
+
+func sortedParams(values map[string]string) []string {
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+	return params
+}
+
+var _ ports.ConfigProvider = (*ChainProvider)(nil)
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+)
+
+func TestChainProviderLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		sources    []*MapSource
+		wantLives  int
+		wantBoost  float64
+		wantOrigin map[string]string
+		wantIssues []app.ConfigIssue
+		wantPreset string
+	}{
+		{
+			name:      "No sources - defaults",
+			sources:   nil,
+			wantLives: 3,
+			wantBoost: 0.25,
+			wantOrigin: map[string]string{
+				app.ParamLives: SourceDefaults,
+				app.ParamBoost: SourceDefaults,
+			},
+			wantPreset: app.PresetNormal,
+		},
+		{
+			name: "Preset with individual override",
+			sources: []*MapSource{
+				NewMapSource(SourceURL, map[string]string{"preset": "hard", "lives": "9"}),
+			},
+			wantLives: 9,
+			wantBoost: 0.5,
+			wantOrigin: map[string]string{
+				app.ParamLives: SourceURL,
+				app.ParamBoost: SourceURL + ":hard",
+			},
+			wantPreset: app.PresetCustom,
+		},
+		{
+			name: "Preset from a higher layer",
+			sources: []*MapSource{
+				NewMapSource(SourceStored, map[string]string{"lives": "9"}),
+				NewMapSource(SourceURL, map[string]string{"preset": "easy"}),
+			},
+			wantLives: 5,
+			wantBoost: 0.1,
+			wantOrigin: map[string]string{
+				app.ParamLives: SourceURL + ":easy",
+			},
+			wantPreset: app.PresetEasy,
+		},
+		{
+			name: "Unknown preset",
+			sources: []*MapSource{
+				NewMapSource(SourceURL, map[string]string{"preset": "nightmare"}),
+			},
+			wantLives: 3,
+			wantBoost: 0.25,
+			wantIssues: []app.ConfigIssue{
+				{Param: "preset", Value: "nightmare", Origin: SourceURL, Severity: app.SeverityError, Kind: app.IssueNotAnOption, Reason: `unknown preset "nightmare"`},
+			},
+			wantPreset: app.PresetNormal,
+		},
+		{
+			name: "Higher priority overrides lower",
+			sources: []*MapSource{
+				NewMapSource(SourceStored, map[string]string{"lives": "5", "boost": "0.5"}),
+				NewMapSource(SourceURL, map[string]string{"lives": "9"}),
+			},
+			wantLives: 9,
+			wantBoost: 0.5,
+			wantOrigin: map[string]string{
+				app.ParamLives: SourceURL,
+				app.ParamBoost: SourceStored,
+			},
+		},
+		{
+			name: "Invalid value keeps the lower layer",
+			sources: []*MapSource{
+				NewMapSource(SourceServer, map[string]string{"lives": "5"}),
+				NewMapSource(SourceURL, map[string]string{"lives": "500"}),
+			},
+			wantLives: 5,
+			wantBoost: 0.25,
+			wantOrigin: map[string]string{
+				app.ParamLives: SourceServer,
+			},
+			wantIssues: []app.ConfigIssue{
+				{Param: "lives", Value: "500", Origin: SourceURL, Severity: app.SeverityError, Kind: app.IssueOutOfRange, Reason: "500 is out of range [1, 99]"},
+			},
+		},
+		{
+			name: "Unknown and malformed values are reported",
+			sources: []*MapSource{
+				NewMapSource(SourceServer, map[string]string{"speed": "9", "boost": "fast"}),
+			},
+			wantLives: 3,
+			wantBoost: 0.25,
+			wantOrigin: map[string]string{
+				app.ParamBoost: SourceDefaults,
+			},
+			wantIssues: []app.ConfigIssue{
+				{Param: "boost", Value: "fast", Origin: SourceServer, Severity: app.SeverityError, Kind: app.IssueMalformed, Reason: `"fast" is not a number`},
+				{Param: "speed", Value: "9", Origin: SourceServer, Severity: app.SeverityWarning, Kind: app.IssueUnknownParam, Reason: "unknown parameter"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := NewChainProvider(NewDefaultsSource())
+			for _, src := range tt.sources {
+				chain.sources = append(chain.sources, src)
+			}
+
+			cfg := chain.Load()
+
+			if cfg.InitialLives != tt.wantLives {
+				t.Errorf("Load() InitialLives = %v, want %v", cfg.InitialLives, tt.wantLives)
+			}
+			if cfg.SpeedIncrement != tt.wantBoost {
+				t.Errorf("Load() SpeedIncrement = %v, want %v", cfg.SpeedIncrement, tt.wantBoost)
+			}
+			if tt.wantPreset != "" && cfg.Preset != tt.wantPreset {
+				t.Errorf("Load() Preset = %v, want %v", cfg.Preset, tt.wantPreset)
+			}
+			if !reflect.DeepEqual(cfg.Issues, tt.wantIssues) {
+				t.Errorf("Load() Issues = %+v, want %+v", cfg.Issues, tt.wantIssues)
+			}
+			for param, origin := range tt.wantOrigin {
+				if cfg.Origin[param] != origin {
+					t.Errorf("Load() Origin[%s] = %v, want %v", param, cfg.Origin[param], origin)
+				}
+			}
+		})
+	}
+}
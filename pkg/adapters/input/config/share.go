@@ -0,0 +1,160 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/url"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/app"
+)
+
+const (
+	ParamChallenge  = "challenge"
+	SourceChallenge = "challenge"
+
+	TokenVersion = "2"
+)
+
+// Parameters that define a challenge; player preferences (debug, mouse) are not shared.
+var challengeParams = []string{
+	app.ParamLives,
+	app.ParamLevel,
+	app.ParamBoost,
+	app.ParamBallSize,
+	app.ParamBallShape,
+	app.ParamFps,
+	app.ParamSeed,
+}
+
+// tokenParams are the parameters each token version carries; version 1
+// predates the ball shape.
+var tokenParams = map[string][]string{
+	"1": {
+		app.ParamLives,
+		app.ParamLevel,
+		app.ParamBoost,
+		app.ParamBallSize,
+		app.ParamFps,
+		app.ParamSeed,
+	},
+	TokenVersion: challengeParams,
+}
+
+// legacyValues are the values of the parameters an older token lacks, as the
+// game played them when the token was made.
+var legacyValues = map[string]string{
+	app.ParamBallShape: app.BallSquare,
+}
+
+var (
+	ErrTokenFormat    = errors.New("malformed challenge token")
+	ErrTokenVersion   = errors.New("unsupported challenge token version")
+	ErrTokenCorrupted = errors.New("challenge token is corrupted")
+)
+
+// EncodeToken builds a compact, versioned token: "<version>.<base64 payload>.<crc32>".
+func EncodeToken(cfg app.Config, seed int64) string {
+	cfg.Seed = seed
+	values := cfg.Values()
+
+	query := url.Values{}
+	for _, param := range challengeParams {
+		query.Set(param, values[param])
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(query.Encode()))
+	return TokenVersion + "." + payload + "." + checksum(TokenVersion+"."+payload)
+}
+
+// DecodeToken verifies and validates a token; the values are returned in challenge mode.
+func DecodeToken(token string) (map[string]string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenFormat
+	}
+
+	version, payload, sum := parts[0], parts[1], parts[2]
+	params, ok := tokenParams[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrTokenVersion, version)
+	}
+
+	if checksum(version+"."+payload) != sum {
+		return nil, ErrTokenCorrupted
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrTokenFormat
+	}
+
+	query, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return nil, ErrTokenFormat
+	}
+
+	cfg := app.NewDefaultConfig()
+	values := map[string]string{app.ParamMode: app.ModeChallenge}
+	for _, param := range params {
+		value := query.Get(param)
+		if value == "" {
+			return nil, fmt.Errorf("%w: missing %s", ErrTokenFormat, param)
+		}
+
+		if err := cfg.Set(param, value); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrTokenFormat, param, err)
+		}
+
+		if err := cfg.ValidateParam(param); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrTokenFormat, param, err)
+		}
+
+		values[param] = value
+	}
+
+	for _, param := range challengeParams {
+		if _, ok := values[param]; !ok {
+			values[param] = legacyValues[param]
+		}
+	}
+
+	return values, nil
+}
+
+// TokenSource is the configuration of a shared challenge link.
+type TokenSource struct {
+	values map[string]string
+	err    error
+}
+
+// NewTokenSource decodes token; an empty token yields no values.
+func NewTokenSource(token string) *TokenSource {
+	if token == "" {
+		return &TokenSource{}
+	}
+
+	values, err := DecodeToken(token)
+	return &TokenSource{values: values, err: err}
+}
+
+func (t *TokenSource) Name() string {
+	return SourceChallenge
+}
+
+func (t *TokenSource) Values() map[string]string {
+	return t.values
+}
+
+func (t *TokenSource) Err() error {
+	return t.err
+}
+
+// checksum catches a token damaged on the way, e.g. cut or retyped from a chat
+// message. It is an integrity check only, not a signature: anyone can edit the
+// payload and compute it again, so a token proves nothing about who made it.
+func checksum(s string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(s)))
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+)
+
+// signedToken builds a token with a valid checksum around an arbitrary payload.
+func signedToken(version, query string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(query))
+	return version + "." + payload + "." + checksum(version+"."+payload)
+}
+
+func TestEncodeDecodeTokenRoundTrip(t *testing.T) {
+	cfg := app.NewDefaultConfig()
+	cfg.InitialLives = 5
+	cfg.SpeedIncrement = 0.1
+	cfg.BallScale = 0.5
+	cfg.BallShape = app.BallRound
+	cfg.Fps = 60
+	cfg.Debug = true
+
+	values, err := DecodeToken(EncodeToken(cfg, 1234))
+	if err != nil {
+		t.Fatalf("DecodeToken() error = %v", err)
+	}
+
+	want := map[string]string{
+		"lives":     "5",
+		"level":     "0",
+		"boost":     "0.1",
+		"ballsize":  "0.5",
+		"ballshape": "round",
+		"fps":       "60",
+		"seed":      "1234",
+		"mode":      app.ModeChallenge,
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("DecodeToken() = %v, want %v", values, want)
+	}
+}
+
+// Links shared before the ball shape was part of a challenge still open, with
+// the square ball they were played with.
+func TestDecodeTokenVersion1(t *testing.T) {
+	values, err := DecodeToken(signedToken("1", "lives=5&level=2&boost=0.5&ballsize=0.5&fps=60&seed=42"))
+	if err != nil {
+		t.Fatalf("DecodeToken() error = %v", err)
+	}
+
+	want := map[string]string{
+		"lives":     "5",
+		"level":     "2",
+		"boost":     "0.5",
+		"ballsize":  "0.5",
+		"ballshape": app.BallSquare,
+		"fps":       "60",
+		"seed":      "42",
+		"mode":      app.ModeChallenge,
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("DecodeToken() = %v, want %v", values, want)
+	}
+}
+
+func TestDecodeTokenRejects(t *testing.T) {
+	valid := EncodeToken(app.NewDefaultConfig(), 7)
+	corrupted := []byte(valid)
+	corrupted[3]++
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:    "Corrupted payload",
+			token:   string(corrupted),
+			wantErr: ErrTokenCorrupted,
+		},
+		{
+			name:    "Unknown version",
+			token:   signedToken("9", "lives=3"),
+			wantErr: ErrTokenVersion,
+		},
+		{
+			name:    "Missing parts",
+			token:   "2.abc",
+			wantErr: ErrTokenFormat,
+		},
+		{
+			name:    "Missing param",
+			token:   signedToken(TokenVersion, "lives=3&level=0&boost=0.25&ballsize=0&ballshape=square&fps=30"),
+			wantErr: ErrTokenFormat,
+		},
+		{
+			name:    "Out of range value",
+			token:   signedToken(TokenVersion, "lives=500&level=0&boost=0.25&ballsize=0&ballshape=square&fps=30&seed=1"),
+			wantErr: ErrTokenFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeToken(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DecodeToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenSourceInChain(t *testing.T) {
+	token := EncodeToken(app.NewDefaultConfig(), 99)
+
+	tests := []struct {
+		name       string
+		token      string
+		wantMode   string
+		wantSeed   int64
+		wantIssues int
+	}{
+		{
+			name:     "No token keeps classic mode",
+			token:    "",
+			wantMode: app.ModeClassic,
+		},
+		{
+			name:     "Valid token enters challenge mode",
+			token:    token,
+			wantMode: app.ModeChallenge,
+			wantSeed: 99,
+		},
+		{
+			name:       "Invalid token is reported and ignored",
+			token:      token + "0",
+			wantMode:   app.ModeClassic,
+			wantIssues: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewChainProvider(NewDefaultsSource(), NewTokenSource(tt.token)).Load()
+
+			if cfg.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", cfg.Mode, tt.wantMode)
+			}
+			if cfg.Seed != tt.wantSeed {
+				t.Errorf("Seed = %d, want %d", cfg.Seed, tt.wantSeed)
+			}
+			if len(cfg.Issues) != tt.wantIssues {
+				t.Errorf("Issues = %v, want %d", cfg.Issues, tt.wantIssues)
+			}
+		})
+	}
+}
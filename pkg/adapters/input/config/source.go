@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// Source names, lowest to highest priority.
+const (
+	SourceDefaults = app.OriginDefault
+	SourceBrowser  = "browser"
+	SourceStored   = "stored"
+	SourceServer   = "server"
+	SourceURL      = "url"
+
+	// Native builds
+	SourceFile = "file"
+	SourceEnv  = "env"
+	SourceFlag = "flag"
+
+	// Values edited on the settings screen, over every source.
+	SourceSettings = "settings"
+)
+
+type DefaultsSource struct{}
+
+func NewDefaultsSource() *DefaultsSource {
+	return &DefaultsSource{}
+}
+
+func (d *DefaultsSource) Name() string {
+	return SourceDefaults
+}
+
+func (d *DefaultsSource) Values() map[string]string {
+	return app.NewDefaultConfig().Values()
+}
+
+// MapSource is a fixed set of values, e.g. decoded from a JSON document.
+type MapSource struct {
+	name   string
+	values map[string]string
+}
+
+func NewMapSource(name string, values map[string]string) *MapSource {
+	return &MapSource{name: name, values: values}
+}
+
+func (m *MapSource) Name() string {
+	return m.name
+}
+
+func (m *MapSource) Values() map[string]string {
+	return m.values
+}
+
+// ParseJSON decodes a flat JSON object; numbers and booleans are kept as raw text.
+func ParseJSON(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid config json: %w", err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for param, val := range raw {
+		switch v := val.(type) {
+		case string:
+			values[param] = v
+		case bool:
+			values[param] = strconv.FormatBool(v)
+		case float64:
+			values[param] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("invalid config json: %q must be a string, number or boolean", param)
+		}
+	}
+
+	return values, nil
+}
+
+// ParseKeyValue decodes a TOML-like document: "key = value" lines, "#" comments,
+// optional quotes; [section] headers are ignored.
+func ParseKeyValue(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid config line %d: %q", i+1, line)
+		}
+
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+var (
+	_ ports.ConfigSource = (*DefaultsSource)(nil)
+	_ ports.ConfigSource = (*MapSource)(nil)
+)
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Mixed value types",
+			data: `{"lives": 5, "boost": 0.3, "debug": true, "fps": "60"}`,
+			want: map[string]string{"lives": "5", "boost": "0.3", "debug": "true", "fps": "60"},
+		},
+		{
+			name: "Empty object",
+			data: `{}`,
+			want: map[string]string{},
+		},
+		{
+			name:    "Nested object rejected",
+			data:    `{"lives": {"value": 5}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Malformed json",
+			data:    `{"lives": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSON([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultsSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+		want  string
+	}{
+		{
+			name:  "Default lives",
+			param: "lives",
+			want:  "3",
+		},
+		{
+			name:  "Default fps",
+			param: "fps",
+			want:  "30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewDefaultsSource()
+			if src.Name() != SourceDefaults {
+				t.Errorf("Name() = %v, want %v", src.Name(), SourceDefaults)
+			}
+			if got := src.Values()[tt.param]; got != tt.want {
+				t.Errorf("Values()[%s] = %v, want %v", tt.param, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKeyValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Plain values",
+			data: "lives = 5\nboost=0.3\n",
+			want: map[string]string{"lives": "5", "boost": "0.3"},
+		},
+		{
+			name: "Comments, quotes and sections",
+			data: "# squash\n[game]\ndebug = \"true\"\nfps = 60 # smooth\n\n",
+			want: map[string]string{"debug": "true", "fps": "60"},
+		},
+		{
+			name:    "Line without separator",
+			data:    "lives 5",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeyValue([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeyValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package controller
+
+import (
+	"maps"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+type Button int
+
+const (
+	ButtonLeft   Button = 0
+	ButtonMiddle Button = 1
+	ButtonRight  Button = 2
+)
+
+// Rect is the on-screen bounding box of the canvas (client coordinates).
+type Rect struct {
+	Left, Top     float64
+	Width, Height float64
+}
+
+// MouseEvent is a platform-independent mouse event.
+type MouseEvent struct {
+	Button               Button
+	ClientX, ClientY     float64
+	MovementX, MovementY float64
+}
+
+// Key is a platform-independent key press.
+type Key int
+
+const (
+	KeyUp Key = iota + 1
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyConfirm // acts as the left button
+	KeyBack    // acts as the right button
+)
+
+// KeyStep is how far one Up/Down press moves the paddle, in court units.
+const KeyStep = 30.0
+
+type CommandKind int
+
+const (
+	CommandStart CommandKind = iota + 1
+	CommandPause
+	CommandResume
+	CommandMovePaddle
+	CommandMovePaddleRelative
+	CommandSelectPreset
+	CommandOpenSettings
+	CommandSelectSetting
+	CommandAdjustSetting
+	CommandCloseSettings
+	CommandLockPointer
+	CommandUnlockPointer
+	CommandShareLink
+)
+
+type Command struct {
+	Kind CommandKind
+	Y    float64
+	Name string
+	Step int
+}
+
+// InputController turns normalized input events into game commands.
+type InputController struct {
+	squash        *app.Squash
+	cfg           app.Config
+	custom        app.Config
+	store         ports.ConfigStore
+	pointerLocked bool
+}
+
+func NewInputController(squash *app.Squash, cfg app.Config) *InputController {
+	custom := cfg
+	custom.Preset = app.PresetCustom
+
+	return &InputController{
+		squash: squash,
+		cfg:    cfg,
+		custom: custom,
+	}
+}
+
+// WithStore persists the settings saved on the settings screen.
+func (c *InputController) WithStore(store ports.ConfigStore) *InputController {
+	c.store = store
+	return c
+}
+
+// MouseDown handles start/restart (left button) and changes the selected setting.
+func (c *InputController) MouseDown(ev MouseEvent) []Command {
+	if ev.Button != ButtonLeft {
+		return nil
+	}
+
+	if c.squash.State == app.StateSettings {
+		return []Command{{Kind: CommandAdjustSetting, Step: 1}}
+	}
+
+	if c.squash.State != app.StateMenu && c.squash.State != app.StateGameOver {
+		return nil
+	}
+
+	return c.withPointerLock([]Command{{Kind: CommandStart}})
+}
+
+// ContextMenu handles pause/resume (right button), opens/saves the settings
+// and shares the challenge link on the game over screen.
+func (c *InputController) ContextMenu() []Command {
+	switch c.squash.State {
+	case app.StateMenu:
+		if c.isChallenge() {
+			return nil
+		}
+		return []Command{{Kind: CommandOpenSettings}}
+
+	case app.StateGameOver:
+		return []Command{{Kind: CommandShareLink, Name: config.EncodeToken(c.cfg, c.squash.Seed)}}
+
+	case app.StateSettings:
+		return []Command{{Kind: CommandCloseSettings}}
+
+	case app.StatePlaying:
+		cmds := []Command{{Kind: CommandPause}}
+		if c.pointerLocked {
+			cmds = append(cmds, Command{Kind: CommandUnlockPointer})
+		}
+		return cmds
+
+	case app.StatePaused:
+		return c.withPointerLock([]Command{{Kind: CommandResume}})
+	}
+
+	return nil
+}
+
+// MouseMove moves the paddle: relative while the pointer is locked, absolute otherwise;
+// screen pixels are converted to logical court units.
+func (c *InputController) MouseMove(ev MouseEvent, rect Rect) []Command {
+	if c.squash.State != app.StatePlaying {
+		return nil
+	}
+
+	if c.pointerLocked {
+		return []Command{{Kind: CommandMovePaddleRelative, Y: c.toCourt(ev.MovementY, rect)}}
+	}
+
+	return []Command{{Kind: CommandMovePaddle, Y: c.toCourt(ev.ClientY-rect.Top, rect)}}
+}
+
+// toCourt converts a distance in screen pixels to logical court units.
+func (c *InputController) toCourt(v float64, rect Rect) float64 {
+	if rect.Height <= 0 {
+		return v
+	}
+
+	return v * c.squash.Height / rect.Height
+}
+
+// Wheel cycles the difficulty preset (menu) or the selected setting (settings screen).
+func (c *InputController) Wheel(deltaY float64) []Command {
+	if deltaY == 0 {
+		return nil
+	}
+
+	step := 1
+	if deltaY < 0 {
+		step = -1
+	}
+
+	switch c.squash.State {
+	case app.StateMenu:
+		if c.isChallenge() {
+			return nil
+		}
+		return []Command{{Kind: CommandSelectPreset, Name: app.NextPreset(c.cfg.Preset, step)}}
+
+	case app.StateSettings:
+		return []Command{{Kind: CommandSelectSetting, Step: step}}
+	}
+
+	return nil
+}
+
+// PointerLockChange tracks the lock state; losing the lock while playing pauses the game.
+func (c *InputController) PointerLockChange(locked bool) []Command {
+	c.pointerLocked = locked
+	if locked || !c.cfg.PointerLock || c.squash.State != app.StatePlaying {
+		return nil
+	}
+
+	return []Command{{Kind: CommandPause}}
+}
+
+// KeyPress maps keyboard input onto the mouse rules: Up/Down move the paddle
+// while playing and act as the wheel elsewhere, Left/Right change the selected
+// setting or the preset, Confirm and Back act as the left and right buttons.
+func (c *InputController) KeyPress(k Key) []Command {
+	switch k {
+	case KeyUp, KeyDown:
+		step := 1.0
+		if k == KeyUp {
+			step = -1
+		}
+		if c.squash.State == app.StatePlaying {
+			center := c.squash.PaddleY + c.squash.PaddleH/2
+			return []Command{{Kind: CommandMovePaddle, Y: center + step*KeyStep}}
+		}
+		return c.Wheel(step)
+
+	case KeyLeft, KeyRight:
+		step := 1
+		if k == KeyLeft {
+			step = -1
+		}
+		if c.squash.State == app.StateSettings {
+			return []Command{{Kind: CommandAdjustSetting, Step: step}}
+		}
+		if c.squash.State == app.StateMenu {
+			return c.Wheel(float64(step))
+		}
+
+	case KeyConfirm:
+		return c.MouseDown(MouseEvent{Button: ButtonLeft})
+
+	case KeyBack:
+		return c.ContextMenu()
+	}
+
+	return nil
+}
+
+// Dispatch applies the game commands and returns the platform commands
+// (pointer lock requests) left for the caller to perform.
+func (c *InputController) Dispatch(cmds []Command) []Command {
+	var platform []Command
+	for _, cmd := range cmds {
+		switch cmd.Kind {
+		case CommandStart:
+			c.squash.Reset(c.cfg)
+			c.squash.State = app.StatePlaying
+
+		case CommandPause:
+			c.squash.State = app.StatePaused
+
+		case CommandResume:
+			c.squash.State = app.StatePlaying
+
+		case CommandMovePaddle:
+			c.squash.CalcMovePaddle(cmd.Y)
+
+		case CommandMovePaddleRelative:
+			c.squash.CalcMovePaddleRelative(cmd.Y)
+
+		case CommandSelectPreset:
+			c.selectPreset(cmd.Name)
+
+		case CommandOpenSettings:
+			c.squash.OpenSettings(c.cfg)
+
+		case CommandSelectSetting:
+			c.squash.Settings.Select(cmd.Step)
+
+		case CommandAdjustSetting:
+			c.squash.Settings.Adjust(cmd.Step)
+
+		case CommandCloseSettings:
+			c.saveSettings()
+
+		case CommandShareLink:
+			c.squash.ShareToken = cmd.Name
+			platform = append(platform, cmd)
+
+		default:
+			platform = append(platform, cmd)
+		}
+	}
+
+	return platform
+}
+
+func (c *InputController) withPointerLock(cmds []Command) []Command {
+	if c.cfg.PointerLock && !c.pointerLocked {
+		cmds = append(cmds, Command{Kind: CommandLockPointer})
+	}
+
+	return cmds
+}
+
+// selectPreset applies a preset over the loaded configuration; "custom" restores it.
+func (c *InputController) selectPreset(name string) {
+	cfg := c.custom
+	if name != app.PresetCustom {
+		if err := cfg.ApplyPreset(name); err != nil {
+			return
+		}
+	}
+
+	// a preset may change the fps, and the loop ticks at the new rate
+	cfg.DeltaTime = float64(app.FrameMillis(cfg.Fps)) / 1000.0
+	c.cfg = cfg
+	c.squash.Reset(c.cfg)
+}
+
+// saveSettings applies the edited settings on the next Reset and persists them;
+// the values that changed are traced to the settings screen.
+func (c *InputController) saveSettings() {
+	values := c.squash.Settings.Values()
+	before := c.cfg.Values()
+	c.cfg = c.squash.CloseSettings()
+
+	// the draft shares the origins of the config it was opened with
+	c.cfg.Origin = maps.Clone(c.cfg.Origin)
+	if c.cfg.Origin == nil {
+		c.cfg.Origin = make(map[string]string, len(values))
+	}
+	for param, value := range values {
+		if value != before[param] {
+			c.cfg.Origin[param] = config.SourceSettings
+		}
+	}
+	c.custom = c.cfg
+	c.custom.Preset = app.PresetCustom
+	c.squash.Reset(c.cfg)
+
+	if c.store != nil {
+		_ = c.store.Save(values)
+	}
+}
+
+// isChallenge locks the settings of a shared challenge.
+func (c *InputController) isChallenge() bool {
+	return c.cfg.Mode == app.ModeChallenge
+}
@@ -0,0 +1,693 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+func newTestController(state app.GameState, pointerLock bool) (*InputController, *app.Squash) {
+	cfg := app.Config{
+		Debug:            false,
+		Fps:              60,
+		DeltaTime:        0.016,
+		InitialLives:     3,
+		InitialLevel:     0,
+		SpeedIncrement:   0.5,
+		BallScale:        0.5,
+		PointerLock:      pointerLock,
+		MouseSensitivity: 1.0,
+		MouseCurve:       1.0,
+		BallShape:        app.BallSquare,
+		Theme:            app.ThemeClassic,
+	}
+	squash := app.NewSquash(800, 600, cfg)
+	squash.State = state
+
+	return NewInputController(squash, cfg), squash
+}
+
+func TestInputControllerMouseDown(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       app.GameState
+		button      Button
+		pointerLock bool
+		want        []Command
+	}{
+		{
+			name:   "Left click on menu starts the game",
+			state:  app.StateMenu,
+			button: ButtonLeft,
+			want:   []Command{{Kind: CommandStart}},
+		},
+		{
+			name:   "Left click on game over restarts the game",
+			state:  app.StateGameOver,
+			button: ButtonLeft,
+			want:   []Command{{Kind: CommandStart}},
+		},
+		{
+			name:        "Left click on menu with pointer lock requests the lock",
+			state:       app.StateMenu,
+			button:      ButtonLeft,
+			pointerLock: true,
+			want:        []Command{{Kind: CommandStart}, {Kind: CommandLockPointer}},
+		},
+		{
+			name:   "Left click while playing is ignored",
+			state:  app.StatePlaying,
+			button: ButtonLeft,
+			want:   nil,
+		},
+		{
+			name:   "Left click on settings changes the selected value",
+			state:  app.StateSettings,
+			button: ButtonLeft,
+			want:   []Command{{Kind: CommandAdjustSetting, Step: 1}},
+		},
+		{
+			name:   "Right button is ignored",
+			state:  app.StateMenu,
+			button: ButtonRight,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _ := newTestController(tt.state, tt.pointerLock)
+
+			got := ctrl.MouseDown(MouseEvent{Button: tt.button})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MouseDown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputControllerContextMenu(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         app.GameState
+		pointerLock   bool
+		pointerLocked bool
+		want          []Command
+	}{
+		{
+			name:  "Right click while playing pauses",
+			state: app.StatePlaying,
+			want:  []Command{{Kind: CommandPause}},
+		},
+		{
+			name:          "Right click while playing releases the pointer lock",
+			state:         app.StatePlaying,
+			pointerLock:   true,
+			pointerLocked: true,
+			want:          []Command{{Kind: CommandPause}, {Kind: CommandUnlockPointer}},
+		},
+		{
+			name:  "Right click while paused resumes",
+			state: app.StatePaused,
+			want:  []Command{{Kind: CommandResume}},
+		},
+		{
+			name:        "Right click while paused re-enters the pointer lock",
+			state:       app.StatePaused,
+			pointerLock: true,
+			want:        []Command{{Kind: CommandResume}, {Kind: CommandLockPointer}},
+		},
+		{
+			name:  "Right click on menu opens the settings",
+			state: app.StateMenu,
+			want:  []Command{{Kind: CommandOpenSettings}},
+		},
+		{
+			name:  "Right click on settings saves",
+			state: app.StateSettings,
+			want:  []Command{{Kind: CommandCloseSettings}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _ := newTestController(tt.state, tt.pointerLock)
+			ctrl.pointerLocked = tt.pointerLocked
+
+			got := ctrl.ContextMenu()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContextMenu() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputControllerMouseMove(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         app.GameState
+		pointerLocked bool
+		event         MouseEvent
+		rect          Rect
+		want          []Command
+	}{
+		{
+			name:  "Absolute move relative to canvas top",
+			state: app.StatePlaying,
+			event: MouseEvent{ClientY: 350},
+			rect:  Rect{Top: 50, Height: 600},
+			want:  []Command{{Kind: CommandMovePaddle, Y: 300}},
+		},
+		{
+			name:  "Absolute move scaled to the logical court",
+			state: app.StatePlaying,
+			event: MouseEvent{ClientY: 200},
+			rect:  Rect{Top: 50, Height: 300},
+			want:  []Command{{Kind: CommandMovePaddle, Y: 300}},
+		},
+		{
+			name:          "Relative move scaled to the logical court",
+			state:         app.StatePlaying,
+			pointerLocked: true,
+			event:         MouseEvent{MovementY: 5},
+			rect:          Rect{Top: 50, Height: 1200},
+			want:          []Command{{Kind: CommandMovePaddleRelative, Y: 2.5}},
+		},
+		{
+			name:          "Relative move while pointer is locked",
+			state:         app.StatePlaying,
+			pointerLocked: true,
+			event:         MouseEvent{ClientY: 350, MovementY: -7},
+			rect:          Rect{Top: 50, Height: 600},
+			want:          []Command{{Kind: CommandMovePaddleRelative, Y: -7}},
+		},
+		{
+			name:  "Move while paused is ignored",
+			state: app.StatePaused,
+			event: MouseEvent{ClientY: 350},
+			rect:  Rect{Top: 50, Height: 600},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _ := newTestController(tt.state, tt.pointerLocked)
+			ctrl.pointerLocked = tt.pointerLocked
+
+			got := ctrl.MouseMove(tt.event, tt.rect)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MouseMove() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputControllerPointerLockChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       app.GameState
+		pointerLock bool
+		locked      bool
+		want        []Command
+	}{
+		{
+			name:        "Lock acquired",
+			state:       app.StatePlaying,
+			pointerLock: true,
+			locked:      true,
+			want:        nil,
+		},
+		{
+			name:        "Lock lost while playing pauses",
+			state:       app.StatePlaying,
+			pointerLock: true,
+			locked:      false,
+			want:        []Command{{Kind: CommandPause}},
+		},
+		{
+			name:        "Lock lost while paused is ignored",
+			state:       app.StatePaused,
+			pointerLock: true,
+			locked:      false,
+			want:        nil,
+		},
+		{
+			name:        "Lock lost without pointer lock mode is ignored",
+			state:       app.StatePlaying,
+			pointerLock: false,
+			locked:      false,
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _ := newTestController(tt.state, tt.pointerLock)
+
+			got := ctrl.PointerLockChange(tt.locked)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PointerLockChange() = %v, want %v", got, tt.want)
+			}
+			if ctrl.pointerLocked != tt.locked {
+				t.Errorf("PointerLockChange() pointerLocked = %v, want %v", ctrl.pointerLocked, tt.locked)
+			}
+		})
+	}
+}
+
+func TestInputControllerDispatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		state        app.GameState
+		cmds         []Command
+		wantState    app.GameState
+		wantPaddleY  float64
+		wantPlatform []Command
+	}{
+		{
+			name:        "Start resets and plays",
+			state:       app.StateGameOver,
+			cmds:        []Command{{Kind: CommandStart}},
+			wantState:   app.StatePlaying,
+			wantPaddleY: 270,
+		},
+		{
+			name:        "Pause",
+			state:       app.StatePlaying,
+			cmds:        []Command{{Kind: CommandPause}},
+			wantState:   app.StatePaused,
+			wantPaddleY: 270,
+		},
+		{
+			name:        "Resume",
+			state:       app.StatePaused,
+			cmds:        []Command{{Kind: CommandResume}},
+			wantState:   app.StatePlaying,
+			wantPaddleY: 270,
+		},
+		{
+			name:        "Absolute paddle move",
+			state:       app.StatePlaying,
+			cmds:        []Command{{Kind: CommandMovePaddle, Y: 100}},
+			wantState:   app.StatePlaying,
+			wantPaddleY: 70,
+		},
+		{
+			name:        "Relative paddle move",
+			state:       app.StatePlaying,
+			cmds:        []Command{{Kind: CommandMovePaddleRelative, Y: 10}},
+			wantState:   app.StatePlaying,
+			wantPaddleY: 280,
+		},
+		{
+			name:         "Platform commands are returned",
+			state:        app.StateMenu,
+			cmds:         []Command{{Kind: CommandStart}, {Kind: CommandLockPointer}},
+			wantState:    app.StatePlaying,
+			wantPaddleY:  270,
+			wantPlatform: []Command{{Kind: CommandLockPointer}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, squash := newTestController(tt.state, false)
+
+			got := ctrl.Dispatch(tt.cmds)
+			if !reflect.DeepEqual(got, tt.wantPlatform) {
+				t.Errorf("Dispatch() = %v, want %v", got, tt.wantPlatform)
+			}
+			if squash.State != tt.wantState {
+				t.Errorf("Dispatch() State = %v, want %v", squash.State, tt.wantState)
+			}
+			if squash.PaddleY != tt.wantPaddleY {
+				t.Errorf("Dispatch() PaddleY = %v, want %v", squash.PaddleY, tt.wantPaddleY)
+			}
+		})
+	}
+}
+
+func TestInputControllerWheel(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  app.GameState
+		preset string
+		deltaY float64
+		want   []Command
+	}{
+		{
+			name:   "Wheel down selects the next preset",
+			state:  app.StateMenu,
+			preset: app.PresetNormal,
+			deltaY: 100,
+			want:   []Command{{Kind: CommandSelectPreset, Name: app.PresetHard}},
+		},
+		{
+			name:   "Wheel up selects the previous preset",
+			state:  app.StateMenu,
+			preset: app.PresetNormal,
+			deltaY: -100,
+			want:   []Command{{Kind: CommandSelectPreset, Name: app.PresetEasy}},
+		},
+		{
+			name:   "Wheel on settings moves the selection",
+			state:  app.StateSettings,
+			preset: app.PresetNormal,
+			deltaY: -100,
+			want:   []Command{{Kind: CommandSelectSetting, Step: -1}},
+		},
+		{
+			name:   "Wheel while playing is ignored",
+			state:  app.StatePlaying,
+			preset: app.PresetNormal,
+			deltaY: 100,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _ := newTestController(tt.state, false)
+			ctrl.cfg.Preset = tt.preset
+
+			got := ctrl.Wheel(tt.deltaY)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wheel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputControllerKeyPress(t *testing.T) {
+	tests := []struct {
+		name  string
+		state app.GameState
+		key   Key
+		want  func(squash *app.Squash) []Command
+	}{
+		{
+			name:  "Up moves the paddle up while playing",
+			state: app.StatePlaying,
+			key:   KeyUp,
+			want: func(squash *app.Squash) []Command {
+				return []Command{{Kind: CommandMovePaddle, Y: squash.PaddleY + squash.PaddleH/2 - KeyStep}}
+			},
+		},
+		{
+			name:  "Down moves the paddle down while playing",
+			state: app.StatePlaying,
+			key:   KeyDown,
+			want: func(squash *app.Squash) []Command {
+				return []Command{{Kind: CommandMovePaddle, Y: squash.PaddleY + squash.PaddleH/2 + KeyStep}}
+			},
+		},
+		{
+			name:  "Down on the menu selects the next preset",
+			state: app.StateMenu,
+			key:   KeyDown,
+			want: func(*app.Squash) []Command {
+				return []Command{{Kind: CommandSelectPreset, Name: app.PresetHard}}
+			},
+		},
+		{
+			name:  "Up on settings moves the selection",
+			state: app.StateSettings,
+			key:   KeyUp,
+			want: func(*app.Squash) []Command {
+				return []Command{{Kind: CommandSelectSetting, Step: -1}}
+			},
+		},
+		{
+			name:  "Left on settings changes the value back",
+			state: app.StateSettings,
+			key:   KeyLeft,
+			want: func(*app.Squash) []Command {
+				return []Command{{Kind: CommandAdjustSetting, Step: -1}}
+			},
+		},
+		{
+			name:  "Right on the menu selects the next preset",
+			state: app.StateMenu,
+			key:   KeyRight,
+			want: func(*app.Squash) []Command {
+				return []Command{{Kind: CommandSelectPreset, Name: app.PresetHard}}
+			},
+		},
+		{
+			name:  "Left while playing is ignored",
+			state: app.StatePlaying,
+			key:   KeyLeft,
+			want:  func(*app.Squash) []Command { return nil },
+		},
+		{
+			name:  "Confirm acts as the left button",
+			state: app.StateMenu,
+			key:   KeyConfirm,
+			want: func(*app.Squash) []Command {
+				return []Command{{Kind: CommandStart}}
+			},
+		},
+		{
+			name:  "Back acts as the right button",
+			state: app.StatePlaying,
+			key:   KeyBack,
+			want: func(*app.Squash) []Command {
+				return []Command{{Kind: CommandPause}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, squash := newTestController(tt.state, false)
+			ctrl.cfg.Preset = app.PresetNormal
+
+			got := ctrl.KeyPress(tt.key)
+			if want := tt.want(squash); !reflect.DeepEqual(got, want) {
+				t.Errorf("KeyPress() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestInputControllerSelectPreset(t *testing.T) {
+	tests := []struct {
+		name       string
+		preset     string
+		wantLives  int
+		wantPreset string
+	}{
+		{
+			name:       "Easy preset resets the game with 5 lives",
+			preset:     app.PresetEasy,
+			wantLives:  5,
+			wantPreset: app.PresetEasy,
+		},
+		{
+			name:       "Custom restores the loaded configuration",
+			preset:     app.PresetCustom,
+			wantLives:  3,
+			wantPreset: app.PresetCustom,
+		},
+		{
+			name:       "Unknown preset is ignored",
+			preset:     "nightmare",
+			wantLives:  3,
+			wantPreset: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, squash := newTestController(app.StateMenu, false)
+
+			ctrl.Dispatch([]Command{{Kind: CommandSelectPreset, Name: tt.preset}})
+
+			if squash.Lives != tt.wantLives {
+				t.Errorf("Dispatch() Lives = %v, want %v", squash.Lives, tt.wantLives)
+			}
+			if squash.Preset != tt.wantPreset {
+				t.Errorf("Dispatch() Preset = %v, want %v", squash.Preset, tt.wantPreset)
+			}
+			if squash.State != app.StateMenu {
+				t.Errorf("Dispatch() State = %v, want %v", squash.State, app.StateMenu)
+			}
+		})
+	}
+}
+
+func TestInputControllerSelectPresetFps(t *testing.T) {
+	ctrl, squash := newTestController(app.StateMenu, false)
+	ctrl.custom.Fps, ctrl.custom.DeltaTime = 30, 0.033
+	ctrl.cfg.Preset = app.PresetHard
+
+	// the insane preset runs at 60 fps, so a tick moves the ball half as far
+	ctrl.Dispatch(ctrl.Wheel(100))
+	if squash.Preset != app.PresetInsane || squash.Fps != 60 {
+		t.Fatalf("Wheel() Preset = %v, Fps = %v, want %v at 60", squash.Preset, squash.Fps, app.PresetInsane)
+	}
+	if squash.DeltaTime != 0.016 {
+		t.Errorf("Wheel() DeltaTime = %v, want 0.016", squash.DeltaTime)
+	}
+
+	ctrl.Dispatch([]Command{{Kind: CommandSelectPreset, Name: app.PresetCustom}})
+	if squash.DeltaTime != 0.033 {
+		t.Errorf("custom DeltaTime = %v, want 0.033", squash.DeltaTime)
+	}
+}
+
+type fakeStore struct {
+	saved map[string]string
+}
+
+func (f *fakeStore) Save(values map[string]string) error {
+	f.saved = values
+	return nil
+}
+
+func TestInputControllerSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmds      []Command
+		wantLives int
+		wantFps   int
+		wantTheme string
+		wantSaved map[string]string
+	}{
+		{
+			name: "Change lives and save",
+			cmds: []Command{
+				{Kind: CommandOpenSettings},
+				{Kind: CommandAdjustSetting, Step: 1},
+				{Kind: CommandCloseSettings},
+			},
+			wantLives: 5,
+			wantFps:   60,
+			wantTheme: app.ThemeClassic,
+			wantSaved: map[string]string{"lives": "5", "boost": "0.5", "ballsize": "0.5", "ballshape": "square", "fps": "60", "theme": "classic", "reducedmotion": "false"},
+		},
+		{
+			name: "Change fps and save",
+			cmds: []Command{
+				{Kind: CommandOpenSettings},
+				{Kind: CommandSelectSetting, Step: -3},
+				{Kind: CommandAdjustSetting, Step: 1},
+				{Kind: CommandCloseSettings},
+			},
+			wantLives: 3,
+			wantFps:   30,
+			wantTheme: app.ThemeClassic,
+			wantSaved: map[string]string{"lives": "3", "boost": "0.5", "ballsize": "0.5", "ballshape": "square", "fps": "30", "theme": "classic", "reducedmotion": "false"},
+		},
+		{
+			name: "Change theme and save",
+			cmds: []Command{
+				{Kind: CommandOpenSettings},
+				{Kind: CommandSelectSetting, Step: -2},
+				{Kind: CommandAdjustSetting, Step: 1},
+				{Kind: CommandCloseSettings},
+			},
+			wantLives: 3,
+			wantFps:   60,
+			wantTheme: app.ThemeNeon,
+			wantSaved: map[string]string{"lives": "3", "boost": "0.5", "ballsize": "0.5", "ballshape": "square", "fps": "60", "theme": "neon", "reducedmotion": "false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, squash := newTestController(app.StateMenu, false)
+			store := &fakeStore{}
+			ctrl.WithStore(store)
+
+			ctrl.Dispatch(tt.cmds)
+
+			if squash.State != app.StateMenu {
+				t.Errorf("State = %v, want %v", squash.State, app.StateMenu)
+			}
+			if squash.Lives != tt.wantLives {
+				t.Errorf("Lives = %v, want %v", squash.Lives, tt.wantLives)
+			}
+			if squash.Fps != tt.wantFps {
+				t.Errorf("Fps = %v, want %v", squash.Fps, tt.wantFps)
+			}
+			if squash.Theme != tt.wantTheme {
+				t.Errorf("Theme = %v, want %v", squash.Theme, tt.wantTheme)
+			}
+			if !reflect.DeepEqual(store.saved, tt.wantSaved) {
+				t.Errorf("saved = %v, want %v", store.saved, tt.wantSaved)
+			}
+			if ctrl.cfg.InitialLives != tt.wantLives {
+				t.Errorf("cfg.InitialLives = %v, want %v (applied on next Reset)", ctrl.cfg.InitialLives, tt.wantLives)
+			}
+		})
+	}
+}
+
+func TestInputControllerSettingsOrigin(t *testing.T) {
+	ctrl, _ := newTestController(app.StateMenu, false)
+	origin := map[string]string{app.ParamLives: config.SourceURL, app.ParamFps: config.SourceURL}
+	ctrl.cfg.Origin = origin
+
+	ctrl.Dispatch([]Command{
+		{Kind: CommandOpenSettings},
+		{Kind: CommandAdjustSetting, Step: 1},
+		{Kind: CommandAdjustSetting, Step: -1},
+		{Kind: CommandSelectSetting, Step: 1},
+		{Kind: CommandAdjustSetting, Step: 1},
+		{Kind: CommandCloseSettings},
+	})
+
+	// lives came back to its value, boost changed
+	want := map[string]string{app.ParamLives: config.SourceURL, app.ParamFps: config.SourceURL, app.ParamBoost: config.SourceSettings}
+	if !reflect.DeepEqual(ctrl.cfg.Origin, want) {
+		t.Errorf("cfg.Origin = %v, want %v", ctrl.cfg.Origin, want)
+	}
+	if origin[app.ParamBoost] != "" {
+		t.Errorf("the origins of the loaded config were changed: %v", origin)
+	}
+}
+
+func TestInputControllerShareLink(t *testing.T) {
+	ctrl, squash := newTestController(app.StateGameOver, false)
+	squash.Seed = 42
+
+	cmds := ctrl.ContextMenu()
+	if len(cmds) != 1 || cmds[0].Kind != CommandShareLink {
+		t.Fatalf("ContextMenu() = %v, want a share link command", cmds)
+	}
+
+	platform := ctrl.Dispatch(cmds)
+	if !reflect.DeepEqual(platform, cmds) {
+		t.Errorf("Dispatch() = %v, want %v", platform, cmds)
+	}
+	if squash.ShareToken != cmds[0].Name {
+		t.Errorf("ShareToken = %q, want %q", squash.ShareToken, cmds[0].Name)
+	}
+
+	values, err := config.DecodeToken(cmds[0].Name)
+	if err != nil {
+		t.Fatalf("DecodeToken() error = %v", err)
+	}
+	if values[app.ParamSeed] != "42" || values[app.ParamLives] != "3" {
+		t.Errorf("DecodeToken() = %v, want seed 42 and lives 3", values)
+	}
+}
+
+func TestInputControllerChallengeLocksSettings(t *testing.T) {
+	ctrl, squash := newTestController(app.StateMenu, false)
+	ctrl.cfg.Mode = app.ModeChallenge
+
+	if got := ctrl.ContextMenu(); got != nil {
+		t.Errorf("ContextMenu() = %v, want nil", got)
+	}
+	if got := ctrl.Wheel(1); got != nil {
+		t.Errorf("Wheel() = %v, want nil", got)
+	}
+	if squash.State != app.StateMenu {
+		t.Errorf("State = %v, want %v", squash.State, app.StateMenu)
+	}
+}
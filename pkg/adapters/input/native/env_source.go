@@ -0,0 +1,52 @@
+package native
+
+import (
+	"strings"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+const EnvPrefix = "SQUASH_"
+
+// EnvSource reads SQUASH_* variables, e.g. SQUASH_LIVES=5.
+type EnvSource struct {
+	environ []string
+}
+
+func NewEnvSource(environ []string) *EnvSource {
+	return &EnvSource{environ: environ}
+}
+
+func (e *EnvSource) Name() string {
+	return config.SourceEnv
+}
+
+func (e *EnvSource) Values() map[string]string {
+	values := make(map[string]string)
+	for _, param := range app.ParamNames() {
+		if value, ok := lookupEnv(e.environ, EnvName(param)); ok {
+			values[param] = value
+		}
+	}
+
+	return values
+}
+
+// EnvName returns the variable name of a parameter (lives -> SQUASH_LIVES).
+func EnvName(param string) string {
+	return EnvPrefix + strings.ToUpper(param)
+}
+
+func lookupEnv(environ []string, name string) (string, bool) {
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok && key == name {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+var _ ports.ConfigSource = (*EnvSource)(nil)
@@ -0,0 +1,65 @@
+package native
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+// FileSource reads a JSON (*.json) or TOML-like config file.
+type FileSource struct {
+	path   string
+	values map[string]string
+	err    error
+	loaded bool
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+func (f *FileSource) Name() string {
+	return config.SourceFile
+}
+
+func (f *FileSource) Values() map[string]string {
+	if !f.loaded {
+		f.loaded = true
+		f.values, f.err = readConfigFile(f.path)
+	}
+
+	return f.values
+}
+
+// Err reports why the file could not be used; a missing file is not an error.
+func (f *FileSource) Err() error {
+	f.Values()
+	return f.err
+}
+
+func readConfigFile(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return config.ParseJSON(data)
+	}
+
+	return config.ParseKeyValue(data)
+}
+
+var _ ports.ConfigSource = (*FileSource)(nil)
@@ -0,0 +1,63 @@
+package native
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+// FlagSource reads command-line flags (-lives=5 -boost=0.3); only flags
+// present on the command line are reported.
+type FlagSource struct {
+	values     map[string]string
+	configPath string
+	args       []string
+}
+
+func NewFlagSource(name string, args []string, output io.Writer) (*FlagSource, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	raw := make(map[string]*string)
+	for _, param := range app.ParamNames() {
+		raw[param] = fs.String(param, "", fmt.Sprintf("%s (see README)", param))
+	}
+	configPath := fs.String("config", "", "config file (.json or key = value)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		if v, ok := raw[f.Name]; ok {
+			values[f.Name] = *v
+		}
+	})
+
+	return &FlagSource{values: values, configPath: *configPath, args: fs.Args()}, nil
+}
+
+func (f *FlagSource) Name() string {
+	return config.SourceFlag
+}
+
+func (f *FlagSource) Values() map[string]string {
+	return f.values
+}
+
+// ConfigPath is the value of -config ("" when not given).
+func (f *FlagSource) ConfigPath() string {
+	return f.configPath
+}
+
+// Args returns the remaining non-flag arguments.
+func (f *FlagSource) Args() []string {
+	return f.args
+}
+
+var _ ports.ConfigSource = (*FlagSource)(nil)
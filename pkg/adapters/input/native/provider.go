@@ -0,0 +1,49 @@
+package native
+
+import (
+	"io"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+const DefaultConfigFile = "squash.toml"
+
+// Provider is the ConfigProvider of native builds.
+// Priority: defaults < file < env < flags.
+type Provider struct {
+	*config.ChainProvider
+	File  *FileSource
+	Flags *FlagSource
+}
+
+// NewProvider parses args (without the program name); the config file is
+// taken from -config, then SQUASH_CONFIG, then DefaultConfigFile.
+func NewProvider(name string, args, environ []string, output io.Writer) (*Provider, error) {
+	flags, err := NewFlagSource(name, args, output)
+	if err != nil {
+		return nil, err
+	}
+
+	path := flags.ConfigPath()
+	if path == "" {
+		path, _ = lookupEnv(environ, EnvPrefix+"CONFIG")
+	}
+	if path == "" {
+		path = DefaultConfigFile
+	}
+
+	file := NewFileSource(path)
+	return &Provider{
+		ChainProvider: config.NewChainProvider(
+			config.NewDefaultsSource(),
+			file,
+			NewEnvSource(environ),
+			flags,
+		),
+		File:  file,
+		Flags: flags,
+	}, nil
+}
+
+var _ ports.ConfigProvider = (*Provider)(nil)
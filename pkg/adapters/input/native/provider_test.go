@@ -0,0 +1,244 @@
+package native
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestFileSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "JSON file",
+			file:    "squash.json",
+			content: `{"lives": 5, "debug": true}`,
+			want:    map[string]string{"lives": "5", "debug": "true"},
+		},
+		{
+			name:    "TOML-like file",
+			file:    "squash.toml",
+			content: "lives = 7\nboost = 0.4\n",
+			want:    map[string]string{"lives": "7", "boost": "0.4"},
+		},
+		{
+			name:    "Malformed file",
+			file:    "squash.json",
+			content: `{"lives": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewFileSource(writeConfigFile(t, tt.file, tt.content))
+
+			if err := src.Err(); (err != nil) != tt.wantErr {
+				t.Fatalf("Err() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(src.Values(), tt.want) {
+				t.Errorf("Values() = %v, want %v", src.Values(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSourceMissing(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "Missing file",
+			path: filepath.Join(os.TempDir(), "squash-does-not-exist.toml"),
+		},
+		{
+			name: "Empty path",
+			path: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewFileSource(tt.path)
+			if src.Err() != nil {
+				t.Errorf("Err() = %v, want nil", src.Err())
+			}
+			if len(src.Values()) != 0 {
+				t.Errorf("Values() = %v, want empty", src.Values())
+			}
+		})
+	}
+}
+
+func TestEnvSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ []string
+		want    map[string]string
+	}{
+		{
+			name:    "Known variables",
+			environ: []string{"HOME=/root", "SQUASH_LIVES=5", "SQUASH_BALLSIZE=0.5"},
+			want:    map[string]string{"lives": "5", "ballsize": "0.5"},
+		},
+		{
+			name:    "Unknown SQUASH variables are skipped",
+			environ: []string{"SQUASH_SPEED=9"},
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEnvSource(tt.environ).Values()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlagSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		want       map[string]string
+		wantConfig string
+		wantArgs   []string
+		wantErr    bool
+	}{
+		{
+			name: "Only given flags are reported",
+			args: []string{"-lives=5", "-debug", "true"},
+			want: map[string]string{"lives": "5", "debug": "true"},
+		},
+		{
+			name:       "Config path and remaining args",
+			args:       []string{"-config", "game.json", "extra"},
+			want:       map[string]string{},
+			wantConfig: "game.json",
+			wantArgs:   []string{"extra"},
+		},
+		{
+			name:    "Unknown flag",
+			args:    []string{"-speed=9"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := NewFlagSource("squash", tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFlagSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(src.Values(), tt.want) {
+				t.Errorf("Values() = %v, want %v", src.Values(), tt.want)
+			}
+			if src.ConfigPath() != tt.wantConfig {
+				t.Errorf("ConfigPath() = %v, want %v", src.ConfigPath(), tt.wantConfig)
+			}
+			if len(src.Args()) != len(tt.wantArgs) {
+				t.Errorf("Args() = %v, want %v", src.Args(), tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestProviderLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		args       []string
+		environ    []string
+		wantLives  int
+		wantBoost  float64
+		wantOrigin map[string]string
+		wantIssues int
+	}{
+		{
+			name:      "Flags override env override file",
+			file:      "lives = 2\nboost = 0.1\nfps = 60\n",
+			args:      []string{"-lives=9"},
+			environ:   []string{"SQUASH_LIVES=4", "SQUASH_BOOST=0.6"},
+			wantLives: 9,
+			wantBoost: 0.6,
+			wantOrigin: map[string]string{
+				app.ParamLives: config.SourceFlag,
+				app.ParamBoost: config.SourceEnv,
+				app.ParamFps:   config.SourceFile,
+			},
+		},
+		{
+			name:       "Invalid env value is reported and file value kept",
+			file:       "lives = 2\n",
+			environ:    []string{"SQUASH_LIVES=500"},
+			wantLives:  2,
+			wantBoost:  0.25,
+			wantOrigin: map[string]string{app.ParamLives: config.SourceFile},
+			wantIssues: 1,
+		},
+		{
+			name:       "Malformed file is reported",
+			file:       "lives five\n",
+			wantLives:  3,
+			wantBoost:  0.25,
+			wantOrigin: map[string]string{app.ParamLives: config.SourceDefaults},
+			wantIssues: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, "squash.toml", tt.file)
+			environ := append([]string{"SQUASH_CONFIG=" + path}, tt.environ...)
+
+			provider, err := NewProvider("squash", tt.args, environ, io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := provider.Load()
+
+			if cfg.InitialLives != tt.wantLives {
+				t.Errorf("Load() InitialLives = %v, want %v", cfg.InitialLives, tt.wantLives)
+			}
+			if cfg.SpeedIncrement != tt.wantBoost {
+				t.Errorf("Load() SpeedIncrement = %v, want %v", cfg.SpeedIncrement, tt.wantBoost)
+			}
+			for param, origin := range tt.wantOrigin {
+				if cfg.Origin[param] != origin {
+					t.Errorf("Load() Origin[%s] = %v, want %v", param, cfg.Origin[param], origin)
+				}
+			}
+			if len(cfg.Issues) != tt.wantIssues {
+				t.Errorf("Load() Issues = %v, want %d", cfg.Issues, tt.wantIssues)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package terminal
+
+import (
+	"io"
+
+	"github.com/psaraiva/squash/pkg/adapters/input/controller"
+)
+
+const (
+	keyEscape = 0x1b
+	keyCtrlC  = 0x03
+)
+
+// Decode turns raw (non-canonical) terminal input into controller keys; quit
+// reports q or Ctrl+C. Arrows, WASD and vi keys move; Space/Enter confirm;
+// P and a lone Esc go back. Unknown escape sequences are skipped.
+func Decode(b []byte) (keys []controller.Key, quit bool) {
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; c {
+		case keyEscape:
+			if i+1 < len(b) && (b[i+1] == '[' || b[i+1] == 'O') {
+				end := sequenceEnd(b, i+2)
+				if end < len(b) {
+					if key, ok := arrowKeys[b[end]]; ok {
+						keys = append(keys, key)
+					}
+				}
+				i = end
+				continue
+			}
+			keys = append(keys, controller.KeyBack)
+
+		case 'q', 'Q', keyCtrlC:
+			return keys, true
+
+		default:
+			if key, ok := letterKeys[c]; ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys, false
+}
+
+// sequenceEnd skips the parameter and intermediate bytes of a CSI sequence and
+// returns the index of its final byte.
+func sequenceEnd(b []byte, i int) int {
+	for i < len(b) && b[i] >= 0x20 && b[i] <= 0x3f {
+		i++
+	}
+
+	return i
+}
+
+var arrowKeys = map[byte]controller.Key{
+	'A': controller.KeyUp,
+	'B': controller.KeyDown,
+	'C': controller.KeyRight,
+	'D': controller.KeyLeft,
+}
+
+var letterKeys = map[byte]controller.Key{
+	'w':  controller.KeyUp,
+	'k':  controller.KeyUp,
+	's':  controller.KeyDown,
+	'j':  controller.KeyDown,
+	'a':  controller.KeyLeft,
+	'h':  controller.KeyLeft,
+	'd':  controller.KeyRight,
+	'l':  controller.KeyRight,
+	' ':  controller.KeyConfirm,
+	'\r': controller.KeyConfirm,
+	'\n': controller.KeyConfirm,
+	'p':  controller.KeyBack,
+	'P':  controller.KeyBack,
+}
+
+// ReadKeys decodes r until quit or an error, sending each batch of keys on out;
+// the channel is closed when reading stops.
+func ReadKeys(r io.Reader, out chan<- []controller.Key) {
+	defer close(out)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		keys, quit := Decode(buf[:n])
+		if len(keys) > 0 {
+			out <- keys
+		}
+		if quit || err != nil {
+			return
+		}
+	}
+}
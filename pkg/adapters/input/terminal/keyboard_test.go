@@ -0,0 +1,53 @@
+package terminal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/psaraiva/squash/pkg/adapters/input/controller"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     []controller.Key
+		wantQuit bool
+	}{
+		{name: "Arrows", input: "\x1b[A\x1b[B\x1b[C\x1b[D", want: []controller.Key{controller.KeyUp, controller.KeyDown, controller.KeyRight, controller.KeyLeft}},
+		{name: "Application mode arrows", input: "\x1bOA\x1bOB", want: []controller.Key{controller.KeyUp, controller.KeyDown}},
+		{name: "Modified arrow", input: "\x1b[1;5A", want: []controller.Key{controller.KeyUp}},
+		{name: "Letters", input: "wsjk", want: []controller.Key{controller.KeyUp, controller.KeyDown, controller.KeyDown, controller.KeyUp}},
+		{name: "Confirm", input: " \r", want: []controller.Key{controller.KeyConfirm, controller.KeyConfirm}},
+		{name: "Back", input: "p\x1b", want: []controller.Key{controller.KeyBack, controller.KeyBack}},
+		{name: "Unknown sequence is skipped", input: "\x1b[15~w", want: []controller.Key{controller.KeyUp}},
+		{name: "Unknown bytes are ignored", input: "xyz", want: nil},
+		{name: "Quit keeps the keys before it", input: "wq s", want: []controller.Key{controller.KeyUp}, wantQuit: true},
+		{name: "Ctrl+C quits", input: "\x03", want: nil, wantQuit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, quit := Decode([]byte(tt.input))
+			if !reflect.DeepEqual(got, tt.want) || quit != tt.wantQuit {
+				t.Errorf("Decode(%q) = %v, %v, want %v, %v", tt.input, got, quit, tt.want, tt.wantQuit)
+			}
+		})
+	}
+}
+
+func TestReadKeys(t *testing.T) {
+	out := make(chan []controller.Key, 4)
+	ReadKeys(strings.NewReader("wsq"), out)
+
+	var got []controller.Key
+	for keys := range out {
+		got = append(got, keys...)
+	}
+
+	want := []controller.Key{controller.KeyUp, controller.KeyDown}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadKeys() = %v, want %v", got, want)
+	}
+}
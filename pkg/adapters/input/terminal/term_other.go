@@ -0,0 +1,22 @@
+//go:build !linux
+
+package terminal
+
+import "errors"
+
+var ErrUnsupported = errors.New("terminal: raw mode is only supported on Linux")
+
+// State is the terminal mode saved by MakeRaw.
+type State struct{}
+
+func MakeRaw(fd int) (*State, error) {
+	return nil, ErrUnsupported
+}
+
+func Restore(fd int, state *State) error {
+	return ErrUnsupported
+}
+
+func Size(fd int) (cols, rows int, err error) {
+	return 0, 0, ErrUnsupported
+}
@@ -0,0 +1,72 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+// BrowserSource reads the player preferences exposed by the browser: the language
+// (navigator.languages) and prefers-reduced-motion. Unsupported languages yield
+// no value, so the default applies.
+type BrowserSource struct{}
+
+func NewBrowserSource() *BrowserSource {
+	return &BrowserSource{}
+}
+
+func (b *BrowserSource) Name() string {
+	return config.SourceBrowser
+}
+
+func (b *BrowserSource) Values() map[string]string {
+	values := make(map[string]string)
+
+	navigator := js.Global().Get("navigator")
+	if !navigator.IsUndefined() && !navigator.IsNull() {
+		for _, tag := range navigatorLanguages(navigator) {
+			if lang, ok := app.MatchLang(tag); ok {
+				values[app.ParamLang] = lang
+				break
+			}
+		}
+	}
+
+	if prefersReducedMotion() {
+		values[app.ParamMotion] = "true"
+	}
+
+	return values
+}
+
+func navigatorLanguages(navigator js.Value) []string {
+	languages := navigator.Get("languages")
+	if languages.IsUndefined() || languages.IsNull() {
+		if language := navigator.Get("language"); language.Type() == js.TypeString {
+			return []string{language.String()}
+		}
+		return nil
+	}
+
+	tags := make([]string, languages.Length())
+	for i := range tags {
+		tags[i] = languages.Index(i).String()
+	}
+
+	return tags
+}
+
+func prefersReducedMotion() bool {
+	matchMedia := js.Global().Get("matchMedia")
+	if matchMedia.Type() != js.TypeFunction {
+		return false
+	}
+
+	return js.Global().Call("matchMedia", "(prefers-reduced-motion: reduce)").Get("matches").Bool()
+}
+
+var _ ports.ConfigSource = (*BrowserSource)(nil)
@@ -0,0 +1,48 @@
+//go:build js && wasm
+
+package wasm
+
+import "syscall/js"
+
+// ClipKey saves the last seconds of play as a GIF.
+const ClipKey = "g"
+
+// SetupClipKey signals requests when the clip key is pressed. The game loop
+// owns the recorder, so it takes the frames and draws the clip itself over its
+// next ticks; a press while a request is pending or a clip is being drawn is
+// dropped.
+func SetupClipKey(requests chan<- struct{}) {
+	js.Global().Get("document").Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		ev := args[0]
+		if ev.Get("repeat").Bool() || ev.Get("ctrlKey").Bool() || ev.Get("metaKey").Bool() {
+			return nil
+		}
+		if key := ev.Get("key").String(); key != ClipKey && key != "G" {
+			return nil
+		}
+
+		select {
+		case requests <- struct{}{}:
+		default:
+		}
+		return nil
+	}))
+}
+
+// Download hands data to the browser as a file named name.
+func Download(name, mime string, data []byte) {
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+
+	blob := js.Global().Get("Blob").New([]any{array}, map[string]any{"type": mime})
+	url := js.Global().Get("URL").Call("createObjectURL", blob)
+	defer js.Global().Get("URL").Call("revokeObjectURL", url)
+
+	doc := js.Global().Get("document")
+	a := doc.Call("createElement", "a")
+	a.Set("href", url)
+	a.Set("download", name)
+	doc.Get("body").Call("appendChild", a)
+	a.Call("click")
+	a.Call("remove")
+}
@@ -3,88 +3,61 @@
 package wasm
 
 import (
-	"strconv"
 	"syscall/js"
 
 	"github.com/psaraiva/squash/internal/app"
 	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
 )
 
-type ConfigLoader struct {
-	data []byte
-}
+// ConfigLoader reads the configuration from the URL query string.
+type ConfigLoader struct{}
 
 func NewConfigLoader() *ConfigLoader {
 	return &ConfigLoader{}
 }
 
 func (c *ConfigLoader) Load() app.Config {
-	cfg := app.NewDefaultConfig()
-
-	window := js.Global().Get("window")
-	if window.IsUndefined() || window.IsNull() {
-		return cfg
-	}
-
-	search := window.Get("location").Get("search")
-	params := js.Global().Get("URLSearchParams").New(search)
+	return config.NewChainProvider(config.NewDefaultsSource(), c).Load()
+}
 
-	// 1. Debug
-	if params.Call("has", "debug").Bool() {
-		cfg.Debug = params.Call("get", "debug").String() == "true"
-	}
+func (c *ConfigLoader) Name() string {
+	return config.SourceURL
+}
 
-	// 2. Lives (1 to 99)
-	if params.Call("has", "lives").Bool() {
-		cfg.InitialLives = 3
-		if val, err := strconv.Atoi(params.Call("get", "lives").String()); err == nil {
-			if val >= 1 && val <= 99 {
-				cfg.InitialLives = val
-			}
+func (c *ConfigLoader) Values() map[string]string {
+	values := make(map[string]string)
+	for _, param := range app.ParamNames() {
+		if value, ok := urlParam(param); ok {
+			values[param] = value
 		}
 	}
 
-	// 3. Initial Level (0 to 50)
-	if params.Call("has", "level").Bool() {
-		cfg.InitialLevel = 0
-		if val, err := strconv.Atoi(params.Call("get", "level").String()); err == nil {
-			if val >= 0 && val <= 50 {
-				cfg.InitialLevel = val
-			}
-		}
-	}
+	return values
+}
 
-	// 4. Speed Increment (0.0 to 1.0)
-	if params.Call("has", "boost").Bool() {
-		cfg.SpeedIncrement = 0.0
-		if val, err := strconv.ParseFloat(params.Call("get", "boost").String(), 64); err == nil {
-			if val >= 0.0 && val <= 1.0 {
-				cfg.SpeedIncrement = val
-			}
-		}
-	}
+// NewChallengeSource decodes the shared challenge link (?challenge=<token>).
+func NewChallengeSource() *config.TokenSource {
+	token, _ := urlParam(config.ParamChallenge)
+	return config.NewTokenSource(token)
+}
 
-	// 5. Ball Size (0.0 to 1.0)
-	if params.Call("has", "ballsize").Bool() {
-		cfg.BallScale = 0.0
-		if val, err := strconv.ParseFloat(params.Call("get", "ballsize").String(), 64); err == nil {
-			if val >= 0.0 && val <= 1.0 {
-				cfg.BallScale = val
-			}
-		}
+func urlParam(name string) (string, bool) {
+	window := js.Global().Get("window")
+	if window.IsUndefined() || window.IsNull() {
+		return "", false
 	}
 
-	// 6. FPS 30 or 60
-	if params.Call("has", "fps").Bool() {
-		cfg.Fps = 30
-		if val, err := strconv.Atoi(params.Call("get", "fps").String()); err == nil {
-			if val == 60 {
-				cfg.Fps = val
-			}
-		}
+	search := window.Get("location").Get("search")
+	params := js.Global().Get("URLSearchParams").New(search)
+	if !params.Call("has", name).Bool() {
+		return "", false
 	}
 
-	return cfg
+	return params.Call("get", name).String(), true
 }
 
-var _ ports.ConfigProvider = (*ConfigLoader)(nil)
+var (
+	_ ports.ConfigProvider = (*ConfigLoader)(nil)
+	_ ports.ConfigSource   = (*ConfigLoader)(nil)
+)
@@ -1,22 +1,36 @@
+//go:build js && wasm
+
 package wasm
 
 import (
 	"syscall/js"
 
-	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/pkg/adapters/input/controller"
 )
 
-func SetupMouseHandlers(squash *app.Squash, canvas js.Value, cfg app.Config) {
-	// Reset/Start
-	canvas.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) any {
-		button := args[0].Get("button").Int()
+func SetupMouseHandlers(ctrl *controller.InputController, canvas js.Value) {
+	doc := js.Global().Get("document")
+
+	dispatch := func(cmds []controller.Command) {
+		for _, cmd := range ctrl.Dispatch(cmds) {
+			switch cmd.Kind {
+			case controller.CommandLockPointer:
+				if !canvas.Get("requestPointerLock").IsUndefined() {
+					canvas.Call("requestPointerLock")
+				}
 
-		// left button
-		if button == 0 && (squash.State == app.StateMenu || squash.State == app.StateGameOver) {
-			squash.Reset(cfg)
-			squash.State = app.StatePlaying
+			case controller.CommandUnlockPointer:
+				doc.Call("exitPointerLock")
+
+			case controller.CommandShareLink:
+				shareLink(cmd.Name)
+			}
 		}
+	}
 
+	// Reset/Start
+	canvas.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		dispatch(ctrl.MouseDown(toMouseEvent(args[0])))
 		args[0].Call("preventDefault")
 		return nil
 	}))
@@ -24,23 +38,69 @@ func SetupMouseHandlers(squash *app.Squash, canvas js.Value, cfg app.Config) {
 	// Pause/Resume
 	canvas.Call("addEventListener", "contextmenu", js.FuncOf(func(this js.Value, args []js.Value) any {
 		args[0].Call("preventDefault")
-
-		if squash.State == app.StatePlaying {
-			squash.State = app.StatePaused
-		} else if squash.State == app.StatePaused {
-			squash.State = app.StatePlaying
-		}
+		dispatch(ctrl.ContextMenu())
 		return nil
 	}))
 
 	canvas.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) any {
-		if squash.State != app.StatePlaying {
-			return nil
+		dispatch(ctrl.MouseMove(toMouseEvent(args[0]), toRect(canvas.Call("getBoundingClientRect"))))
+		return nil
+	}))
+
+	// Difficulty preset (menu)
+	canvas.Call("addEventListener", "wheel", js.FuncOf(func(this js.Value, args []js.Value) any {
+		cmds := ctrl.Wheel(args[0].Get("deltaY").Float())
+		if len(cmds) > 0 {
+			args[0].Call("preventDefault")
 		}
+		dispatch(cmds)
+		return nil
+	}), map[string]any{"passive": false})
 
-		rect := canvas.Call("getBoundingClientRect")
-		mouseY := args[0].Get("clientY").Float() - rect.Get("top").Float()
-		squash.CalcMovePaddle(mouseY)
+	doc.Call("addEventListener", "pointerlockchange", js.FuncOf(func(this js.Value, args []js.Value) any {
+		dispatch(ctrl.PointerLockChange(doc.Get("pointerLockElement").Equal(canvas)))
 		return nil
 	}))
 }
+
+func toMouseEvent(ev js.Value) controller.MouseEvent {
+	return controller.MouseEvent{
+		Button:    controller.Button(ev.Get("button").Int()),
+		ClientX:   ev.Get("clientX").Float(),
+		ClientY:   ev.Get("clientY").Float(),
+		MovementX: floatOrZero(ev.Get("movementX")),
+		MovementY: floatOrZero(ev.Get("movementY")),
+	}
+}
+
+func toRect(rect js.Value) controller.Rect {
+	return controller.Rect{
+		Left:   rect.Get("left").Float(),
+		Top:    rect.Get("top").Float(),
+		Width:  rect.Get("width").Float(),
+		Height: rect.Get("height").Float(),
+	}
+}
+
+func floatOrZero(v js.Value) float64 {
+	if v.Type() != js.TypeNumber {
+		return 0
+	}
+
+	return v.Float()
+}
+
+// shareLink shows the challenge link in the address bar and copies it to the clipboard.
+func shareLink(token string) {
+	location := js.Global().Get("location")
+	link := location.Get("origin").String() + location.Get("pathname").String() + "?challenge=" + token
+
+	js.Global().Get("history").Call("replaceState", nil, "", link)
+
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if clipboard.IsUndefined() {
+		return
+	}
+
+	clipboard.Call("writeText", link)
+}
@@ -0,0 +1,89 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+// ServerSource reads a config.json served next to the game.
+type ServerSource struct {
+	url    string
+	values map[string]string
+	loaded bool
+}
+
+func NewServerSource(url string) *ServerSource {
+	return &ServerSource{url: url}
+}
+
+func (s *ServerSource) Name() string {
+	return config.SourceServer
+}
+
+func (s *ServerSource) Values() map[string]string {
+	if s.loaded {
+		return s.values
+	}
+
+	s.loaded = true
+	body, ok := fetchText(s.url)
+	if !ok {
+		return nil
+	}
+
+	values, err := config.ParseJSON([]byte(body))
+	if err != nil {
+		return nil
+	}
+
+	s.values = values
+	return s.values
+}
+
+// fetchText blocks the calling goroutine until the request settles; a missing file is not an error.
+func fetchText(url string) (string, bool) {
+	type result struct {
+		body string
+		ok   bool
+	}
+
+	done := make(chan result, 1)
+
+	var onText, onResponse, onError js.Func
+	onText = js.FuncOf(func(this js.Value, args []js.Value) any {
+		done <- result{body: args[0].String(), ok: true}
+		return nil
+	})
+	onResponse = js.FuncOf(func(this js.Value, args []js.Value) any {
+		resp := args[0]
+		if !resp.Get("ok").Bool() {
+			done <- result{}
+			return nil
+		}
+
+		resp.Call("text").Call("then", onText).Call("catch", onError)
+		return nil
+	})
+	onError = js.FuncOf(func(this js.Value, args []js.Value) any {
+		done <- result{}
+		return nil
+	})
+	defer onText.Release()
+	defer onResponse.Release()
+	defer onError.Release()
+
+	fetch := js.Global().Get("fetch")
+	if fetch.IsUndefined() {
+		return "", false
+	}
+
+	fetch.Invoke(url).Call("then", onResponse).Call("catch", onError)
+	res := <-done
+	return res.body, res.ok
+}
+
+var _ ports.ConfigSource = (*ServerSource)(nil)
@@ -0,0 +1,64 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/input/config"
+)
+
+const StorageKey = "squash.config"
+
+// StorageSource reads settings persisted in the browser localStorage.
+type StorageSource struct{}
+
+func NewStorageSource() *StorageSource {
+	return &StorageSource{}
+}
+
+func (s *StorageSource) Name() string {
+	return config.SourceStored
+}
+
+func (s *StorageSource) Values() map[string]string {
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return nil
+	}
+
+	item := storage.Call("getItem", StorageKey)
+	if item.IsNull() {
+		return nil
+	}
+
+	values, err := config.ParseJSON([]byte(item.String()))
+	if err != nil {
+		return nil
+	}
+
+	return values
+}
+
+func (s *StorageSource) Save(values map[string]string) error {
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return errors.New("localStorage is not available")
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	storage.Call("setItem", StorageKey, string(data))
+	return nil
+}
+
+var (
+	_ ports.ConfigSource = (*StorageSource)(nil)
+	_ ports.ConfigStore  = (*StorageSource)(nil)
+)
@@ -0,0 +1,30 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/pkg/adapters/input/web"
+)
+
+// LoadThemes returns the built-in themes plus the custom theme served at url, if any.
+// A malformed custom theme is reported and left out.
+func LoadThemes(url string) (map[string]web.Theme, error) {
+	themes := make(map[string]web.Theme, len(web.Themes)+1)
+	for name, theme := range web.Themes {
+		themes[name] = theme
+	}
+
+	body, ok := fetchText(url)
+	if !ok {
+		return themes, nil
+	}
+
+	custom, err := web.ParseTheme([]byte(body))
+	if err != nil {
+		return themes, err
+	}
+
+	themes[app.ThemeCustom] = custom
+	return themes, nil
+}
@@ -0,0 +1,269 @@
+package web
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+const (
+	maxParticles = 256
+	trailLength  = 8
+)
+
+// particle is a square spark moving away from an impact and fading out.
+type particle struct {
+	x, y   float64
+	dx, dy float64
+	life   float64 // seconds left
+	ttl    float64
+	size   float64
+	color  ports.Color
+}
+
+// burst describes the particles spawned by one engine event.
+type burst struct {
+	count    int
+	speed    float64 // maximum, in court units per second
+	ttl      float64
+	size     float64
+	shake    float64 // amplitude in court units
+	shakeDur float64
+}
+
+var eventBursts = map[app.EventKind]burst{
+	app.EventPaddleHit:  {count: 12, speed: 180, ttl: 0.35, size: 3, shake: 2, shakeDur: 0.08},
+	app.EventWallBounce: {count: 6, speed: 120, ttl: 0.25, size: 2},
+	app.EventLifeLost:   {count: 32, speed: 260, ttl: 0.7, size: 4, shake: 8, shakeDur: 0.3},
+	app.EventLevelUp:    {count: 40, speed: 220, ttl: 0.8, size: 3, shake: 3, shakeDur: 0.2},
+	app.EventGameOver:   {count: 64, speed: 300, ttl: 1.2, size: 5, shake: 12, shakeDur: 0.5},
+}
+
+// Effects adds particles, screen shake and a ball trail driven by engine events.
+// Particles live in a fixed pool, so a frame does not allocate; when the pool is
+// full, live particles are recycled in turn. Reduced motion turns everything off.
+type Effects struct {
+	Shake  bool
+	Trails bool
+
+	particles [maxParticles]particle
+	live      int
+	next      int // slot replaced when the pool is full
+
+	shakeAmp, shakeLeft, shakeDur float64
+	offsetX, offsetY              float64
+
+	trail      [trailLength]ports.Point
+	trailHead  int
+	trailCount int
+
+	rng *rand.Rand
+}
+
+func NewEffects(seed int64) *Effects {
+	return &Effects{
+		Shake:  true,
+		Trails: true,
+		rng:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Update spawns the effects of the events drained from the engine and advances
+// them by one game tick. Effects freeze while the game is paused.
+func (e *Effects) Update(p *app.Squash, events []app.Event, t Theme) {
+	if p.ReducedMotion {
+		e.clear()
+		return
+	}
+
+	for _, event := range events {
+		e.spawn(event, t)
+	}
+
+	if p.State == app.StatePaused {
+		return
+	}
+
+	dt := p.DeltaTime
+	e.updateParticles(dt)
+	e.updateShake(dt)
+	e.updateTrail(p)
+}
+
+// Offset is the screen shake translation of the current frame.
+func (e *Effects) Offset() (float64, float64) {
+	return e.offsetX, e.offsetY
+}
+
+func (e *Effects) drawParticles(r ports.Renderer) {
+	for i := 0; i < e.live; i++ {
+		pt := &e.particles[i]
+		color := fade(pt.color, pt.life/pt.ttl)
+		r.DrawRect(pt.x-pt.size/2, pt.y-pt.size/2, pt.size, pt.size, ports.Style{Fill: color})
+	}
+}
+
+func (e *Effects) spawn(event app.Event, t Theme) {
+	b, ok := eventBursts[event.Kind]
+	if !ok {
+		return
+	}
+
+	if event.Kind == app.EventLifeLost || event.Kind == app.EventGameOver {
+		e.resetTrail() // the ball leaves the court and respawns elsewhere
+	}
+
+	color := burstColor(event.Kind, t)
+	for i := 0; i < b.count; i++ {
+		angle := e.rng.Float64() * 2 * math.Pi
+		speed := b.speed * (0.3 + 0.7*e.rng.Float64())
+		ttl := b.ttl * (0.6 + 0.4*e.rng.Float64())
+
+		*e.slot() = particle{
+			x:     event.X,
+			y:     event.Y,
+			dx:    math.Cos(angle) * speed,
+			dy:    math.Sin(angle) * speed,
+			life:  ttl,
+			ttl:   ttl,
+			size:  b.size,
+			color: color,
+		}
+	}
+
+	// a weaker impact does not cut a stronger shake short
+	if e.Shake && b.shake > 0 && b.shake >= e.shakeAmplitude() {
+		e.shakeAmp, e.shakeLeft, e.shakeDur = b.shake, b.shakeDur, b.shakeDur
+	}
+}
+
+// slot returns a free particle, or recycles a live one when the pool is full.
+func (e *Effects) slot() *particle {
+	if e.live < maxParticles {
+		e.live++
+		return &e.particles[e.live-1]
+	}
+
+	pt := &e.particles[e.next]
+	e.next = (e.next + 1) % maxParticles
+	return pt
+}
+
+// particleDrag is the rate at which a particle loses its speed, per second:
+// v(t) = v0·e^(-particleDrag·t), about 0.92 a tick at 30 FPS.
+const particleDrag = 2.5
+
+func (e *Effects) updateParticles(dt float64) {
+	// the drag is integrated over the tick, not applied once per tick, so a
+	// burst spreads as far at any frame rate
+	keep := math.Exp(-particleDrag * dt)
+	travel := (1 - keep) / particleDrag
+
+	for i := 0; i < e.live; {
+		pt := &e.particles[i]
+		pt.life -= dt
+		if pt.life <= 0 {
+			// swap with the last live particle
+			e.live--
+			e.particles[i] = e.particles[e.live]
+			continue
+		}
+
+		pt.x += pt.dx * travel
+		pt.y += pt.dy * travel
+		pt.dx *= keep
+		pt.dy *= keep
+		i++
+	}
+
+	if e.next >= e.live {
+		e.next = 0
+	}
+}
+
+func (e *Effects) updateShake(dt float64) {
+	e.offsetX, e.offsetY = 0, 0
+	if !e.Shake || e.shakeLeft <= 0 {
+		e.shakeLeft = 0
+		return
+	}
+
+	amp := e.shakeAmplitude()
+	e.offsetX = (e.rng.Float64()*2 - 1) * amp
+	e.offsetY = (e.rng.Float64()*2 - 1) * amp
+	e.shakeLeft -= dt
+}
+
+// shakeAmplitude decays linearly to zero over the shake duration.
+func (e *Effects) shakeAmplitude() float64 {
+	if e.shakeLeft <= 0 || e.shakeDur <= 0 {
+		return 0
+	}
+
+	return e.shakeAmp * e.shakeLeft / e.shakeDur
+}
+
+func (e *Effects) updateTrail(p *app.Squash) {
+	if !e.Trails || p.State != app.StatePlaying {
+		e.resetTrail()
+		return
+	}
+
+	radius := p.BallSize / 2
+	e.trail[e.trailHead] = ports.Point{X: p.BallX + radius, Y: p.BallY + radius}
+	e.trailHead = (e.trailHead + 1) % trailLength
+	if e.trailCount < trailLength {
+		e.trailCount++
+	}
+}
+
+// drawTrail draws the previous ball positions, oldest first, shrinking and fading.
+func (e *Effects) drawTrail(r ports.Renderer, p *app.Squash, t Theme) {
+	for i := 0; i < e.trailCount; i++ {
+		idx := (e.trailHead - e.trailCount + i + trailLength) % trailLength
+		point := e.trail[idx]
+
+		age := float64(i+1) / float64(e.trailCount+1) // 0 oldest .. 1 newest
+		size := p.BallSize * (0.4 + 0.5*age)
+		style := ports.Style{Fill: fade(t.Ball, 0.5*age)}
+
+		if p.BallShape == app.BallRound {
+			r.DrawCircle(point.X, point.Y, size/2, style)
+		} else {
+			r.DrawRect(point.X-size/2, point.Y-size/2, size, size, style)
+		}
+	}
+}
+
+func (e *Effects) resetTrail() {
+	e.trailHead, e.trailCount = 0, 0
+}
+
+func (e *Effects) clear() {
+	e.live, e.next = 0, 0
+	e.shakeLeft, e.offsetX, e.offsetY = 0, 0, 0
+	e.resetTrail()
+}
+
+func burstColor(kind app.EventKind, t Theme) ports.Color {
+	switch kind {
+	case app.EventPaddleHit:
+		return t.Paddle
+	case app.EventWallBounce:
+		if t.Court.A > 0 {
+			return t.Court
+		}
+	case app.EventLevelUp:
+		return t.Text
+	}
+
+	return t.Ball
+}
+
+// fade scales the alpha of c by k in [0, 1].
+func fade(c ports.Color, k float64) ports.Color {
+	c.A = uint8(math.Round(float64(c.A) * math.Max(0, math.Min(1, k))))
+	return c
+}
@@ -0,0 +1,266 @@
+package web
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/internal/ports/mocks"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// nopRenderer discards every call; used where the mock would allocate.
+type nopRenderer struct{}
+
+func (nopRenderer) Clear(ports.Color)                                         {}
+func (nopRenderer) DrawCircle(float64, float64, float64, ports.Style)         {}
+func (nopRenderer) DrawImage(image.Image, float64, float64, float64, float64) {}
+func (nopRenderer) DrawLine(float64, float64, float64, float64, ports.Style)  {}
+func (nopRenderer) DrawPolygon([]ports.Point, ports.Style)                    {}
+func (nopRenderer) DrawRect(float64, float64, float64, float64, ports.Style)  {}
+func (nopRenderer) DrawText(string, float64, float64, ports.TextStyle)        {}
+func (nopRenderer) MeasureText(string, ports.Font) float64                    { return 0 }
+func (nopRenderer) Restore()                                                  {}
+func (nopRenderer) Rotate(float64)                                            {}
+func (nopRenderer) Save()                                                     {}
+func (nopRenderer) Scale(float64, float64)                                    {}
+func (nopRenderer) SetAlpha(float64)                                          {}
+func (nopRenderer) Translate(float64, float64)                                {}
+
+func newEffectsGame(state app.GameState) *app.Squash {
+	cfg := app.NewDefaultConfig()
+	cfg.DeltaTime = 0.016
+	g := app.NewSquash(800, 600, cfg)
+	g.State = state
+	return g
+}
+
+func TestEffectsSpawn(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []app.Event
+		want   int
+	}{
+		{
+			name: "No events",
+			want: 0,
+		},
+		{
+			name:   "Paddle hit",
+			events: []app.Event{{Kind: app.EventPaddleHit, X: 20, Y: 300}},
+			want:   eventBursts[app.EventPaddleHit].count,
+		},
+		{
+			name:   "Wall and level up",
+			events: []app.Event{{Kind: app.EventWallBounce}, {Kind: app.EventLevelUp}},
+			want:   eventBursts[app.EventWallBounce].count + eventBursts[app.EventLevelUp].count,
+		},
+		{
+			name:   "Pool is bounded",
+			events: []app.Event{{Kind: app.EventGameOver}, {Kind: app.EventGameOver}, {Kind: app.EventGameOver}, {Kind: app.EventGameOver}, {Kind: app.EventGameOver}},
+			want:   maxParticles,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := NewEffects(1)
+			g := newEffectsGame(app.StatePaused) // paused: spawn without aging
+
+			fx.Update(g, tt.events, ThemeClassic)
+
+			if fx.live != tt.want {
+				t.Errorf("live particles = %d, want %d", fx.live, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectsParticlesExpire(t *testing.T) {
+	fx := NewEffects(1)
+	g := newEffectsGame(app.StateGameOver)
+
+	fx.Update(g, []app.Event{{Kind: app.EventLifeLost, X: 0, Y: 300}}, ThemeClassic)
+	if fx.live == 0 {
+		t.Fatal("no particles spawned")
+	}
+
+	ticks := int(eventBursts[app.EventLifeLost].ttl/g.DeltaTime) + 1
+	for i := 0; i < ticks; i++ {
+		fx.Update(g, nil, ThemeClassic)
+	}
+
+	if fx.live != 0 {
+		t.Errorf("live particles = %d after the ttl, want 0", fx.live)
+	}
+}
+
+func TestEffectsBurstDistanceIgnoresFps(t *testing.T) {
+	// both bursts live through 0.2 s: the shortest ttl is 0.6 of 0.35 s
+	const seconds = 0.2
+	event := app.Event{Kind: app.EventPaddleHit, X: 400, Y: 300}
+
+	var spread [2][]particle
+	for i, fps := range []int{30, 60} {
+		e := NewEffects(1)
+		e.spawn(event, ThemeClassic)
+		dt := 1 / float64(fps)
+		for tick := 0; tick < int(seconds*float64(fps)); tick++ {
+			e.updateParticles(dt)
+		}
+		spread[i] = append(spread[i], e.particles[:e.live]...)
+	}
+
+	if len(spread[0]) != len(spread[1]) {
+		t.Fatalf("live particles = %d at 30 FPS, %d at 60 FPS", len(spread[0]), len(spread[1]))
+	}
+	for i := range spread[0] {
+		a, b := spread[0][i], spread[1][i]
+		if math.Abs(a.x-b.x) > 1e-6 || math.Abs(a.y-b.y) > 1e-6 {
+			t.Errorf("particle %d at [%.3f, %.3f] at 30 FPS, [%.3f, %.3f] at 60 FPS", i, a.x, a.y, b.x, b.y)
+		}
+	}
+}
+
+func TestEffectsPauseFreezes(t *testing.T) {
+	fx := NewEffects(1)
+	g := newEffectsGame(app.StatePaused)
+
+	fx.Update(g, []app.Event{{Kind: app.EventPaddleHit, X: 20, Y: 300}}, ThemeClassic)
+	before := fx.particles[0]
+	fx.Update(g, nil, ThemeClassic)
+
+	if fx.particles[0] != before {
+		t.Errorf("particle moved while paused: %+v -> %+v", before, fx.particles[0])
+	}
+}
+
+func TestEffectsShake(t *testing.T) {
+	tests := []struct {
+		name      string
+		shake     bool
+		event     app.EventKind
+		wantShake bool
+	}{
+		{name: "Life lost shakes", shake: true, event: app.EventLifeLost, wantShake: true},
+		{name: "Wall bounce does not shake", shake: true, event: app.EventWallBounce},
+		{name: "Shake disabled", shake: false, event: app.EventLifeLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := NewEffects(1)
+			fx.Shake = tt.shake
+			g := newEffectsGame(app.StatePlaying)
+
+			fx.Update(g, []app.Event{{Kind: tt.event}}, ThemeClassic)
+			dx, dy := fx.Offset()
+
+			if got := dx != 0 || dy != 0; got != tt.wantShake {
+				t.Errorf("Offset() = %v, %v, want shake %v", dx, dy, tt.wantShake)
+			}
+
+			for i := 0; i < 60; i++ {
+				fx.Update(g, nil, ThemeClassic)
+			}
+			if dx, dy := fx.Offset(); dx != 0 || dy != 0 {
+				t.Errorf("Offset() = %v, %v after the shake, want 0", dx, dy)
+			}
+		})
+	}
+}
+
+func TestEffectsTrail(t *testing.T) {
+	tests := []struct {
+		name   string
+		trails bool
+		state  app.GameState
+		ticks  int
+		events []app.Event
+		want   int
+	}{
+		{name: "Grows while playing", trails: true, state: app.StatePlaying, ticks: 3, want: 3},
+		{name: "Bounded length", trails: true, state: app.StatePlaying, ticks: 20, want: trailLength},
+		{name: "Life lost restarts it", trails: true, state: app.StatePlaying, ticks: 5, events: []app.Event{{Kind: app.EventLifeLost}}, want: 1},
+		{name: "Only while playing", trails: true, state: app.StateMenu, ticks: 5, want: 0},
+		{name: "Disabled", trails: false, state: app.StatePlaying, ticks: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := NewEffects(1)
+			fx.Trails = tt.trails
+			g := newEffectsGame(tt.state)
+
+			for i := 0; i < tt.ticks; i++ {
+				var events []app.Event
+				if i == tt.ticks-1 {
+					events = tt.events
+				}
+				g.BallX += 5
+				fx.Update(g, events, ThemeClassic)
+			}
+
+			if fx.trailCount != tt.want {
+				t.Errorf("trail length = %d, want %d", fx.trailCount, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectsReducedMotion(t *testing.T) {
+	fx := NewEffects(1)
+	g := newEffectsGame(app.StatePlaying)
+	fx.Update(g, []app.Event{{Kind: app.EventLifeLost}}, ThemeClassic)
+
+	g.ReducedMotion = true
+	fx.Update(g, []app.Event{{Kind: app.EventGameOver}}, ThemeClassic)
+
+	dx, dy := fx.Offset()
+	if fx.live != 0 || fx.trailCount != 0 || dx != 0 || dy != 0 {
+		t.Errorf("reduced motion left particles=%d trail=%d offset=%v,%v", fx.live, fx.trailCount, dx, dy)
+	}
+}
+
+func TestEffectsDoNotAllocate(t *testing.T) {
+	fx := NewEffects(1)
+	g := newEffectsGame(app.StatePlaying)
+	events := []app.Event{{Kind: app.EventPaddleHit, X: 20, Y: 300}, {Kind: app.EventWallBounce, X: 400, Y: 0}}
+	var r ports.Renderer = nopRenderer{}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		fx.Update(g, events, ThemeClassic)
+		fx.drawTrail(r, g, ThemeClassic)
+		fx.drawParticles(r)
+	})
+
+	if allocs != 0 {
+		t.Errorf("allocations per frame = %v, want 0", allocs)
+	}
+}
+
+func TestPaintGameEffects(t *testing.T) {
+	fx := NewEffects(1)
+	g := newEffectsGame(app.StatePlaying)
+	fx.Update(g, []app.Event{{Kind: app.EventLifeLost, X: 0, Y: 300}}, ThemeClassic)
+
+	mockRenderer := mocks.NewRenderer(t)
+	mockRenderer.On("Clear", colorBackground).Return()
+	mockRenderer.On("Save").Return()
+	mockRenderer.On("Translate", mock.Anything, mock.Anything).Return()
+	mockRenderer.On("Restore").Return()
+	mockRenderer.On("DrawRect", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(80.0)
+
+	PaintGame(mockRenderer, g, ThemeClassic, fx)
+
+	dx, dy := fx.Offset()
+	mockRenderer.AssertCalled(t, "Translate", dx, dy)
+	mockRenderer.AssertNumberOfCalls(t, "Restore", 1)
+	// ball, paddle and one square per particle
+	mockRenderer.AssertNumberOfCalls(t, "DrawRect", 2+fx.live+fx.trailCount)
+}
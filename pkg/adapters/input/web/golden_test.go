@@ -0,0 +1,255 @@
+package web
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/crt"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+	"github.com/psaraiva/squash/pkg/adapters/output/record"
+	"github.com/psaraiva/squash/pkg/adapters/output/svg"
+)
+
+// Regenerate the frames and the command snapshots with:
+// go test ./pkg/adapters/input/web -run 'TestPaintGame(Golden|Commands)' -update
+var update = flag.Bool("update", false, "rewrite the golden frames and command snapshots in testdata/golden")
+
+const goldenScale = 0.5
+
+func newGoldenGame(state app.GameState, edit func(cfg *app.Config)) *app.Squash {
+	cfg := app.NewDefaultConfig()
+	cfg.DeltaTime = 0.016
+	cfg.Seed = 1
+	if edit != nil {
+		edit(&cfg)
+	}
+
+	g := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	if state == app.StateSettings {
+		g.OpenSettings(cfg)
+	}
+	g.State = state
+	g.BallX, g.BallY = 500, 200
+	g.PaddleY = 270
+	g.Score = 1230
+	return g
+}
+
+type goldenCase struct {
+	name  string
+	state app.GameState
+	theme Theme
+	cfg   func(cfg *app.Config)
+	fx    bool
+	crt   bool
+}
+
+var goldenCases = []goldenCase{
+	{name: "menu", state: app.StateMenu, theme: ThemeClassic},
+	{name: "playing", state: app.StatePlaying, theme: ThemeClassic},
+	{name: "playing_round_neon", state: app.StatePlaying, theme: ThemeNeon, cfg: func(cfg *app.Config) { cfg.BallShape = app.BallRound }},
+	{name: "playing_effects", state: app.StatePlaying, theme: ThemeClassic, fx: true},
+	{name: "paused_light", state: app.StatePaused, theme: ThemeLight},
+	{name: "gameover_pt_br", state: app.StateGameOver, theme: ThemeHighContrast, cfg: func(cfg *app.Config) { cfg.Lang = app.LangPortuguese }},
+	{name: "settings", state: app.StateSettings, theme: ThemeClassic},
+	{name: "playing_crt_neon", state: app.StatePlaying, theme: ThemeNeon, fx: true, crt: true},
+	{name: "debug", state: app.StatePlaying, theme: ThemeClassic, cfg: func(cfg *app.Config) { cfg.Debug = true }},
+}
+
+// paint draws the frame of the case on r.
+func (tt goldenCase) paint(r ports.Renderer) {
+	g := newGoldenGame(tt.state, tt.cfg)
+
+	var fx *Effects
+	if tt.fx {
+		fx = NewEffects(1)
+		fx.Update(g, []app.Event{{Kind: app.EventPaddleHit, X: 20, Y: 300}}, tt.theme)
+	}
+
+	if !tt.crt {
+		PaintGame(r, g, tt.theme, fx)
+		return
+	}
+
+	// a frame with the ball further back leaves its afterimage
+	screen := crt.New(r, g.Width, g.Height, crt.DefaultOptions())
+	screen.SetEnabled(true)
+	g.BallX, g.BallY = g.BallX-30, g.BallY+15
+	PaintGame(screen, g, tt.theme, fx)
+	screen.EndFrame()
+	g.BallX, g.BallY = g.BallX+30, g.BallY-15
+	PaintGame(screen, g, tt.theme, fx)
+	screen.EndFrame()
+}
+
+func TestPaintGameGolden(t *testing.T) {
+	for _, tt := range goldenCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := raster.NewScaled(app.CourtWidth, app.CourtHeight, goldenScale)
+			tt.paint(r)
+
+			var got bytes.Buffer
+			if err := r.EncodePNG(&got); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, filepath.Join("testdata", "golden", tt.name+".png"), got.Bytes(), r.Image())
+		})
+	}
+}
+
+// The SVG frames are the same screens as text, so a visual change shows up in
+// the review as a diff of the elements that moved.
+func TestPaintGameGoldenSVG(t *testing.T) {
+	for _, tt := range goldenCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := svg.NewScaled(app.CourtWidth, app.CourtHeight, goldenScale)
+			tt.paint(r)
+
+			checkGoldenText(t, filepath.Join("testdata", "golden", tt.name+".svg"), r.Bytes())
+		})
+	}
+}
+
+// The command snapshots pin the Renderer calls of each screen, including the
+// transforms and the text layout, without rasterizing anything.
+func TestPaintGameCommands(t *testing.T) {
+	for _, tt := range goldenCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b := record.NewCommandBuffer(nil)
+			tt.paint(b)
+
+			checkGoldenCommands(t, filepath.Join("testdata", "golden", tt.name+".cmd"), b)
+		})
+	}
+}
+
+// checkGolden compares the frame with the golden PNG pixel by pixel; on a
+// mismatch the frame is written next to it with a .failed.png suffix.
+func checkGolden(t *testing.T, path string, encoded []byte, got *image.RGBA) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, encoded, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	defer f.Close()
+
+	want, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !want.Bounds().Eq(got.Bounds()) {
+		t.Fatalf("size = %v, want %v", got.Bounds(), want.Bounds())
+	}
+
+	diff := 0
+	b := got.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r1, g1, b1, a1 := want.At(x, y).RGBA()
+			r2, g2, b2, a2 := got.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				diff++
+			}
+		}
+	}
+
+	if diff > 0 {
+		failed := path[:len(path)-len(".png")] + ".failed.png"
+		_ = os.WriteFile(failed, encoded, 0o644)
+		t.Errorf("%d pixels differ from %s; frame written to %s", diff, path, failed)
+	}
+}
+
+// checkGoldenText compares the document with the golden file line by line and
+// reports the first line that differs; on a mismatch the document is written
+// next to it with a .failed suffix.
+func checkGoldenText(t *testing.T, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if bytes.Equal(want, got) {
+		return
+	}
+
+	ext := filepath.Ext(path)
+	failed := strings.TrimSuffix(path, ext) + ".failed" + ext
+	_ = os.WriteFile(failed, got, 0o644)
+
+	wantLines, gotLines := strings.Split(string(want), "\n"), strings.Split(string(got), "\n")
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			t.Errorf("%s:%d differs; frame written to %s\n- %s\n+ %s", path, i+1, failed, w, g)
+			return
+		}
+	}
+}
+
+// checkGoldenCommands parses the golden commands and reports the ones that
+// differ from the recorded frame.
+func checkGoldenCommands(t *testing.T, path string, b *record.CommandBuffer) {
+	t.Helper()
+
+	var got bytes.Buffer
+	if _, err := b.WriteTo(&got); err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		checkGoldenText(t, path, got.Bytes())
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	defer f.Close()
+
+	want, err := record.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := record.Diff(want, b.Commands()); len(diff) > 0 {
+		t.Errorf("%s differs (run with -update to accept):\n%s", path, strings.Join(diff, "\n"))
+	}
+}
@@ -0,0 +1,273 @@
+package web
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/app"
+)
+
+// Message identifiers of the player-facing text.
+const (
+	MsgScore           = "score"
+	MsgLives           = "lives"
+	MsgMenuStart       = "menu.start"
+	MsgMenuHint        = "menu.hint"
+	MsgMenuPreset      = "menu.preset"
+	MsgMenuChallenge   = "menu.challenge"
+	MsgPaused          = "paused"
+	MsgGameOver        = "gameover"
+	MsgGameOverRestart = "gameover.restart"
+	MsgGameOverShare   = "gameover.share"
+	MsgGameOverCopied  = "gameover.copied"
+	MsgUnverified      = "challenge.unverified"
+	MsgSettingsTitle   = "settings.title"
+	MsgSettingsHint    = "settings.hint"
+	MsgSettingsSave    = "settings.save"
+	MsgIssueRejected   = "issue.rejected"
+	MsgIssueIgnored    = "issue.ignored"
+	MsgClipExporting   = "clip.exporting"
+)
+
+// MsgSetting is the label of a settings field, e.g. MsgSetting(app.ParamLives).
+func MsgSetting(param string) string {
+	return "setting." + param
+}
+
+// MsgPreset is the name of a preset, e.g. MsgPreset(app.PresetHard).
+func MsgPreset(name string) string {
+	return "preset." + name
+}
+
+// MsgOption is the name of a value of a settings field, e.g.
+// MsgOption(app.ParamTheme, app.ThemeNeon); numbers have none.
+func MsgOption(param, value string) string {
+	return "option." + param + "." + value
+}
+
+// MsgIssue is the reason of a config issue of the given kind.
+func MsgIssue(kind app.IssueKind) string {
+	return issueMessages[kind]
+}
+
+var issueMessages = map[app.IssueKind]string{
+	app.IssueMalformed:    "issue.malformed",
+	app.IssueOutOfRange:   "issue.outofrange",
+	app.IssueNotAnOption:  "issue.notanoption",
+	app.IssueUnknownParam: "issue.unknownparam",
+	app.IssueSource:       "issue.source",
+}
+
+// Plural holds the forms of a message that depends on a count.
+type Plural struct {
+	One, Other string
+}
+
+// Locale is the message catalog and number format of a language.
+type Locale struct {
+	Lang      string
+	Thousands string
+
+	// one reports whether a count takes the singular form.
+	one      func(n int) bool
+	messages map[string]string
+	plurals  map[string]Plural
+}
+
+var LocaleEnglish = Locale{
+	Lang:      app.LangEnglish,
+	Thousands: ",",
+	one:       func(n int) bool { return n == 1 },
+	messages: map[string]string{
+		MsgScore:           "Score: %s",
+		MsgLives:           "Lives: %s",
+		MsgMenuStart:       "SQUASH - LEFT CLICK TO START",
+		MsgMenuHint:        "(RIGHT CLICK TO PAUSE / SETTINGS)",
+		MsgMenuPreset:      "< %s > (WHEEL: DIFFICULTY)",
+		MsgMenuChallenge:   "CHALLENGE - SEED %d",
+		MsgPaused:          "PAUSED - RIGHT CLICK TO RESUME",
+		MsgGameOverRestart: "(LEFT CLICK TO RESTART)",
+		MsgGameOverShare:   "(RIGHT CLICK: COPY CHALLENGE LINK)",
+		MsgGameOverCopied:  "CHALLENGE LINK COPIED",
+		MsgUnverified:      "(CHALLENGE LINKS ARE NOT VERIFIED)",
+		MsgSettingsTitle:   "SETTINGS",
+		MsgSettingsHint:    "(WHEEL: SELECT - LEFT CLICK: CHANGE)",
+		MsgSettingsSave:    "(RIGHT CLICK: SAVE)",
+		MsgIssueRejected:   "REJECTED",
+		MsgIssueIgnored:    "IGNORED",
+		MsgClipExporting:   "SAVING CLIP - %d%%",
+
+		MsgSetting(app.ParamLives):     "LIVES",
+		MsgSetting(app.ParamBoost):     "BOOST",
+		MsgSetting(app.ParamBallSize):  "BALL SIZE",
+		MsgSetting(app.ParamBallShape): "BALL SHAPE",
+		MsgSetting(app.ParamFps):       "FPS",
+		MsgSetting(app.ParamTheme):     "THEME",
+		MsgSetting(app.ParamMotion):    "REDUCED MOTION",
+
+		MsgPreset(app.PresetEasy):   "EASY",
+		MsgPreset(app.PresetNormal): "NORMAL",
+		MsgPreset(app.PresetHard):   "HARD",
+		MsgPreset(app.PresetInsane): "INSANE",
+		MsgPreset(app.PresetCustom): "CUSTOM",
+
+		MsgOption(app.ParamBallShape, app.BallSquare):    "SQUARE",
+		MsgOption(app.ParamBallShape, app.BallRound):     "ROUND",
+		MsgOption(app.ParamTheme, app.ThemeClassic):      "CLASSIC",
+		MsgOption(app.ParamTheme, app.ThemeNeon):         "NEON",
+		MsgOption(app.ParamTheme, app.ThemeLight):        "LIGHT",
+		MsgOption(app.ParamTheme, app.ThemeHighContrast): "HIGH CONTRAST",
+		MsgOption(app.ParamTheme, app.ThemeCustom):       "CUSTOM",
+		MsgOption(app.ParamMotion, "false"):              "OFF",
+		MsgOption(app.ParamMotion, "true"):               "ON",
+
+		MsgIssue(app.IssueMalformed):    "NOT A VALID VALUE",
+		MsgIssue(app.IssueOutOfRange):   "OUT OF RANGE",
+		MsgIssue(app.IssueNotAnOption):  "NOT ONE OF THE OPTIONS",
+		MsgIssue(app.IssueUnknownParam): "UNKNOWN PARAMETER",
+		MsgIssue(app.IssueSource):       "COULD NOT BE LOADED",
+	},
+	plurals: map[string]Plural{
+		MsgGameOver: {One: "GAME OVER - %s POINT", Other: "GAME OVER - %s POINTS"},
+	},
+}
+
+var LocalePortuguese = Locale{
+	Lang:      app.LangPortuguese,
+	Thousands: ".",
+	// CLDR: 0 and 1 are singular in Portuguese ("0 ponto", "1 ponto").
+	one: func(n int) bool { return n == 0 || n == 1 },
+	messages: map[string]string{
+		MsgScore:           "Pontos: %s",
+		MsgLives:           "Vidas: %s",
+		MsgMenuStart:       "SQUASH - CLIQUE ESQUERDO PARA COMEÇAR",
+		MsgMenuHint:        "(CLIQUE DIREITO PARA PAUSAR / CONFIGURAÇÕES)",
+		MsgMenuPreset:      "< %s > (RODA: DIFICULDADE)",
+		MsgMenuChallenge:   "DESAFIO - SEMENTE %d",
+		MsgPaused:          "PAUSADO - CLIQUE DIREITO PARA CONTINUAR",
+		MsgGameOverRestart: "(CLIQUE ESQUERDO PARA REINICIAR)",
+		MsgGameOverShare:   "(CLIQUE DIREITO: COPIAR LINK DO DESAFIO)",
+		MsgGameOverCopied:  "LINK DO DESAFIO COPIADO",
+		MsgUnverified:      "(LINKS DE DESAFIO NÃO SÃO VERIFICADOS)",
+		MsgSettingsTitle:   "CONFIGURAÇÕES",
+		MsgSettingsHint:    "(RODA: SELECIONAR - CLIQUE ESQUERDO: ALTERAR)",
+		MsgSettingsSave:    "(CLIQUE DIREITO: SALVAR)",
+		MsgIssueRejected:   "REJEITADO",
+		MsgIssueIgnored:    "IGNORADO",
+		MsgClipExporting:   "SALVANDO CLIPE - %d%%",
+
+		MsgSetting(app.ParamLives):     "VIDAS",
+		MsgSetting(app.ParamBoost):     "ACELERAÇÃO",
+		MsgSetting(app.ParamBallSize):  "TAMANHO DA BOLA",
+		MsgSetting(app.ParamBallShape): "FORMATO DA BOLA",
+		MsgSetting(app.ParamFps):       "FPS",
+		MsgSetting(app.ParamTheme):     "TEMA",
+		MsgSetting(app.ParamMotion):    "MOVIMENTO REDUZIDO",
+
+		MsgPreset(app.PresetEasy):   "FÁCIL",
+		MsgPreset(app.PresetNormal): "NORMAL",
+		MsgPreset(app.PresetHard):   "DIFÍCIL",
+		MsgPreset(app.PresetInsane): "INSANO",
+		MsgPreset(app.PresetCustom): "PERSONALIZADO",
+
+		MsgOption(app.ParamBallShape, app.BallSquare):    "QUADRADA",
+		MsgOption(app.ParamBallShape, app.BallRound):     "REDONDA",
+		MsgOption(app.ParamTheme, app.ThemeClassic):      "CLÁSSICO",
+		MsgOption(app.ParamTheme, app.ThemeNeon):         "NEON",
+		MsgOption(app.ParamTheme, app.ThemeLight):        "CLARO",
+		MsgOption(app.ParamTheme, app.ThemeHighContrast): "ALTO CONTRASTE",
+		MsgOption(app.ParamTheme, app.ThemeCustom):       "PERSONALIZADO",
+		MsgOption(app.ParamMotion, "false"):              "DESLIGADO",
+		MsgOption(app.ParamMotion, "true"):               "LIGADO",
+
+		MsgIssue(app.IssueMalformed):    "VALOR INVÁLIDO",
+		MsgIssue(app.IssueOutOfRange):   "FORA DO INTERVALO",
+		MsgIssue(app.IssueNotAnOption):  "NÃO É UMA DAS OPÇÕES",
+		MsgIssue(app.IssueUnknownParam): "PARÂMETRO DESCONHECIDO",
+		MsgIssue(app.IssueSource):       "NÃO PÔDE SER CARREGADO",
+	},
+	plurals: map[string]Plural{
+		MsgGameOver: {One: "FIM DE JOGO - %s PONTO", Other: "FIM DE JOGO - %s PONTOS"},
+	},
+}
+
+// Locales are the supported languages, by app.Lang* tag.
+var Locales = map[string]Locale{
+	app.LangEnglish:    LocaleEnglish,
+	app.LangPortuguese: LocalePortuguese,
+}
+
+// LookupLocale returns the locale of lang, or English when it is not supported.
+func LookupLocale(lang string) Locale {
+	if locale, ok := Locales[lang]; ok {
+		return locale
+	}
+
+	return LocaleEnglish
+}
+
+// T formats a message; a message missing from the catalog falls back to English,
+// then to its identifier.
+func (l Locale) T(id string, args ...any) string {
+	format, ok := l.messages[id]
+	if !ok {
+		format, ok = LocaleEnglish.messages[id]
+	}
+	if !ok {
+		return id
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+// Name is the text of a name from the config, such as a preset or a theme;
+// a name missing from the catalog, e.g. a number, is shown in upper case.
+func (l Locale) Name(id, name string) string {
+	if _, ok := l.messages[id]; !ok {
+		if _, ok := LocaleEnglish.messages[id]; !ok {
+			return strings.ToUpper(name)
+		}
+	}
+
+	return l.T(id)
+}
+
+// N formats the plural form of a message that matches n, with n formatted by Number.
+func (l Locale) N(id string, n int) string {
+	plural, ok := l.plurals[id]
+	one := l.one
+	if !ok {
+		plural, ok = LocaleEnglish.plurals[id]
+		one = LocaleEnglish.one
+	}
+	if !ok {
+		return id
+	}
+
+	format := plural.Other
+	if one(n) {
+		format = plural.One
+	}
+
+	return fmt.Sprintf(format, l.Number(n))
+}
+
+// Number formats n with the thousands separator of the locale, e.g. 12,345 or 12.345.
+func (l Locale) Number(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	out := make([]byte, 0, len(digits)+len(digits)/3*len(l.Thousands))
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, l.Thousands...)
+		}
+		out = append(out, digits[i])
+	}
+
+	return sign + string(out)
+}
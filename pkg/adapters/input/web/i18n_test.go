@@ -0,0 +1,169 @@
+package web
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+)
+
+func TestLocaleNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		n      int
+		want   string
+	}{
+		{name: "Small number", locale: LocaleEnglish, n: 120, want: "120"},
+		{name: "English thousands", locale: LocaleEnglish, n: 1234567, want: "1,234,567"},
+		{name: "Portuguese thousands", locale: LocalePortuguese, n: 1234567, want: "1.234.567"},
+		{name: "Exact group", locale: LocalePortuguese, n: 100000, want: "100.000"},
+		{name: "Negative", locale: LocaleEnglish, n: -4500, want: "-4,500"},
+		{name: "Zero", locale: LocaleEnglish, n: 0, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.Number(tt.n); got != tt.want {
+				t.Errorf("Number(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalePlural(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		n      int
+		want   string
+	}{
+		{name: "English zero is plural", locale: LocaleEnglish, n: 0, want: "GAME OVER - 0 POINTS"},
+		{name: "English one", locale: LocaleEnglish, n: 1, want: "GAME OVER - 1 POINT"},
+		{name: "English many", locale: LocaleEnglish, n: 1500, want: "GAME OVER - 1,500 POINTS"},
+		{name: "Portuguese zero is singular", locale: LocalePortuguese, n: 0, want: "FIM DE JOGO - 0 PONTO"},
+		{name: "Portuguese one", locale: LocalePortuguese, n: 1, want: "FIM DE JOGO - 1 PONTO"},
+		{name: "Portuguese many", locale: LocalePortuguese, n: 1500, want: "FIM DE JOGO - 1.500 PONTOS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.N(MsgGameOver, tt.n); got != tt.want {
+				t.Errorf("N(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFallback(t *testing.T) {
+	partial := Locale{Lang: "xx", Thousands: " ", one: func(n int) bool { return n == 1 }}
+
+	if got := partial.T(MsgPaused); got != LocaleEnglish.T(MsgPaused) {
+		t.Errorf("T() = %q, want the English message", got)
+	}
+	if got := partial.N(MsgGameOver, 2000); got != "GAME OVER - 2 000 POINTS" {
+		t.Errorf("N() = %q, want the English forms with the locale number", got)
+	}
+	if got := LocaleEnglish.T("missing"); got != "missing" {
+		t.Errorf("T() = %q, want the identifier", got)
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		want string
+	}{
+		{name: "English", lang: app.LangEnglish, want: app.LangEnglish},
+		{name: "Portuguese", lang: app.LangPortuguese, want: app.LangPortuguese},
+		{name: "Unsupported falls back to English", lang: "fr", want: app.LangEnglish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LookupLocale(tt.lang).Lang; got != tt.want {
+				t.Errorf("LookupLocale(%q) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalesAreComplete(t *testing.T) {
+	for _, lang := range app.Languages {
+		locale, ok := Locales[lang]
+		if !ok {
+			t.Fatalf("Locales has no %q", lang)
+		}
+
+		for id := range LocaleEnglish.messages {
+			if _, ok := locale.messages[id]; !ok {
+				t.Errorf("%s: missing message %q", lang, id)
+			}
+		}
+		for id := range LocaleEnglish.plurals {
+			if _, ok := locale.plurals[id]; !ok {
+				t.Errorf("%s: missing plural %q", lang, id)
+			}
+		}
+		for _, field := range app.SettingsFields {
+			if _, ok := locale.messages[MsgSetting(field.Param)]; !ok {
+				t.Errorf("%s: missing settings label %q", lang, field.Param)
+			}
+		}
+		for _, preset := range app.Presets {
+			if _, ok := locale.messages[MsgPreset(preset.Name)]; !ok {
+				t.Errorf("%s: missing preset %q", lang, preset.Name)
+			}
+		}
+		for _, name := range app.ThemeNames {
+			if _, ok := locale.messages[MsgOption(app.ParamTheme, name)]; !ok {
+				t.Errorf("%s: missing theme %q", lang, name)
+			}
+		}
+		for kind := app.IssueMalformed; kind <= app.IssueSource; kind++ {
+			if _, ok := locale.messages[MsgIssue(kind)]; !ok {
+				t.Errorf("%s: missing issue kind %d", lang, kind)
+			}
+		}
+	}
+}
+
+func TestGetTextPortuguese(t *testing.T) {
+	cfg := app.NewDefaultConfig()
+	cfg.Lang = app.LangPortuguese
+	g := app.NewSquash(800, 600, cfg)
+	g.Score = 2500
+
+	m := LookupLocale(g.Lang)
+
+	if got := getTextScore(m, g); got != "Pontos: 2.500" {
+		t.Errorf("getTextScore() = %q", got)
+	}
+	if got := getTextLives(m, g); got != "Vidas: 3" {
+		t.Errorf("getTextLives() = %q", got)
+	}
+
+	if got := getTextPreset(m, g); got != "< NORMAL > (RODA: DIFICULDADE)" {
+		t.Errorf("getTextPreset() = %q", got)
+	}
+
+	g.OpenSettings(cfg)
+	settings := getTextStateSettings(m, g)
+	for _, line := range []string{"  FORMATO DA BOLA: QUADRADA", "  TEMA: CLÁSSICO", "  MOVIMENTO REDUZIDO: DESLIGADO"} {
+		if !slices.Contains(settings, line) {
+			t.Errorf("getTextStateSettings() = %v, missing %q", settings, line)
+		}
+	}
+
+	g.ConfigIssues = []app.ConfigIssue{{Param: "lives", Value: "500", Origin: "url", Severity: app.SeverityError, Kind: app.IssueOutOfRange}}
+	if got := getTextConfigIssues(m, g); !reflect.DeepEqual(got, []string{"REJEITADO lives=500 (url): FORA DO INTERVALO"}) {
+		t.Errorf("getTextConfigIssues() = %v", got)
+	}
+
+	want := []string{"FIM DE JOGO - 2.500 PONTOS", "(CLIQUE ESQUERDO PARA REINICIAR)", "(CLIQUE DIREITO: COPIAR LINK DO DESAFIO)"}
+	if got := getTextStateGameOver(m, g); !reflect.DeepEqual(got, want) {
+		t.Errorf("getTextStateGameOver() = %v, want %v", got, want)
+	}
+}
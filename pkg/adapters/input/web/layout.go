@@ -0,0 +1,166 @@
+package web
+
+import (
+	"math"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// Anchor is the point of the court a text block is attached to.
+type Anchor int
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// ascent approximates the height above the baseline as a fraction of the font size.
+const ascent = 0.8
+
+// Layout places HUD text relative to the court edges using the renderer's
+// text metrics, so positions follow the court size, the font and the text length.
+type Layout struct {
+	Width, Height float64
+	Padding       float64
+	// LineHeight is the distance between baselines as a multiple of the font size.
+	LineHeight  float64
+	MinFontSize float64
+}
+
+// Box is an area of the court in logical units.
+type Box struct {
+	X, Y, W, H float64
+}
+
+func (b Box) Bottom() float64 {
+	return b.Y + b.H
+}
+
+// TextBlock is a group of lines attached to an anchor; Offset moves it away from the anchor.
+type TextBlock struct {
+	Anchor Anchor
+	Lines  []string
+	Font   ports.Font
+	Offset ports.Point
+}
+
+// PlacedText is a line ready to draw: baseline position and the (possibly shrunk) font.
+type PlacedText struct {
+	Text string
+	X, Y float64
+	Font ports.Font
+}
+
+func NewLayout(w, h float64) Layout {
+	return Layout{
+		Width:       w,
+		Height:      h,
+		Padding:     math.Round(math.Min(w, h) * 0.025),
+		LineHeight:  1.25,
+		MinFontSize: 8,
+	}
+}
+
+// Place measures the block and returns its lines and bounds; a block wider than
+// the court (minus padding) is drawn with a smaller font instead of being clipped.
+func (l Layout) Place(r ports.Renderer, b TextBlock) ([]PlacedText, Box) {
+	if len(b.Lines) == 0 {
+		return nil, Box{}
+	}
+
+	widths := make([]float64, len(b.Lines))
+	widest := 0.0
+	for i, line := range b.Lines {
+		widths[i] = r.MeasureText(line, b.Font)
+		widest = math.Max(widest, widths[i])
+	}
+
+	font := b.Font
+	if scale := l.fitScale(font, widest); scale < 1 {
+		font.Size *= scale
+		widest *= scale
+		for i := range widths {
+			widths[i] *= scale
+		}
+	}
+
+	lineHeight := font.Size * l.LineHeight
+	box := Box{W: widest, H: font.Size + lineHeight*float64(len(b.Lines)-1)}
+
+	switch b.Anchor {
+	case AnchorTopLeft, AnchorLeft, AnchorBottomLeft:
+		box.X = l.Padding
+	case AnchorTop, AnchorCenter, AnchorBottom:
+		box.X = (l.Width - box.W) / 2
+	default:
+		box.X = l.Width - l.Padding - box.W
+	}
+
+	switch b.Anchor {
+	case AnchorTopLeft, AnchorTop, AnchorTopRight:
+		box.Y = l.Padding
+	case AnchorLeft, AnchorCenter, AnchorRight:
+		box.Y = (l.Height - box.H) / 2
+	default:
+		box.Y = l.Height - l.Padding - box.H
+	}
+
+	box.X += b.Offset.X
+	box.Y += b.Offset.Y
+
+	placed := make([]PlacedText, len(b.Lines))
+	for i, line := range b.Lines {
+		placed[i] = PlacedText{
+			Text: line,
+			X:    l.alignX(b.Anchor, box, widths[i]),
+			Y:    box.Y + font.Size*ascent + lineHeight*float64(i),
+			Font: font,
+		}
+	}
+
+	return placed, box
+}
+
+// FitRow returns font, shrunk if needed so that texts fit side by side on one
+// line with at least the padding between them.
+func (l Layout) FitRow(r ports.Renderer, font ports.Font, texts ...string) ports.Font {
+	total := l.Padding * float64(len(texts)-1)
+	for _, text := range texts {
+		total += r.MeasureText(text, font)
+	}
+
+	if scale := l.fitScale(font, total); scale < 1 {
+		font.Size *= scale
+	}
+
+	return font
+}
+
+// fitScale is the factor that makes width fit the court, bounded by MinFontSize.
+func (l Layout) fitScale(font ports.Font, width float64) float64 {
+	available := l.Width - 2*l.Padding
+	if width <= available || width <= 0 || font.Size <= 0 {
+		return 1
+	}
+
+	return math.Max(available/width, math.Min(1, l.MinFontSize/font.Size))
+}
+
+// alignX aligns each line of a block on the side of its anchor.
+func (l Layout) alignX(anchor Anchor, box Box, width float64) float64 {
+	switch anchor {
+	case AnchorTopLeft, AnchorLeft, AnchorBottomLeft:
+		return box.X
+	case AnchorTop, AnchorCenter, AnchorBottom:
+		return box.X + (box.W-width)/2
+	default:
+		return box.X + box.W - width
+	}
+}
@@ -0,0 +1,210 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/internal/ports/mocks"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		width       float64
+		height      float64
+		wantPadding float64
+	}{
+		{
+			name:        "Logical court",
+			width:       800,
+			height:      600,
+			wantPadding: 15,
+		},
+		{
+			name:        "Portrait court uses the width",
+			width:       360,
+			height:      640,
+			wantPadding: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLayout(tt.width, tt.height).Padding; got != tt.wantPadding {
+				t.Errorf("NewLayout().Padding = %v, want %v", got, tt.wantPadding)
+			}
+		})
+	}
+}
+
+func TestLayoutPlaceAnchors(t *testing.T) {
+	font := ports.Font{Family: "Arial", Size: 20}
+
+	tests := []struct {
+		name   string
+		anchor Anchor
+		offset ports.Point
+		wantX  float64
+		wantY  float64
+	}{
+		{name: "Top left", anchor: AnchorTopLeft, wantX: 10, wantY: 10},
+		{name: "Top", anchor: AnchorTop, wantX: 150, wantY: 10},
+		{name: "Top right", anchor: AnchorTopRight, wantX: 290, wantY: 10},
+		{name: "Left", anchor: AnchorLeft, wantX: 10, wantY: 80},
+		{name: "Center", anchor: AnchorCenter, wantX: 150, wantY: 80},
+		{name: "Right", anchor: AnchorRight, wantX: 290, wantY: 80},
+		{name: "Bottom left", anchor: AnchorBottomLeft, wantX: 10, wantY: 150},
+		{name: "Bottom", anchor: AnchorBottom, wantX: 150, wantY: 150},
+		{name: "Bottom right", anchor: AnchorBottomRight, wantX: 290, wantY: 150},
+		{name: "Offset", anchor: AnchorTopLeft, offset: ports.Point{X: 5, Y: 30}, wantX: 15, wantY: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Layout{Width: 400, Height: 200, Padding: 10, LineHeight: 1, MinFontSize: 8}
+
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("MeasureText", "wide", font).Return(100.0)
+			mockRenderer.On("MeasureText", "thin", font).Return(40.0)
+
+			placed, box := l.Place(mockRenderer, TextBlock{Anchor: tt.anchor, Lines: []string{"wide", "thin"}, Font: font, Offset: tt.offset})
+
+			want := Box{X: tt.wantX, Y: tt.wantY, W: 100, H: 40}
+			if box != want {
+				t.Fatalf("Place() box = %+v, want %+v", box, want)
+			}
+			if len(placed) != 2 {
+				t.Fatalf("Place() lines = %d, want 2", len(placed))
+			}
+			if placed[0].Y != tt.wantY+16 || placed[1].Y != tt.wantY+36 {
+				t.Errorf("Place() baselines = %v, %v, want %v, %v", placed[0].Y, placed[1].Y, tt.wantY+16, tt.wantY+36)
+			}
+		})
+	}
+}
+
+func TestLayoutPlaceAlignsLines(t *testing.T) {
+	font := ports.Font{Family: "Arial", Size: 20}
+
+	tests := []struct {
+		name      string
+		anchor    Anchor
+		wantThinX float64
+	}{
+		{name: "Left side aligns left", anchor: AnchorLeft, wantThinX: 10},
+		{name: "Middle centers", anchor: AnchorCenter, wantThinX: 180},
+		{name: "Right side aligns right", anchor: AnchorRight, wantThinX: 350},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Layout{Width: 400, Height: 200, Padding: 10, LineHeight: 1, MinFontSize: 8}
+
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("MeasureText", "wide", font).Return(100.0)
+			mockRenderer.On("MeasureText", "thin", font).Return(40.0)
+
+			placed, _ := l.Place(mockRenderer, TextBlock{Anchor: tt.anchor, Lines: []string{"wide", "thin"}, Font: font})
+
+			if placed[1].X != tt.wantThinX {
+				t.Errorf("Place() thin line x = %v, want %v", placed[1].X, tt.wantThinX)
+			}
+		})
+	}
+}
+
+func TestLayoutPlaceShrinksToFit(t *testing.T) {
+	font := ports.Font{Family: "Arial", Size: 20}
+
+	tests := []struct {
+		name     string
+		width    float64
+		wantSize float64
+		wantW    float64
+	}{
+		{
+			name:     "Fitting text keeps its size",
+			width:    380,
+			wantSize: 20,
+			wantW:    380,
+		},
+		{
+			name:     "Wide text shrinks to the court",
+			width:    760,
+			wantSize: 10,
+			wantW:    380,
+		},
+		{
+			name:     "Shrinking stops at the minimum size",
+			width:    1900,
+			wantSize: 8,
+			wantW:    760,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Layout{Width: 400, Height: 200, Padding: 10, LineHeight: 1.25, MinFontSize: 8}
+
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("MeasureText", "text", font).Return(tt.width)
+
+			placed, box := l.Place(mockRenderer, TextBlock{Anchor: AnchorTopLeft, Lines: []string{"text"}, Font: font})
+
+			if placed[0].Font.Size != tt.wantSize {
+				t.Errorf("Place() font size = %v, want %v", placed[0].Font.Size, tt.wantSize)
+			}
+			if box.W != tt.wantW {
+				t.Errorf("Place() width = %v, want %v", box.W, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestLayoutPlaceEmpty(t *testing.T) {
+	mockRenderer := mocks.NewRenderer(t)
+
+	placed, box := NewLayout(800, 600).Place(mockRenderer, TextBlock{Anchor: AnchorCenter})
+
+	if placed != nil || box != (Box{}) {
+		t.Errorf("Place() = %v, %+v, want nothing", placed, box)
+	}
+	mockRenderer.AssertNotCalled(t, "MeasureText", mock.Anything, mock.Anything)
+}
+
+func TestLayoutFitRow(t *testing.T) {
+	font := ports.Font{Family: "Arial", Size: 20}
+
+	tests := []struct {
+		name     string
+		widths   []float64
+		wantSize float64
+	}{
+		{
+			name:     "Row fits",
+			widths:   []float64{100, 100},
+			wantSize: 20,
+		},
+		{
+			name:     "Padding between texts is kept",
+			widths:   []float64{370, 380},
+			wantSize: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Layout{Width: 400, Height: 200, Padding: 10, LineHeight: 1.25, MinFontSize: 8}
+
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("MeasureText", "a", font).Return(tt.widths[0])
+			mockRenderer.On("MeasureText", "b", font).Return(tt.widths[1])
+
+			if got := l.FitRow(mockRenderer, font, "a", "b"); got.Size != tt.wantSize {
+				t.Errorf("FitRow() size = %v, want %v", got.Size, tt.wantSize)
+			}
+		})
+	}
+}
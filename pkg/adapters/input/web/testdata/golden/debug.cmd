@@ -0,0 +1,13 @@
+clear #000000
+rect 500 200 10 10 fill=#ffffff
+rect 10 270 10 60 fill=#ffffff
+text 15 31 "Score: 1,230" font="Arial" size=20 color=#ffffff
+text 689 31 "Lives: 3" font="Arial" size=20 color=#ffffff
+text 15 59.6 "Game:....." font="monospace" size=12 color=#ffff00
+text 15 74.6 "FPS:      30" font="monospace" size=12 color=#ffff00
+text 15 89.6 "Level:    0" font="monospace" size=12 color=#ffff00
+text 15 104.6 "Ball:....." font="monospace" size=12 color=#ffff00
+text 15 119.6 "Size:     10.0" font="monospace" size=12 color=#ffff00
+text 15 134.6 "Spawn:    [400.0, 344.0]" font="monospace" size=12 color=#ffff00
+text 15 149.6 "Position: [500.0, 200.0]" font="monospace" size=12 color=#ffff00
+text 15 164.6 "Velocity: [-200.00, -200.00]" font="monospace" size=12 color=#ffff00
@@ -0,0 +1,7 @@
+clear #000000
+rect 2 2 796 596 stroke=#ffffff width=4
+text 15 34.2 "Pontos: 1.230" font="Arial" size=24 bold color=#ffffff
+text 669.8 34.2 "Vidas: 3" font="Arial" size=24 bold color=#ffffff
+text 212.8 277.2 "FIM DE JOGO - 1.230 PONTOS" font="Arial" size=24 bold color=#ffffff
+text 169.6 307.2 "(CLIQUE ESQUERDO PARA REINICIAR)" font="Arial" size=24 bold color=#ffffff
+text 112 337.2 "(CLIQUE DIREITO: COPIAR LINK DO DESAFIO)" font="Arial" size=24 bold color=#ffffff
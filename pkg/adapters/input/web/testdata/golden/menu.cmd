@@ -0,0 +1,6 @@
+clear #000000
+text 15 31 "Score: 1,230" font="Arial" size=20 color=#ffffff
+text 689 31 "Lives: 3" font="Arial" size=20 color=#ffffff
+text 232 281 "SQUASH - LEFT CLICK TO START" font="Arial" size=20 color=#ffffff
+text 202 306 "(RIGHT CLICK TO PAUSE / SETTINGS)" font="Arial" size=20 color=#ffffff
+text 220 331 "< NORMAL > (WHEEL: DIFFICULTY)" font="Arial" size=20 color=#ffffff
@@ -0,0 +1,5 @@
+clear #f4f4f4
+rect 0.5 0.5 799 599 stroke=#cccccc width=1
+text 15 31 "Score: 1,230" font="Arial" size=20 color=#222222
+text 689 31 "Lives: 3" font="Arial" size=20 color=#222222
+text 220 306 "PAUSED - RIGHT CLICK TO RESUME" font="Arial" size=20 color=#222222
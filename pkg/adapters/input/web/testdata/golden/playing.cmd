@@ -0,0 +1,5 @@
+clear #000000
+rect 500 200 10 10 fill=#ffffff
+rect 10 270 10 60 fill=#ffffff
+text 15 31 "Score: 1,230" font="Arial" size=20 color=#ffffff
+text 689 31 "Lives: 3" font="Arial" size=20 color=#ffffff
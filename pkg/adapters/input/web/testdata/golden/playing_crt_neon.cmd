@@ -0,0 +1,508 @@
+clear #0b0221
+alpha 0.25
+polygon 32.734,24.781 75.386,21.992 119.34,19.574 164.411,17.529 210.412,15.855 257.158,14.554 304.462,13.624 352.138,13.066 400,12.88 447.862,13.066 495.538,13.624 542.842,14.554 589.588,15.855 635.589,17.529 680.66,19.574 724.614,21.992 767.266,24.781 772.11,67.625 776.074,112.12 779.156,157.935 781.358,204.74 782.679,252.205 783.12,300 782.679,347.795 781.358,395.26 779.156,442.065 776.074,487.88 772.11,532.375 767.266,575.219 724.614,578.008 680.66,580.426 635.589,582.471 589.588,584.145 542.842,585.446 495.538,586.376 447.862,586.934 400,587.12 352.138,586.934 304.462,586.376 257.158,585.446 210.412,584.145 164.411,582.471 119.34,580.426 75.386,578.008 32.734,575.219 27.89,532.375 23.926,487.88 20.844,442.065 18.642,395.26 17.321,347.795 16.88,300 17.321,252.205 18.642,204.74 20.844,157.935 23.926,112.12 27.89,67.625 stroke=#ff2a6d width=8
+alpha 1
+polygon 32.734,24.781 75.386,21.992 119.34,19.574 164.411,17.529 210.412,15.855 257.158,14.554 304.462,13.624 352.138,13.066 400,12.88 447.862,13.066 495.538,13.624 542.842,14.554 589.588,15.855 635.589,17.529 680.66,19.574 724.614,21.992 767.266,24.781 772.11,67.625 776.074,112.12 779.156,157.935 781.358,204.74 782.679,252.205 783.12,300 782.679,347.795 781.358,395.26 779.156,442.065 776.074,487.88 772.11,532.375 767.266,575.219 724.614,578.008 680.66,580.426 635.589,582.471 589.588,584.145 542.842,585.446 495.538,586.376 447.862,586.934 400,587.12 352.138,586.934 304.462,586.376 257.158,585.446 210.412,584.145 164.411,582.471 119.34,580.426 75.386,578.008 32.734,575.219 27.89,532.375 23.926,487.88 20.844,442.065 18.642,395.26 17.321,347.795 16.88,300 17.321,252.205 18.642,204.74 20.844,157.935 23.926,112.12 27.89,67.625 stroke=#ff2a6d width=2
+alpha 0.25
+polygon 501.409,200.692 507.826,200.726 507.887,207.126 501.466,207.094 stroke=#05d9e840 width=6
+alpha 1
+polygon 501.409,200.692 507.826,200.726 507.887,207.126 501.466,207.094 fill=#05d9e840
+alpha 0.25
+polygon 470.057,213.634 479.98,213.667 480.039,223.562 470.108,223.533 stroke=#05d9e8 width=7
+alpha 1
+polygon 470.057,213.634 479.98,213.667 480.039,223.562 470.108,223.533 fill=#05d9e8 stroke=#d1f7ff width=1
+alpha 0.25
+polygon 25.34,269.456 34.226,269.395 34.057,298.3 34.191,327.209 25.304,327.155 25.166,298.303 stroke=#ff2a6d width=7
+alpha 1
+polygon 25.34,269.456 34.226,269.395 34.057,298.3 34.191,327.209 25.304,327.155 25.166,298.303 fill=#ff2a6d stroke=#d1f7ff width=1
+alpha 0.25
+polygon 30.816,295.263 33.488,295.261 33.485,298.152 30.813,298.153 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 30.816,295.263 33.488,295.261 33.485,298.152 30.813,298.153 fill=#ff2a6df2
+alpha 0.25
+polygon 31.332,297.476 34.005,297.474 34.004,300.366 31.331,300.366 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 31.332,297.476 34.005,297.474 34.004,300.366 31.331,300.366 fill=#ff2a6df2
+alpha 0.25
+polygon 33.665,297.301 36.342,297.299 36.341,300.193 33.663,300.193 stroke=#ff2a6ded width=6
+alpha 1
+polygon 33.665,297.301 36.342,297.299 36.341,300.193 33.663,300.193 fill=#ff2a6ded
+alpha 0.25
+polygon 32.197,298.561 34.872,298.561 34.872,301.453 32.197,301.452 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 32.197,298.561 34.872,298.561 34.872,301.453 32.197,301.452 fill=#ff2a6df2
+alpha 0.25
+polygon 33.037,298.357 35.713,298.356 35.713,301.249 33.037,301.249 stroke=#ff2a6def width=6
+alpha 1
+polygon 33.037,298.357 35.713,298.356 35.713,301.249 33.037,301.249 fill=#ff2a6def
+alpha 0.25
+polygon 31.492,297.119 34.165,297.117 34.164,300.009 31.49,300.009 stroke=#ff2a6def width=6
+alpha 1
+polygon 31.492,297.119 34.165,297.117 34.164,300.009 31.49,300.009 fill=#ff2a6def
+alpha 0.25
+polygon 32.231,295.743 34.906,295.741 34.903,298.633 32.228,298.634 stroke=#ff2a6dee width=6
+alpha 1
+polygon 32.231,295.743 34.906,295.741 34.903,298.633 32.228,298.634 fill=#ff2a6dee
+alpha 0.25
+polygon 31.591,298.315 34.265,298.314 34.264,301.206 31.591,301.205 stroke=#ff2a6df3 width=6
+alpha 1
+polygon 31.591,298.315 34.265,298.314 34.264,301.206 31.591,301.205 fill=#ff2a6df3
+alpha 0.25
+polygon 32.378,298.187 35.053,298.186 35.052,301.078 32.377,301.078 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 32.378,298.187 35.053,298.186 35.052,301.078 32.377,301.078 fill=#ff2a6df2
+alpha 0.25
+polygon 33.333,299.228 36.01,299.227 36.011,302.121 33.334,302.119 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 33.333,299.228 36.01,299.227 36.011,302.121 33.334,302.119 fill=#ff2a6df2
+alpha 0.25
+polygon 31.926,296.726 34.6,296.724 34.598,299.616 31.924,299.616 stroke=#ff2a6ded width=6
+alpha 1
+polygon 31.926,296.726 34.6,296.724 34.598,299.616 31.924,299.616 fill=#ff2a6ded
+alpha 0.25
+polygon 30.793,295.189 33.465,295.186 33.461,298.077 30.789,298.079 stroke=#ff2a6ded width=6
+alpha 1
+polygon 30.793,295.189 33.465,295.186 33.461,298.077 30.789,298.079 fill=#ff2a6ded
+alpha 1
+text 41.648 49.619 "Score: 1,230" font="Courier New" size=20 bold color=#d1f7ff
+text 673.671 45.268 "Lives: 3" font="Courier New" size=20 bold color=#d1f7ff
+alpha 0.35
+rect 0 2 800 2 fill=#000000
+rect 0 6 800 2 fill=#000000
+rect 0 10 800 2 fill=#000000
+rect 0 14 800 2 fill=#000000
+rect 0 18 800 2 fill=#000000
+rect 0 22 800 2 fill=#000000
+rect 0 26 800 2 fill=#000000
+rect 0 30 800 2 fill=#000000
+rect 0 34 800 2 fill=#000000
+rect 0 38 800 2 fill=#000000
+rect 0 42 800 2 fill=#000000
+rect 0 46 800 2 fill=#000000
+rect 0 50 800 2 fill=#000000
+rect 0 54 800 2 fill=#000000
+rect 0 58 800 2 fill=#000000
+rect 0 62 800 2 fill=#000000
+rect 0 66 800 2 fill=#000000
+rect 0 70 800 2 fill=#000000
+rect 0 74 800 2 fill=#000000
+rect 0 78 800 2 fill=#000000
+rect 0 82 800 2 fill=#000000
+rect 0 86 800 2 fill=#000000
+rect 0 90 800 2 fill=#000000
+rect 0 94 800 2 fill=#000000
+rect 0 98 800 2 fill=#000000
+rect 0 102 800 2 fill=#000000
+rect 0 106 800 2 fill=#000000
+rect 0 110 800 2 fill=#000000
+rect 0 114 800 2 fill=#000000
+rect 0 118 800 2 fill=#000000
+rect 0 122 800 2 fill=#000000
+rect 0 126 800 2 fill=#000000
+rect 0 130 800 2 fill=#000000
+rect 0 134 800 2 fill=#000000
+rect 0 138 800 2 fill=#000000
+rect 0 142 800 2 fill=#000000
+rect 0 146 800 2 fill=#000000
+rect 0 150 800 2 fill=#000000
+rect 0 154 800 2 fill=#000000
+rect 0 158 800 2 fill=#000000
+rect 0 162 800 2 fill=#000000
+rect 0 166 800 2 fill=#000000
+rect 0 170 800 2 fill=#000000
+rect 0 174 800 2 fill=#000000
+rect 0 178 800 2 fill=#000000
+rect 0 182 800 2 fill=#000000
+rect 0 186 800 2 fill=#000000
+rect 0 190 800 2 fill=#000000
+rect 0 194 800 2 fill=#000000
+rect 0 198 800 2 fill=#000000
+rect 0 202 800 2 fill=#000000
+rect 0 206 800 2 fill=#000000
+rect 0 210 800 2 fill=#000000
+rect 0 214 800 2 fill=#000000
+rect 0 218 800 2 fill=#000000
+rect 0 222 800 2 fill=#000000
+rect 0 226 800 2 fill=#000000
+rect 0 230 800 2 fill=#000000
+rect 0 234 800 2 fill=#000000
+rect 0 238 800 2 fill=#000000
+rect 0 242 800 2 fill=#000000
+rect 0 246 800 2 fill=#000000
+rect 0 250 800 2 fill=#000000
+rect 0 254 800 2 fill=#000000
+rect 0 258 800 2 fill=#000000
+rect 0 262 800 2 fill=#000000
+rect 0 266 800 2 fill=#000000
+rect 0 270 800 2 fill=#000000
+rect 0 274 800 2 fill=#000000
+rect 0 278 800 2 fill=#000000
+rect 0 282 800 2 fill=#000000
+rect 0 286 800 2 fill=#000000
+rect 0 290 800 2 fill=#000000
+rect 0 294 800 2 fill=#000000
+rect 0 298 800 2 fill=#000000
+rect 0 302 800 2 fill=#000000
+rect 0 306 800 2 fill=#000000
+rect 0 310 800 2 fill=#000000
+rect 0 314 800 2 fill=#000000
+rect 0 318 800 2 fill=#000000
+rect 0 322 800 2 fill=#000000
+rect 0 326 800 2 fill=#000000
+rect 0 330 800 2 fill=#000000
+rect 0 334 800 2 fill=#000000
+rect 0 338 800 2 fill=#000000
+rect 0 342 800 2 fill=#000000
+rect 0 346 800 2 fill=#000000
+rect 0 350 800 2 fill=#000000
+rect 0 354 800 2 fill=#000000
+rect 0 358 800 2 fill=#000000
+rect 0 362 800 2 fill=#000000
+rect 0 366 800 2 fill=#000000
+rect 0 370 800 2 fill=#000000
+rect 0 374 800 2 fill=#000000
+rect 0 378 800 2 fill=#000000
+rect 0 382 800 2 fill=#000000
+rect 0 386 800 2 fill=#000000
+rect 0 390 800 2 fill=#000000
+rect 0 394 800 2 fill=#000000
+rect 0 398 800 2 fill=#000000
+rect 0 402 800 2 fill=#000000
+rect 0 406 800 2 fill=#000000
+rect 0 410 800 2 fill=#000000
+rect 0 414 800 2 fill=#000000
+rect 0 418 800 2 fill=#000000
+rect 0 422 800 2 fill=#000000
+rect 0 426 800 2 fill=#000000
+rect 0 430 800 2 fill=#000000
+rect 0 434 800 2 fill=#000000
+rect 0 438 800 2 fill=#000000
+rect 0 442 800 2 fill=#000000
+rect 0 446 800 2 fill=#000000
+rect 0 450 800 2 fill=#000000
+rect 0 454 800 2 fill=#000000
+rect 0 458 800 2 fill=#000000
+rect 0 462 800 2 fill=#000000
+rect 0 466 800 2 fill=#000000
+rect 0 470 800 2 fill=#000000
+rect 0 474 800 2 fill=#000000
+rect 0 478 800 2 fill=#000000
+rect 0 482 800 2 fill=#000000
+rect 0 486 800 2 fill=#000000
+rect 0 490 800 2 fill=#000000
+rect 0 494 800 2 fill=#000000
+rect 0 498 800 2 fill=#000000
+rect 0 502 800 2 fill=#000000
+rect 0 506 800 2 fill=#000000
+rect 0 510 800 2 fill=#000000
+rect 0 514 800 2 fill=#000000
+rect 0 518 800 2 fill=#000000
+rect 0 522 800 2 fill=#000000
+rect 0 526 800 2 fill=#000000
+rect 0 530 800 2 fill=#000000
+rect 0 534 800 2 fill=#000000
+rect 0 538 800 2 fill=#000000
+rect 0 542 800 2 fill=#000000
+rect 0 546 800 2 fill=#000000
+rect 0 550 800 2 fill=#000000
+rect 0 554 800 2 fill=#000000
+rect 0 558 800 2 fill=#000000
+rect 0 562 800 2 fill=#000000
+rect 0 566 800 2 fill=#000000
+rect 0 570 800 2 fill=#000000
+rect 0 574 800 2 fill=#000000
+rect 0 578 800 2 fill=#000000
+rect 0 582 800 2 fill=#000000
+rect 0 586 800 2 fill=#000000
+rect 0 590 800 2 fill=#000000
+rect 0 594 800 2 fill=#000000
+rect 0 598 800 2 fill=#000000
+alpha 1
+polygon 0,0 800,0 768,24 725.281,21.188 681.25,18.75 636.094,16.688 590,15 543.156,13.688 495.75,12.75 447.969,12.188 400,12 352.031,12.188 304.25,12.75 256.844,13.688 210,15 163.906,16.688 118.75,18.75 74.719,21.188 32,24 fill=#000000
+polygon 800,0 800,600 768,576 772.889,533.056 776.889,488.444 780,442.5 782.222,395.556 783.556,347.944 784,300 783.556,252.056 782.222,204.444 780,157.5 776.889,111.556 772.889,66.944 768,24 fill=#000000
+polygon 800,600 0,600 32,576 74.719,578.813 118.75,581.25 163.906,583.313 210,585 256.844,586.313 304.25,587.25 352.031,587.813 400,588 447.969,587.813 495.75,587.25 543.156,586.313 590,585 636.094,583.313 681.25,581.25 725.281,578.813 768,576 fill=#000000
+polygon 0,600 0,0 32,24 27.111,66.944 23.111,111.556 20,157.5 17.778,204.444 16.444,252.056 16,300 16.444,347.944 17.778,395.556 20,442.5 23.111,488.444 27.111,533.056 32,576 fill=#000000
+clear #0b0221
+alpha 0.25
+polygon 32.734,24.781 75.386,21.992 119.34,19.574 164.411,17.529 210.412,15.855 257.158,14.554 304.462,13.624 352.138,13.066 400,12.88 447.862,13.066 495.538,13.624 542.842,14.554 589.588,15.855 635.589,17.529 680.66,19.574 724.614,21.992 767.266,24.781 772.11,67.625 776.074,112.12 779.156,157.935 781.358,204.74 782.679,252.205 783.12,300 782.679,347.795 781.358,395.26 779.156,442.065 776.074,487.88 772.11,532.375 767.266,575.219 724.614,578.008 680.66,580.426 635.589,582.471 589.588,584.145 542.842,585.446 495.538,586.376 447.862,586.934 400,587.12 352.138,586.934 304.462,586.376 257.158,585.446 210.412,584.145 164.411,582.471 119.34,580.426 75.386,578.008 32.734,575.219 27.89,532.375 23.926,487.88 20.844,442.065 18.642,395.26 17.321,347.795 16.88,300 17.321,252.205 18.642,204.74 20.844,157.935 23.926,112.12 27.89,67.625 stroke=#ff2a6d width=8
+alpha 1
+polygon 32.734,24.781 75.386,21.992 119.34,19.574 164.411,17.529 210.412,15.855 257.158,14.554 304.462,13.624 352.138,13.066 400,12.88 447.862,13.066 495.538,13.624 542.842,14.554 589.588,15.855 635.589,17.529 680.66,19.574 724.614,21.992 767.266,24.781 772.11,67.625 776.074,112.12 779.156,157.935 781.358,204.74 782.679,252.205 783.12,300 782.679,347.795 781.358,395.26 779.156,442.065 776.074,487.88 772.11,532.375 767.266,575.219 724.614,578.008 680.66,580.426 635.589,582.471 589.588,584.145 542.842,585.446 495.538,586.376 447.862,586.934 400,587.12 352.138,586.934 304.462,586.376 257.158,585.446 210.412,584.145 164.411,582.471 119.34,580.426 75.386,578.008 32.734,575.219 27.89,532.375 23.926,487.88 20.844,442.065 18.642,395.26 17.321,347.795 16.88,300 17.321,252.205 18.642,204.74 20.844,157.935 23.926,112.12 27.89,67.625 stroke=#ff2a6d width=2
+alpha 0.075
+polygon 501.409,200.692 507.826,200.726 507.887,207.126 501.466,207.094 stroke=#05d9e840 width=6
+alpha 0.3
+polygon 501.409,200.692 507.826,200.726 507.887,207.126 501.466,207.094 fill=#05d9e840
+alpha 0.075
+polygon 470.057,213.634 479.98,213.667 480.039,223.562 470.108,223.533 stroke=#05d9e8 width=7
+alpha 0.3
+polygon 470.057,213.634 479.98,213.667 480.039,223.562 470.108,223.533 fill=#05d9e8 stroke=#d1f7ff width=1
+alpha 0.075
+polygon 25.34,269.456 34.226,269.395 34.057,298.3 34.191,327.209 25.304,327.155 25.166,298.303 stroke=#ff2a6d width=7
+alpha 0.3
+polygon 25.34,269.456 34.226,269.395 34.057,298.3 34.191,327.209 25.304,327.155 25.166,298.303 fill=#ff2a6d stroke=#d1f7ff width=1
+alpha 0.075
+polygon 30.816,295.263 33.488,295.261 33.485,298.152 30.813,298.153 stroke=#ff2a6df2 width=6
+alpha 0.3
+polygon 30.816,295.263 33.488,295.261 33.485,298.152 30.813,298.153 fill=#ff2a6df2
+alpha 0.075
+polygon 31.332,297.476 34.005,297.474 34.004,300.366 31.331,300.366 stroke=#ff2a6df2 width=6
+alpha 0.3
+polygon 31.332,297.476 34.005,297.474 34.004,300.366 31.331,300.366 fill=#ff2a6df2
+alpha 0.075
+polygon 33.665,297.301 36.342,297.299 36.341,300.193 33.663,300.193 stroke=#ff2a6ded width=6
+alpha 0.3
+polygon 33.665,297.301 36.342,297.299 36.341,300.193 33.663,300.193 fill=#ff2a6ded
+alpha 0.075
+polygon 32.197,298.561 34.872,298.561 34.872,301.453 32.197,301.452 stroke=#ff2a6df2 width=6
+alpha 0.3
+polygon 32.197,298.561 34.872,298.561 34.872,301.453 32.197,301.452 fill=#ff2a6df2
+alpha 0.075
+polygon 33.037,298.357 35.713,298.356 35.713,301.249 33.037,301.249 stroke=#ff2a6def width=6
+alpha 0.3
+polygon 33.037,298.357 35.713,298.356 35.713,301.249 33.037,301.249 fill=#ff2a6def
+alpha 0.075
+polygon 31.492,297.119 34.165,297.117 34.164,300.009 31.49,300.009 stroke=#ff2a6def width=6
+alpha 0.3
+polygon 31.492,297.119 34.165,297.117 34.164,300.009 31.49,300.009 fill=#ff2a6def
+alpha 0.075
+polygon 32.231,295.743 34.906,295.741 34.903,298.633 32.228,298.634 stroke=#ff2a6dee width=6
+alpha 0.3
+polygon 32.231,295.743 34.906,295.741 34.903,298.633 32.228,298.634 fill=#ff2a6dee
+alpha 0.075
+polygon 31.591,298.315 34.265,298.314 34.264,301.206 31.591,301.205 stroke=#ff2a6df3 width=6
+alpha 0.3
+polygon 31.591,298.315 34.265,298.314 34.264,301.206 31.591,301.205 fill=#ff2a6df3
+alpha 0.075
+polygon 32.378,298.187 35.053,298.186 35.052,301.078 32.377,301.078 stroke=#ff2a6df2 width=6
+alpha 0.3
+polygon 32.378,298.187 35.053,298.186 35.052,301.078 32.377,301.078 fill=#ff2a6df2
+alpha 0.075
+polygon 33.333,299.228 36.01,299.227 36.011,302.121 33.334,302.119 stroke=#ff2a6df2 width=6
+alpha 0.3
+polygon 33.333,299.228 36.01,299.227 36.011,302.121 33.334,302.119 fill=#ff2a6df2
+alpha 0.075
+polygon 31.926,296.726 34.6,296.724 34.598,299.616 31.924,299.616 stroke=#ff2a6ded width=6
+alpha 0.3
+polygon 31.926,296.726 34.6,296.724 34.598,299.616 31.924,299.616 fill=#ff2a6ded
+alpha 0.075
+polygon 30.793,295.189 33.465,295.186 33.461,298.077 30.789,298.079 stroke=#ff2a6ded width=6
+alpha 0.3
+polygon 30.793,295.189 33.465,295.186 33.461,298.077 30.789,298.079 fill=#ff2a6ded
+alpha 0.25
+polygon 501.409,200.692 507.826,200.726 507.887,207.126 501.466,207.094 stroke=#05d9e840 width=6
+alpha 1
+polygon 501.409,200.692 507.826,200.726 507.887,207.126 501.466,207.094 fill=#05d9e840
+alpha 0.25
+polygon 499.664,198.961 509.535,199.015 509.63,208.859 499.751,208.811 stroke=#05d9e8 width=7
+alpha 1
+polygon 499.664,198.961 509.535,199.015 509.63,208.859 499.751,208.811 fill=#05d9e8 stroke=#d1f7ff width=1
+alpha 0.25
+polygon 25.34,269.456 34.226,269.395 34.057,298.3 34.191,327.209 25.304,327.155 25.166,298.303 stroke=#ff2a6d width=7
+alpha 1
+polygon 25.34,269.456 34.226,269.395 34.057,298.3 34.191,327.209 25.304,327.155 25.166,298.303 fill=#ff2a6d stroke=#d1f7ff width=1
+alpha 0.25
+polygon 30.816,295.263 33.488,295.261 33.485,298.152 30.813,298.153 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 30.816,295.263 33.488,295.261 33.485,298.152 30.813,298.153 fill=#ff2a6df2
+alpha 0.25
+polygon 31.332,297.476 34.005,297.474 34.004,300.366 31.331,300.366 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 31.332,297.476 34.005,297.474 34.004,300.366 31.331,300.366 fill=#ff2a6df2
+alpha 0.25
+polygon 33.665,297.301 36.342,297.299 36.341,300.193 33.663,300.193 stroke=#ff2a6ded width=6
+alpha 1
+polygon 33.665,297.301 36.342,297.299 36.341,300.193 33.663,300.193 fill=#ff2a6ded
+alpha 0.25
+polygon 32.197,298.561 34.872,298.561 34.872,301.453 32.197,301.452 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 32.197,298.561 34.872,298.561 34.872,301.453 32.197,301.452 fill=#ff2a6df2
+alpha 0.25
+polygon 33.037,298.357 35.713,298.356 35.713,301.249 33.037,301.249 stroke=#ff2a6def width=6
+alpha 1
+polygon 33.037,298.357 35.713,298.356 35.713,301.249 33.037,301.249 fill=#ff2a6def
+alpha 0.25
+polygon 31.492,297.119 34.165,297.117 34.164,300.009 31.49,300.009 stroke=#ff2a6def width=6
+alpha 1
+polygon 31.492,297.119 34.165,297.117 34.164,300.009 31.49,300.009 fill=#ff2a6def
+alpha 0.25
+polygon 32.231,295.743 34.906,295.741 34.903,298.633 32.228,298.634 stroke=#ff2a6dee width=6
+alpha 1
+polygon 32.231,295.743 34.906,295.741 34.903,298.633 32.228,298.634 fill=#ff2a6dee
+alpha 0.25
+polygon 31.591,298.315 34.265,298.314 34.264,301.206 31.591,301.205 stroke=#ff2a6df3 width=6
+alpha 1
+polygon 31.591,298.315 34.265,298.314 34.264,301.206 31.591,301.205 fill=#ff2a6df3
+alpha 0.25
+polygon 32.378,298.187 35.053,298.186 35.052,301.078 32.377,301.078 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 32.378,298.187 35.053,298.186 35.052,301.078 32.377,301.078 fill=#ff2a6df2
+alpha 0.25
+polygon 33.333,299.228 36.01,299.227 36.011,302.121 33.334,302.119 stroke=#ff2a6df2 width=6
+alpha 1
+polygon 33.333,299.228 36.01,299.227 36.011,302.121 33.334,302.119 fill=#ff2a6df2
+alpha 0.25
+polygon 31.926,296.726 34.6,296.724 34.598,299.616 31.924,299.616 stroke=#ff2a6ded width=6
+alpha 1
+polygon 31.926,296.726 34.6,296.724 34.598,299.616 31.924,299.616 fill=#ff2a6ded
+alpha 0.25
+polygon 30.793,295.189 33.465,295.186 33.461,298.077 30.789,298.079 stroke=#ff2a6ded width=6
+alpha 1
+polygon 30.793,295.189 33.465,295.186 33.461,298.077 30.789,298.079 fill=#ff2a6ded
+alpha 1
+text 41.648 49.619 "Score: 1,230" font="Courier New" size=20 bold color=#d1f7ff
+text 673.671 45.268 "Lives: 3" font="Courier New" size=20 bold color=#d1f7ff
+alpha 0.35
+rect 0 2 800 2 fill=#000000
+rect 0 6 800 2 fill=#000000
+rect 0 10 800 2 fill=#000000
+rect 0 14 800 2 fill=#000000
+rect 0 18 800 2 fill=#000000
+rect 0 22 800 2 fill=#000000
+rect 0 26 800 2 fill=#000000
+rect 0 30 800 2 fill=#000000
+rect 0 34 800 2 fill=#000000
+rect 0 38 800 2 fill=#000000
+rect 0 42 800 2 fill=#000000
+rect 0 46 800 2 fill=#000000
+rect 0 50 800 2 fill=#000000
+rect 0 54 800 2 fill=#000000
+rect 0 58 800 2 fill=#000000
+rect 0 62 800 2 fill=#000000
+rect 0 66 800 2 fill=#000000
+rect 0 70 800 2 fill=#000000
+rect 0 74 800 2 fill=#000000
+rect 0 78 800 2 fill=#000000
+rect 0 82 800 2 fill=#000000
+rect 0 86 800 2 fill=#000000
+rect 0 90 800 2 fill=#000000
+rect 0 94 800 2 fill=#000000
+rect 0 98 800 2 fill=#000000
+rect 0 102 800 2 fill=#000000
+rect 0 106 800 2 fill=#000000
+rect 0 110 800 2 fill=#000000
+rect 0 114 800 2 fill=#000000
+rect 0 118 800 2 fill=#000000
+rect 0 122 800 2 fill=#000000
+rect 0 126 800 2 fill=#000000
+rect 0 130 800 2 fill=#000000
+rect 0 134 800 2 fill=#000000
+rect 0 138 800 2 fill=#000000
+rect 0 142 800 2 fill=#000000
+rect 0 146 800 2 fill=#000000
+rect 0 150 800 2 fill=#000000
+rect 0 154 800 2 fill=#000000
+rect 0 158 800 2 fill=#000000
+rect 0 162 800 2 fill=#000000
+rect 0 166 800 2 fill=#000000
+rect 0 170 800 2 fill=#000000
+rect 0 174 800 2 fill=#000000
+rect 0 178 800 2 fill=#000000
+rect 0 182 800 2 fill=#000000
+rect 0 186 800 2 fill=#000000
+rect 0 190 800 2 fill=#000000
+rect 0 194 800 2 fill=#000000
+rect 0 198 800 2 fill=#000000
+rect 0 202 800 2 fill=#000000
+rect 0 206 800 2 fill=#000000
+rect 0 210 800 2 fill=#000000
+rect 0 214 800 2 fill=#000000
+rect 0 218 800 2 fill=#000000
+rect 0 222 800 2 fill=#000000
+rect 0 226 800 2 fill=#000000
+rect 0 230 800 2 fill=#000000
+rect 0 234 800 2 fill=#000000
+rect 0 238 800 2 fill=#000000
+rect 0 242 800 2 fill=#000000
+rect 0 246 800 2 fill=#000000
+rect 0 250 800 2 fill=#000000
+rect 0 254 800 2 fill=#000000
+rect 0 258 800 2 fill=#000000
+rect 0 262 800 2 fill=#000000
+rect 0 266 800 2 fill=#000000
+rect 0 270 800 2 fill=#000000
+rect 0 274 800 2 fill=#000000
+rect 0 278 800 2 fill=#000000
+rect 0 282 800 2 fill=#000000
+rect 0 286 800 2 fill=#000000
+rect 0 290 800 2 fill=#000000
+rect 0 294 800 2 fill=#000000
+rect 0 298 800 2 fill=#000000
+rect 0 302 800 2 fill=#000000
+rect 0 306 800 2 fill=#000000
+rect 0 310 800 2 fill=#000000
+rect 0 314 800 2 fill=#000000
+rect 0 318 800 2 fill=#000000
+rect 0 322 800 2 fill=#000000
+rect 0 326 800 2 fill=#000000
+rect 0 330 800 2 fill=#000000
+rect 0 334 800 2 fill=#000000
+rect 0 338 800 2 fill=#000000
+rect 0 342 800 2 fill=#000000
+rect 0 346 800 2 fill=#000000
+rect 0 350 800 2 fill=#000000
+rect 0 354 800 2 fill=#000000
+rect 0 358 800 2 fill=#000000
+rect 0 362 800 2 fill=#000000
+rect 0 366 800 2 fill=#000000
+rect 0 370 800 2 fill=#000000
+rect 0 374 800 2 fill=#000000
+rect 0 378 800 2 fill=#000000
+rect 0 382 800 2 fill=#000000
+rect 0 386 800 2 fill=#000000
+rect 0 390 800 2 fill=#000000
+rect 0 394 800 2 fill=#000000
+rect 0 398 800 2 fill=#000000
+rect 0 402 800 2 fill=#000000
+rect 0 406 800 2 fill=#000000
+rect 0 410 800 2 fill=#000000
+rect 0 414 800 2 fill=#000000
+rect 0 418 800 2 fill=#000000
+rect 0 422 800 2 fill=#000000
+rect 0 426 800 2 fill=#000000
+rect 0 430 800 2 fill=#000000
+rect 0 434 800 2 fill=#000000
+rect 0 438 800 2 fill=#000000
+rect 0 442 800 2 fill=#000000
+rect 0 446 800 2 fill=#000000
+rect 0 450 800 2 fill=#000000
+rect 0 454 800 2 fill=#000000
+rect 0 458 800 2 fill=#000000
+rect 0 462 800 2 fill=#000000
+rect 0 466 800 2 fill=#000000
+rect 0 470 800 2 fill=#000000
+rect 0 474 800 2 fill=#000000
+rect 0 478 800 2 fill=#000000
+rect 0 482 800 2 fill=#000000
+rect 0 486 800 2 fill=#000000
+rect 0 490 800 2 fill=#000000
+rect 0 494 800 2 fill=#000000
+rect 0 498 800 2 fill=#000000
+rect 0 502 800 2 fill=#000000
+rect 0 506 800 2 fill=#000000
+rect 0 510 800 2 fill=#000000
+rect 0 514 800 2 fill=#000000
+rect 0 518 800 2 fill=#000000
+rect 0 522 800 2 fill=#000000
+rect 0 526 800 2 fill=#000000
+rect 0 530 800 2 fill=#000000
+rect 0 534 800 2 fill=#000000
+rect 0 538 800 2 fill=#000000
+rect 0 542 800 2 fill=#000000
+rect 0 546 800 2 fill=#000000
+rect 0 550 800 2 fill=#000000
+rect 0 554 800 2 fill=#000000
+rect 0 558 800 2 fill=#000000
+rect 0 562 800 2 fill=#000000
+rect 0 566 800 2 fill=#000000
+rect 0 570 800 2 fill=#000000
+rect 0 574 800 2 fill=#000000
+rect 0 578 800 2 fill=#000000
+rect 0 582 800 2 fill=#000000
+rect 0 586 800 2 fill=#000000
+rect 0 590 800 2 fill=#000000
+rect 0 594 800 2 fill=#000000
+rect 0 598 800 2 fill=#000000
+alpha 1
+polygon 0,0 800,0 768,24 725.281,21.188 681.25,18.75 636.094,16.688 590,15 543.156,13.688 495.75,12.75 447.969,12.188 400,12 352.031,12.188 304.25,12.75 256.844,13.688 210,15 163.906,16.688 118.75,18.75 74.719,21.188 32,24 fill=#000000
+polygon 800,0 800,600 768,576 772.889,533.056 776.889,488.444 780,442.5 782.222,395.556 783.556,347.944 784,300 783.556,252.056 782.222,204.444 780,157.5 776.889,111.556 772.889,66.944 768,24 fill=#000000
+polygon 800,600 0,600 32,576 74.719,578.813 118.75,581.25 163.906,583.313 210,585 256.844,586.313 304.25,587.25 352.031,587.813 400,588 447.969,587.813 495.75,587.25 543.156,586.313 590,585 636.094,583.313 681.25,581.25 725.281,578.813 768,576 fill=#000000
+polygon 0,600 0,0 32,24 27.111,66.944 23.111,111.556 20,157.5 17.778,204.444 16.444,252.056 16,300 16.444,347.944 17.778,395.556 20,442.5 23.111,488.444 27.111,533.056 32,576 fill=#000000
@@ -0,0 +1,21 @@
+clear #000000
+save
+translate 0.379 -1.764
+rect 501.75 201.75 6.5 6.5 fill=#ffffff40
+rect 500 200 10 10 fill=#ffffff
+rect 10 270 10 60 fill=#ffffff
+rect 16.359 296.846 3 3 fill=#fffffff2
+rect 16.941 299.143 3 3 fill=#fffffff2
+rect 19.559 298.963 3 3 fill=#ffffffed
+rect 17.913 300.271 3 3 fill=#fffffff2
+rect 18.856 300.059 3 3 fill=#ffffffef
+rect 17.12 298.773 3 3 fill=#ffffffef
+rect 17.949 297.346 3 3 fill=#ffffffee
+rect 17.233 300.015 3 3 fill=#fffffff3
+rect 18.116 299.882 3 3 fill=#fffffff2
+rect 19.189 300.962 3 3 fill=#fffffff2
+rect 17.607 298.365 3 3 fill=#ffffffed
+rect 16.332 296.769 3 3 fill=#ffffffed
+restore
+text 15 31 "Score: 1,230" font="Arial" size=20 color=#ffffff
+text 689 31 "Lives: 3" font="Arial" size=20 color=#ffffff
@@ -0,0 +1,6 @@
+clear #0b0221
+rect 1 1 798 598 stroke=#ff2a6d width=2
+circle 505 205 5 fill=#05d9e8 stroke=#d1f7ff width=1
+rect 10 270 10 60 fill=#ff2a6d stroke=#d1f7ff width=1
+text 15 31 "Score: 1,230" font="Courier New" size=20 bold color=#d1f7ff
+text 689 31 "Lives: 3" font="Courier New" size=20 bold color=#d1f7ff
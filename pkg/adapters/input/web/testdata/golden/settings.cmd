@@ -0,0 +1,13 @@
+clear #000000
+text 15 31 "Score: 1,230" font="Arial" size=20 color=#ffffff
+text 689 31 "Lives: 3" font="Arial" size=20 color=#ffffff
+text 352 193.5 "SETTINGS" font="Arial" size=20 color=#ffffff
+text 340 218.5 "> LIVES: 3" font="Arial" size=20 color=#ffffff
+text 322 243.5 "  BOOST: 0.25" font="Arial" size=20 color=#ffffff
+text 316 268.5 "  BALL SIZE: 0" font="Arial" size=20 color=#ffffff
+text 280 293.5 "  BALL SHAPE: SQUARE" font="Arial" size=20 color=#ffffff
+text 346 318.5 "  FPS: 30" font="Arial" size=20 color=#ffffff
+text 304 343.5 "  THEME: CLASSIC" font="Arial" size=20 color=#ffffff
+text 274 368.5 "  REDUCED MOTION: OFF" font="Arial" size=20 color=#ffffff
+text 184 393.5 "(WHEEL: SELECT - LEFT CLICK: CHANGE)" font="Arial" size=20 color=#ffffff
+text 286 418.5 "(RIGHT CLICK: SAVE)" font="Arial" size=20 color=#ffffff
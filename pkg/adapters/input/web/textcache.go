@@ -0,0 +1,50 @@
+package web
+
+import "github.com/psaraiva/squash/internal/app"
+
+// memo keeps the last value built from a key and builds it again only when the
+// key changes. The HUD text of a frame is mostly the same as the previous one,
+// so formatting it every frame only feeds the garbage collector. It is not
+// safe for concurrent use: each Painter has its own.
+type memo[K comparable, V any] struct {
+	key   K
+	value V
+	ok    bool
+}
+
+func (m *memo[K, V]) get(key K, build func(K) V) V {
+	if !m.ok || m.key != key {
+		m.key, m.value, m.ok = key, build(key), true
+	}
+
+	return m.value
+}
+
+// localizedNumber is a number shown in a language.
+type localizedNumber struct {
+	lang string
+	n    int
+}
+
+// hudText is the HUD and debug text of one game, kept between its frames.
+type hudText struct {
+	score memo[localizedNumber, string]
+	lives memo[localizedNumber, string]
+
+	fps   memo[int, string]
+	level memo[int, string]
+	size  memo[float64, string]
+	spawn memo[[2]float64, string]
+}
+
+func (h *hudText) scoreText(m Locale, p *app.Squash) string {
+	return h.score.get(localizedNumber{lang: m.Lang, n: p.Score}, func(localizedNumber) string {
+		return getTextScore(m, p)
+	})
+}
+
+func (h *hudText) livesText(m Locale, p *app.Squash) string {
+	return h.lives.get(localizedNumber{lang: m.Lang, n: p.Lives}, func(localizedNumber) string {
+		return getTextLives(m, p)
+	})
+}
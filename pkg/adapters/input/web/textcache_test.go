@@ -0,0 +1,69 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+)
+
+func TestMemo(t *testing.T) {
+	var m memo[int, string]
+	builds := 0
+	build := func(n int) string {
+		builds++
+		return "n" + string(rune('0'+n))
+	}
+
+	for _, step := range []struct {
+		key    int
+		want   string
+		builds int
+	}{
+		{key: 1, want: "n1", builds: 1},
+		{key: 1, want: "n1", builds: 1},
+		{key: 2, want: "n2", builds: 2},
+		{key: 1, want: "n1", builds: 3},
+	} {
+		if got := m.get(step.key, build); got != step.want || builds != step.builds {
+			t.Errorf("get(%d) = %q after %d builds, want %q after %d", step.key, got, builds, step.want, step.builds)
+		}
+	}
+}
+
+func TestHUDTextFollowsLanguage(t *testing.T) {
+	g := newGoldenGame(app.StatePlaying, nil)
+	g.Score = 1500
+
+	var h hudText
+	if got := h.scoreText(LocaleEnglish, g); got != "Score: 1,500" {
+		t.Errorf("scoreText(en) = %q", got)
+	}
+	// same score, other language: the cached text must not be reused
+	if got := h.scoreText(LocalePortuguese, g); got != "Pontos: 1.500" {
+		t.Errorf("scoreText(pt-BR) = %q", got)
+	}
+}
+
+func TestHUDTextPerGame(t *testing.T) {
+	live, clip := newGoldenGame(app.StatePlaying, nil), newGoldenGame(app.StatePlaying, nil)
+	live.Score, clip.Score = 100, 200
+
+	var a, b hudText
+	a.scoreText(LocaleEnglish, live)
+	b.scoreText(LocaleEnglish, clip)
+
+	// painting another game does not drop the text of this one
+	if a.score.key.n != 100 || b.score.key.n != 200 {
+		t.Errorf("cached scores = %d and %d, want 100 and 200", a.score.key.n, b.score.key.n)
+	}
+}
+
+func BenchmarkGetDebugInfo(b *testing.B) {
+	g := newGoldenGame(app.StatePaused, nil)
+
+	var h hudText
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		getDebugInfo(&h, g)
+	}
+}
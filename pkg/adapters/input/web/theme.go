@@ -0,0 +1,266 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// Theme is the palette, fonts and line widths used by PaintGame.
+// A transparent Court or Outline color disables the court border or the entity outline.
+type Theme struct {
+	Name string
+
+	Background ports.Color
+	Court      ports.Color
+	Ball       ports.Color
+	Paddle     ports.Color
+	Outline    ports.Color
+	Text       ports.Color
+	Debug      ports.Color
+
+	TextFont  ports.Font
+	DebugFont ports.Font
+
+	CourtLineWidth   float64
+	OutlineLineWidth float64
+
+	CRT bool // draw the theme with the CRT look
+}
+
+var ErrColorFormat = errors.New("color must be #rgb, #rrggbb or #rrggbbaa")
+
+var (
+	ThemeClassic = Theme{
+		Name:       app.ThemeClassic,
+		Background: ports.RGB(0, 0, 0),
+		Ball:       ports.RGB(255, 255, 255),
+		Paddle:     ports.RGB(255, 255, 255),
+		Text:       ports.RGB(255, 255, 255),
+		Debug:      ports.RGB(255, 255, 0),
+		TextFont:   ports.Font{Family: "Arial", Size: 20},
+		DebugFont:  ports.Font{Family: "monospace", Size: 12},
+	}
+
+	ThemeNeon = Theme{
+		Name:             app.ThemeNeon,
+		Background:       ports.RGB(11, 2, 33),
+		Court:            ports.RGB(255, 42, 109),
+		Ball:             ports.RGB(5, 217, 232),
+		Paddle:           ports.RGB(255, 42, 109),
+		Outline:          ports.RGB(209, 247, 255),
+		Text:             ports.RGB(209, 247, 255),
+		Debug:            ports.RGB(249, 248, 113),
+		TextFont:         ports.Font{Family: "Courier New", Size: 20, Bold: true},
+		DebugFont:        ports.Font{Family: "monospace", Size: 12},
+		CourtLineWidth:   2,
+		OutlineLineWidth: 1,
+	}
+
+	ThemeLight = Theme{
+		Name:           app.ThemeLight,
+		Background:     ports.RGB(244, 244, 244),
+		Court:          ports.RGB(204, 204, 204),
+		Ball:           ports.RGB(34, 34, 34),
+		Paddle:         ports.RGB(34, 34, 34),
+		Text:           ports.RGB(34, 34, 34),
+		Debug:          ports.RGB(179, 89, 0),
+		TextFont:       ports.Font{Family: "Arial", Size: 20},
+		DebugFont:      ports.Font{Family: "monospace", Size: 12},
+		CourtLineWidth: 1,
+	}
+
+	ThemeHighContrast = Theme{
+		Name:             app.ThemeHighContrast,
+		Background:       ports.RGB(0, 0, 0),
+		Court:            ports.RGB(255, 255, 255),
+		Ball:             ports.RGB(255, 255, 0),
+		Paddle:           ports.RGB(255, 255, 255),
+		Outline:          ports.RGB(0, 0, 0),
+		Text:             ports.RGB(255, 255, 255),
+		Debug:            ports.RGB(0, 255, 255),
+		TextFont:         ports.Font{Family: "Arial", Size: 24, Bold: true},
+		DebugFont:        ports.Font{Family: "monospace", Size: 14, Bold: true},
+		CourtLineWidth:   4,
+		OutlineLineWidth: 2,
+	}
+)
+
+// Themes are the built-in themes; the custom theme is added by the frontend.
+var Themes = map[string]Theme{
+	app.ThemeClassic:      ThemeClassic,
+	app.ThemeNeon:         ThemeNeon,
+	app.ThemeLight:        ThemeLight,
+	app.ThemeHighContrast: ThemeHighContrast,
+}
+
+// LookupTheme returns the named theme from themes, or classic when it is missing.
+func LookupTheme(themes map[string]Theme, name string) Theme {
+	if theme, ok := themes[name]; ok {
+		return theme
+	}
+
+	return ThemeClassic
+}
+
+// Colors are the colors of the theme, the background first (e.g. for a GIF palette).
+func (t Theme) Colors() []ports.Color {
+	return []ports.Color{t.Background, t.Court, t.Ball, t.Paddle, t.Outline, t.Text, t.Debug}
+}
+
+// CRTEnabled reports whether the frame gets the CRT look: asked by the config
+// or by the theme, and never with reduced motion.
+func CRTEnabled(p *app.Squash, t Theme) bool {
+	return (p.CRT || t.CRT) && !p.ReducedMotion
+}
+
+func (t Theme) entityStyle(fill ports.Color) ports.Style {
+	return ports.Style{Fill: fill, Stroke: t.Outline, LineWidth: t.OutlineLineWidth}
+}
+
+func (t Theme) courtStyle() ports.Style {
+	return ports.Style{Stroke: t.Court, LineWidth: t.CourtLineWidth}
+}
+
+// themeJSON is the file format of a custom theme; missing fields keep the base theme.
+type themeJSON struct {
+	Base             string    `json:"base"`
+	Background       string    `json:"background"`
+	Court            string    `json:"court"`
+	Ball             string    `json:"ball"`
+	Paddle           string    `json:"paddle"`
+	Outline          string    `json:"outline"`
+	Text             string    `json:"text"`
+	Debug            string    `json:"debug"`
+	TextFont         *fontJSON `json:"textFont"`
+	DebugFont        *fontJSON `json:"debugFont"`
+	CourtLineWidth   *float64  `json:"courtLineWidth"`
+	OutlineLineWidth *float64  `json:"outlineLineWidth"`
+	CRT              bool      `json:"crt"`
+}
+
+type fontJSON struct {
+	Family string  `json:"family"`
+	Size   float64 `json:"size"`
+	Bold   bool    `json:"bold"`
+}
+
+// ParseTheme reads a custom theme, e.g. {"base": "neon", "ball": "#ff0000"}.
+func ParseTheme(data []byte) (Theme, error) {
+	var raw themeJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Theme{}, err
+	}
+
+	theme := ThemeClassic
+	if raw.Base != "" {
+		base, ok := Themes[raw.Base]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown base theme %q", raw.Base)
+		}
+		theme = base
+	}
+	theme.Name = app.ThemeCustom
+
+	colors := []struct {
+		name  string
+		value string
+		dst   *ports.Color
+	}{
+		{"background", raw.Background, &theme.Background},
+		{"court", raw.Court, &theme.Court},
+		{"ball", raw.Ball, &theme.Ball},
+		{"paddle", raw.Paddle, &theme.Paddle},
+		{"outline", raw.Outline, &theme.Outline},
+		{"text", raw.Text, &theme.Text},
+		{"debug", raw.Debug, &theme.Debug},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+
+		color, err := ParseColor(c.value)
+		if err != nil {
+			return Theme{}, fmt.Errorf("%s: %w", c.name, err)
+		}
+		*c.dst = color
+	}
+
+	if err := setFont(&theme.TextFont, raw.TextFont); err != nil {
+		return Theme{}, fmt.Errorf("textFont: %w", err)
+	}
+	if err := setFont(&theme.DebugFont, raw.DebugFont); err != nil {
+		return Theme{}, fmt.Errorf("debugFont: %w", err)
+	}
+
+	if err := setLineWidth(&theme.CourtLineWidth, raw.CourtLineWidth); err != nil {
+		return Theme{}, fmt.Errorf("courtLineWidth: %w", err)
+	}
+	if err := setLineWidth(&theme.OutlineLineWidth, raw.OutlineLineWidth); err != nil {
+		return Theme{}, fmt.Errorf("outlineLineWidth: %w", err)
+	}
+	theme.CRT = theme.CRT || raw.CRT
+
+	return theme, nil
+}
+
+// ParseColor reads a CSS hex color: #rgb, #rrggbb or #rrggbbaa.
+func ParseColor(s string) (ports.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == len(s) {
+		return ports.Color{}, ErrColorFormat
+	}
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return ports.Color{}, ErrColorFormat
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ports.Color{}, ErrColorFormat
+	}
+
+	return ports.Color{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func setFont(dst *ports.Font, font *fontJSON) error {
+	if font == nil {
+		return nil
+	}
+
+	if font.Size < 6 || font.Size > 96 {
+		return fmt.Errorf("size %g is out of range [6, 96]", font.Size)
+	}
+
+	if font.Family != "" {
+		dst.Family = font.Family
+	}
+	dst.Size = font.Size
+	dst.Bold = font.Bold
+	return nil
+}
+
+func setLineWidth(dst *float64, width *float64) error {
+	if width == nil {
+		return nil
+	}
+
+	if *width < 0 || *width > 20 {
+		return fmt.Errorf("%g is out of range [0, 20]", *width)
+	}
+
+	*dst = *width
+	return nil
+}
@@ -0,0 +1,251 @@
+package web
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/internal/ports/mocks"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    ports.Color
+		wantErr bool
+	}{
+		{
+			name:  "Short form",
+			value: "#f80",
+			want:  ports.RGB(255, 136, 0),
+		},
+		{
+			name:  "Opaque",
+			value: "#0b0221",
+			want:  ports.RGB(11, 2, 33),
+		},
+		{
+			name:  "With alpha",
+			value: "#ffffff80",
+			want:  ports.Color{R: 255, G: 255, B: 255, A: 128},
+		},
+		{
+			name:    "Missing hash",
+			value:   "ffffff",
+			wantErr: true,
+		},
+		{
+			name:    "Wrong length",
+			value:   "#fffff",
+			wantErr: true,
+		},
+		{
+			name:    "Not hexadecimal",
+			value:   "#gggggg",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseColor(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColor(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrColorFormat) {
+				t.Errorf("ParseColor(%q) error = %v, want %v", tt.value, err, ErrColorFormat)
+			}
+			if got != tt.want {
+				t.Errorf("ParseColor(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		check   func(th Theme) bool
+		wantErr bool
+	}{
+		{
+			name:  "Empty theme is classic",
+			data:  `{}`,
+			check: func(th Theme) bool { return th.Background == ThemeClassic.Background && th.Name == app.ThemeCustom },
+		},
+		{
+			name: "Override on a base theme",
+			data: `{"base": "neon", "ball": "#ff0000"}`,
+			check: func(th Theme) bool {
+				return th.Ball == ports.RGB(255, 0, 0) && th.Paddle == ThemeNeon.Paddle && th.CourtLineWidth == 2
+			},
+		},
+		{
+			name: "Fonts and line widths",
+			data: `{"textFont": {"family": "Verdana", "size": 22, "bold": true}, "courtLineWidth": 3}`,
+			check: func(th Theme) bool {
+				return th.TextFont == ports.Font{Family: "Verdana", Size: 22, Bold: true} && th.CourtLineWidth == 3
+			},
+		},
+		{
+			name:  "CRT look",
+			data:  `{"base": "neon", "crt": true}`,
+			check: func(th Theme) bool { return th.CRT && th.Ball == ThemeNeon.Ball },
+		},
+		{
+			name:    "Unknown base",
+			data:    `{"base": "vaporwave"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid color",
+			data:    `{"text": "white"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Font too large",
+			data:    `{"debugFont": {"size": 200}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Negative line width",
+			data:    `{"outlineLineWidth": -1}`,
+			wantErr: true,
+		},
+		{
+			name:    "Malformed json",
+			data:    `{"ball": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTheme([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !tt.check(got) {
+				t.Errorf("ParseTheme() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestLookupTheme(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme string
+		want  string
+	}{
+		{
+			name:  "Built-in theme",
+			theme: app.ThemeNeon,
+			want:  app.ThemeNeon,
+		},
+		{
+			name:  "Missing custom theme falls back to classic",
+			theme: app.ThemeCustom,
+			want:  app.ThemeClassic,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LookupTheme(Themes, tt.theme); got.Name != tt.want {
+				t.Errorf("LookupTheme(%q) = %q, want %q", tt.theme, got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinThemesMatchConfig(t *testing.T) {
+	for _, name := range app.ThemeNames {
+		if name == app.ThemeCustom {
+			continue
+		}
+
+		theme, ok := Themes[name]
+		if !ok {
+			t.Errorf("Themes[%q] is missing", name)
+			continue
+		}
+		if theme.Name != name {
+			t.Errorf("Themes[%q].Name = %q", name, theme.Name)
+		}
+	}
+}
+
+func TestCRTEnabled(t *testing.T) {
+	crtTheme := ThemeNeon
+	crtTheme.CRT = true
+
+	tests := []struct {
+		name    string
+		crt     bool
+		reduced bool
+		theme   Theme
+		want    bool
+	}{
+		{name: "Off by default", theme: ThemeNeon},
+		{name: "Asked by the config", crt: true, theme: ThemeNeon, want: true},
+		{name: "Asked by the theme", theme: crtTheme, want: true},
+		{name: "Reduced motion turns it off", crt: true, reduced: true, theme: crtTheme},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &app.Squash{CRT: tt.crt, ReducedMotion: tt.reduced}
+			if got := CRTEnabled(p, tt.theme); got != tt.want {
+				t.Errorf("CRTEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaintGameTheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		theme     Theme
+		wantCourt bool
+	}{
+		{
+			name:  "Classic has no court border",
+			theme: ThemeClassic,
+		},
+		{
+			name:      "Neon draws the court and outlines",
+			theme:     ThemeNeon,
+			wantCourt: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("Clear", tt.theme.Background).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, ports.TextStyle{Font: tt.theme.TextFont, Color: tt.theme.Text}).Return()
+			mockRenderer.On("MeasureText", mock.Anything, tt.theme.TextFont).Return(80.0)
+			mockRenderer.On("DrawRect", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			g := app.NewSquash(800, 600, app.NewDefaultConfig())
+			g.State = app.StatePlaying
+
+			PaintGame(mockRenderer, g, tt.theme, nil)
+
+			court := tt.theme.courtStyle()
+			if tt.wantCourt {
+				mockRenderer.AssertCalled(t, "DrawRect", 1.0, 1.0, 798.0, 598.0, court)
+			} else {
+				mockRenderer.AssertNotCalled(t, "DrawRect", mock.Anything, mock.Anything, mock.Anything, mock.Anything, court)
+			}
+			mockRenderer.AssertCalled(t, "DrawRect", g.BallX, g.BallY, g.BallSize, g.BallSize, tt.theme.entityStyle(tt.theme.Ball))
+			mockRenderer.AssertCalled(t, "DrawRect", g.PaddleX, g.PaddleY, g.PaddleW, g.PaddleH, tt.theme.entityStyle(tt.theme.Paddle))
+		})
+	}
+}
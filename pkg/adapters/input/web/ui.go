@@ -2,96 +2,271 @@ package web
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/psaraiva/squash/internal/app"
 	"github.com/psaraiva/squash/internal/ports"
 )
 
-func PaintGame(r ports.Renderer, p *app.Squash) {
-	r.Clear()
+// Painter paints the frames of one game. It keeps the HUD text between the
+// frames, so that a frame only formats the lines that changed; every game loop
+// owns its own. The zero value is ready to use.
+type Painter struct {
+	// Exporting shows on the HUD that a clip is being saved, with the share
+	// of its frames drawn so far in ExportProgress, from 0 to 1.
+	Exporting      bool
+	ExportProgress float64
 
-	drawTextScore(r, getTextScore(p))
-	drawTextLives(r, p, getTextLives(p))
+	text hudText
+}
+
+// PaintGame draws a single frame, e.g. of a clip or an exported image, with a
+// Painter of its own.
+func PaintGame(r ports.Renderer, p *app.Squash, t Theme, fx *Effects) {
+	var pt Painter
+	pt.Paint(r, p, t, fx)
+}
+
+// Paint draws a frame; fx adds the particles, trail and screen shake (nil for none).
+// On a ports.LayeredRenderer the court is a static background layer, drawn
+// again only when the theme or the court size changes; the screen shake moves
+// the entities, not the court.
+func (pt *Painter) Paint(r ports.Renderer, p *app.Squash, t Theme, fx *Effects) {
+	if beginLayer(r, ports.LayerBackground, backgroundKey{theme: t, width: p.Width, height: p.Height}) {
+		r.Clear(t.Background)
+		drawCourt(r, p, t)
+	}
+
+	beginLayer(r, ports.LayerEntities, nil)
+	shaking := false
+	if fx != nil {
+		dx, dy := fx.Offset()
+		if shaking = dx != 0 || dy != 0; shaking {
+			r.Save()
+			r.Translate(dx, dy)
+		}
+	}
+
+	if p.State == app.StatePlaying {
+		if fx != nil {
+			fx.drawTrail(r, p, t)
+		}
+		drawGameElements(r, p, t)
+	}
+	if fx != nil {
+		fx.drawParticles(r)
+	}
+
+	if shaking {
+		r.Restore()
+	}
+
+	beginLayer(r, ports.LayerHUD, nil)
+	m := LookupLocale(p.Lang)
+	l := NewLayout(p.Width, p.Height)
+	hud := drawHUD(r, l, t, pt.text.scoreText(m, p), pt.text.livesText(m, p))
+	if pt.Exporting {
+		drawBlock(r, l, TextBlock{Anchor: AnchorBottom, Lines: []string{getTextExporting(m, pt.ExportProgress)}, Font: t.DebugFont}, t.Text)
+	}
 
 	switch p.State {
 	case app.StateMenu:
-		drawTextCenter(r, p, getTextStateMenu())
+		drawTextCenter(r, l, t, append(append(getTextStateMenu(m), getTextPreset(m, p)), getTextUnverified(m, p)...))
+		drawConfigIssues(r, l, t, getTextConfigIssues(m, p))
 
 	case app.StatePaused:
-		drawTextCenter(r, p, getTextStatePaused())
-
-	case app.StatePlaying:
-		drawGameElements(r, p)
+		drawTextCenter(r, l, t, getTextStatePaused(m))
 
 	case app.StateGameOver:
-		drawTextCenter(r, p, getTextStateGameOver(p))
+		drawTextCenter(r, l, t, getTextStateGameOver(m, p))
+
+	case app.StateSettings:
+		drawTextCenter(r, l, t, getTextStateSettings(m, p))
 	}
 
 	if p.DebugMode {
-		drawDebugInfo(r, getDebugInfo(p))
+		beginLayer(r, ports.LayerDebug, nil)
+		drawDebugInfo(r, l, t, hud, getDebugInfo(&pt.text, p))
 	}
 }
 
-func getTextScore(p *app.Squash) string {
-	return fmt.Sprintf("Score: %d", p.Score)
+// backgroundKey is what the background layer depends on.
+type backgroundKey struct {
+	theme         Theme
+	width, height float64
 }
 
-func drawTextScore(r ports.Renderer, text string) {
-	r.DrawText(text, 30, 30)
+// beginLayer starts a layer on a LayeredRenderer; any other renderer draws
+// every layer, in order, on its single surface.
+func beginLayer(r ports.Renderer, layer ports.Layer, key any) bool {
+	if lr, ok := r.(ports.LayeredRenderer); ok {
+		return lr.BeginLayer(layer, key)
+	}
+
+	return true
 }
 
-func getTextLives(p *app.Squash) string {
-	return fmt.Sprintf("Lives: %d", p.Lives)
+func drawCourt(r ports.Renderer, p *app.Squash, t Theme) {
+	if t.Court.A == 0 || t.CourtLineWidth <= 0 {
+		return
+	}
+
+	inset := t.CourtLineWidth / 2
+	r.DrawRect(inset, inset, p.Width-t.CourtLineWidth, p.Height-t.CourtLineWidth, t.courtStyle())
 }
 
-func drawTextLives(r ports.Renderer, p *app.Squash, text string) {
-	r.DrawText(text, p.Width-120, 30)
+func getTextScore(m Locale, p *app.Squash) string {
+	return m.T(MsgScore, m.Number(p.Score))
 }
 
-func drawGameElements(r ports.Renderer, p *app.Squash) {
-	r.DrawBall(p.BallX, p.BallY, p.BallSize)
-	r.DrawPaddle(p.PaddleX, p.PaddleY, p.PaddleW, p.PaddleH)
+func getTextLives(m Locale, p *app.Squash) string {
+	return m.T(MsgLives, m.Number(p.Lives))
 }
 
-func getDebugInfo(p *app.Squash) []string {
-	return []string{
-		fmt.Sprint("Game:....."),
-		fmt.Sprintf("FPS:      %d", p.Fps),
-		fmt.Sprintf("Level:    %d", p.LastLevel),
-		fmt.Sprint("Ball:....."),
-		fmt.Sprintf("Size:     %.1f", p.BallSize),
-		fmt.Sprintf("Spawn:    [%.1f, %.1f]", p.BallSpawnX, p.BallSpawnY),
+func getTextExporting(m Locale, progress float64) string {
+	return m.T(MsgClipExporting, int(math.Max(0, math.Min(1, progress))*100))
+}
+
+// drawHUD draws the score (top left) and lives (top right) on one row and
+// returns the area they use; both shrink together when they would overlap.
+func drawHUD(r ports.Renderer, l Layout, t Theme, score, lives string) Box {
+	font := l.FitRow(r, t.TextFont, score, lives)
+
+	_, box := drawBlock(r, l, TextBlock{Anchor: AnchorTopLeft, Lines: []string{score}, Font: font}, t.Text)
+	drawBlock(r, l, TextBlock{Anchor: AnchorTopRight, Lines: []string{lives}, Font: font}, t.Text)
+	return box
+}
+
+// drawBlock places a text block with the layout and draws it in color.
+func drawBlock(r ports.Renderer, l Layout, b TextBlock, color ports.Color) ([]PlacedText, Box) {
+	placed, box := l.Place(r, b)
+	for _, line := range placed {
+		r.DrawText(line.Text, line.X, line.Y, ports.TextStyle{Font: line.Font, Color: color})
+	}
+
+	return placed, box
+}
+
+func drawGameElements(r ports.Renderer, p *app.Squash, t Theme) {
+	if p.BallShape == app.BallRound {
+		radius := p.BallSize / 2
+		r.DrawCircle(p.BallX+radius, p.BallY+radius, radius, t.entityStyle(t.Ball))
+	} else {
+		r.DrawRect(p.BallX, p.BallY, p.BallSize, p.BallSize, t.entityStyle(t.Ball))
+	}
+	r.DrawRect(p.PaddleX, p.PaddleY, p.PaddleW, p.PaddleH, t.entityStyle(t.Paddle))
+}
+
+// getDebugInfo keeps the lines that seldom change in h; the position and the
+// velocity of the ball change every tick and are formatted every frame.
+func getDebugInfo(h *hudText, p *app.Squash) []string {
+	info := []string{
+		"Game:.....",
+		h.fps.get(p.Fps, func(fps int) string { return fmt.Sprintf("FPS:      %d", fps) }),
+		h.level.get(p.LastLevel, func(level int) string { return fmt.Sprintf("Level:    %d", level) }),
+		"Ball:.....",
+		h.size.get(p.BallSize, func(size float64) string { return fmt.Sprintf("Size:     %.1f", size) }),
+		h.spawn.get([2]float64{p.BallSpawnX, p.BallSpawnY}, func(v [2]float64) string {
+			return fmt.Sprintf("Spawn:    [%.1f, %.1f]", v[0], v[1])
+		}),
 		fmt.Sprintf("Position: [%.1f, %.1f]", p.BallX, p.BallY),
 		fmt.Sprintf("Velocity: [%.2f, %.2f]", p.BallDX, p.BallDY),
 	}
-}
 
-func drawDebugInfo(r ports.Renderer, info []string) {
-	for i, text := range info {
-		r.DrawDebugText(text, 10, 80+float64(i*15))
+	if len(p.ConfigTrace) > 0 {
+		info = append(info, fmt.Sprint("Config:..."))
+		for _, t := range p.ConfigTrace {
+			info = append(info, fmt.Sprintf("%-10s%s (%s)", t.Param+":", t.Value, t.Origin))
+		}
 	}
+
+	return info
 }
 
-func getTextStateMenu() []string {
+// drawDebugInfo lists the debug lines on the left, below the HUD.
+func drawDebugInfo(r ports.Renderer, l Layout, t Theme, hud Box, info []string) {
+	offset := ports.Point{Y: hud.Bottom()}
+	drawBlock(r, l, TextBlock{Anchor: AnchorTopLeft, Lines: info, Font: t.DebugFont, Offset: offset}, t.Debug)
+}
+
+func getTextStateMenu(m Locale) []string {
 	return []string{
-		"SQUASH - LEFT CLICK TO START",
-		"(RIGHT CLICK TO PAUSE)",
+		m.T(MsgMenuStart),
+		m.T(MsgMenuHint),
 	}
 }
 
-func getTextStatePaused() []string {
-	return []string{"PAUSED - RIGHT CLICK TO RESUME"}
+func getTextPreset(m Locale, p *app.Squash) string {
+	if p.Mode == app.ModeChallenge {
+		return m.T(MsgMenuChallenge, p.Seed)
+	}
+
+	return m.T(MsgMenuPreset, m.Name(MsgPreset(p.Preset), p.Preset))
 }
 
-func getTextStateGameOver(p *app.Squash) []string {
-	return []string{
-		fmt.Sprintf("GAME OVER - SCORE: %d", p.Score),
-		"(LEFT CLICK TO RESTART)",
+func getTextStatePaused(m Locale) []string {
+	return []string{m.T(MsgPaused)}
+}
+
+func getTextStateGameOver(m Locale, p *app.Squash) []string {
+	share := m.T(MsgGameOverShare)
+	if p.ShareToken != "" {
+		share = m.T(MsgGameOverCopied)
 	}
+
+	return append([]string{
+		m.N(MsgGameOver, p.Score),
+		m.T(MsgGameOverRestart),
+		share,
+	}, getTextUnverified(m, p)...)
+}
+
+// getTextUnverified warns, in a challenge or once its link is copied, that
+// the link is not verified: its checksum only catches a damaged link, and
+// anyone can edit the settings it carries.
+func getTextUnverified(m Locale, p *app.Squash) []string {
+	if p.Mode != app.ModeChallenge && p.ShareToken == "" {
+		return nil
+	}
+
+	return []string{m.T(MsgUnverified)}
+}
+
+func getTextStateSettings(m Locale, p *app.Squash) []string {
+	values := p.Settings.Draft.Values()
+	text := []string{m.T(MsgSettingsTitle)}
+	for i, field := range app.SettingsFields {
+		marker := "  "
+		if i == p.Settings.Selected {
+			marker = "> "
+		}
+		value := m.Name(MsgOption(field.Param, values[field.Param]), values[field.Param])
+		text = append(text, fmt.Sprintf("%s%s: %s", marker, m.T(MsgSetting(field.Param)), value))
+	}
+
+	return append(text,
+		m.T(MsgSettingsHint),
+		m.T(MsgSettingsSave),
+	)
+}
+
+func drawTextCenter(r ports.Renderer, l Layout, t Theme, text []string) {
+	drawBlock(r, l, TextBlock{Anchor: AnchorCenter, Lines: text, Font: t.TextFont}, t.Text)
 }
 
-func drawTextCenter(r ports.Renderer, p *app.Squash, text []string) {
-	for i, line := range text {
-		r.DrawText(line, (p.Width-r.MeasureText(line))/2, p.Height/2+float64(i*20))
+func getTextConfigIssues(m Locale, p *app.Squash) []string {
+	lines := make([]string, 0, len(p.ConfigIssues))
+	for _, issue := range p.ConfigIssues {
+		label := m.T(MsgIssueRejected)
+		if issue.Severity == app.SeverityWarning {
+			label = m.T(MsgIssueIgnored)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s=%s (%s): %s", label, issue.Param, issue.Value, issue.Origin, m.T(MsgIssue(issue.Kind))))
 	}
+
+	return lines
+}
+
+func drawConfigIssues(r ports.Renderer, l Layout, t Theme, lines []string) {
+	drawBlock(r, l, TextBlock{Anchor: AnchorBottomLeft, Lines: lines, Font: t.DebugFont}, t.Debug)
 }
@@ -1,14 +1,42 @@
 package web
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
 	"github.com/psaraiva/squash/internal/ports/mocks"
+	"github.com/psaraiva/squash/pkg/adapters/output/record"
 
 	"github.com/stretchr/testify/mock"
 )
 
+// Styles PaintGame draws with under the classic theme.
+var (
+	colorBackground = ThemeClassic.Background
+	styleEntity     = ThemeClassic.entityStyle(ThemeClassic.Ball)
+	styleText       = ports.TextStyle{Font: ThemeClassic.TextFont, Color: ThemeClassic.Text}
+	styleDebug      = ports.TextStyle{Font: ThemeClassic.DebugFont, Color: ThemeClassic.Debug}
+)
+
+// assertDebugTextCalls counts the DrawText calls made with the debug style.
+func assertDebugTextCalls(t *testing.T, m *mocks.Renderer, want int) {
+	t.Helper()
+
+	got := 0
+	for _, call := range m.Calls {
+		if call.Method == "DrawText" && call.Arguments.Get(3) == styleDebug {
+			got++
+		}
+	}
+
+	if got != want {
+		t.Errorf("debug DrawText calls = %d, want %d", got, want)
+	}
+}
+
 func TestPaintGameStateMenu(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -57,9 +85,9 @@ func TestPaintGameStateMenu(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("Clear").Return()
-			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything).Return()
-			mockRenderer.On("MeasureText", mock.Anything).Return(tt.textWidth)
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(tt.textWidth)
 
 			cfg := app.Config{
 				Debug:          false,
@@ -74,11 +102,11 @@ func TestPaintGameStateMenu(t *testing.T) {
 			g.State = tt.gameState
 			g.Score = tt.score
 
-			PaintGame(mockRenderer, g)
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
 
-			mockRenderer.AssertCalled(t, "Clear")
+			mockRenderer.AssertCalled(t, "Clear", colorBackground)
 			if tt.expectMeasureText {
-				mockRenderer.AssertCalled(t, "MeasureText", mock.Anything)
+				mockRenderer.AssertCalled(t, "MeasureText", mock.Anything, mock.Anything)
 			}
 		})
 	}
@@ -126,12 +154,12 @@ func TestPaintGameStatePlaying(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("Clear").Return()
-			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything).Return()
-			mockRenderer.On("DrawBall", mock.Anything, mock.Anything, mock.Anything).Return()
-			mockRenderer.On("DrawPaddle", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("DrawRect", mock.Anything, mock.Anything, mock.Anything, mock.Anything, styleEntity).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(80.0)
 			if tt.debugMode {
-				mockRenderer.On("DrawDebugText", mock.Anything, mock.Anything, mock.Anything).Return()
+				mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, styleDebug).Return()
 			}
 
 			cfg := app.Config{
@@ -147,17 +175,17 @@ func TestPaintGameStatePlaying(t *testing.T) {
 			g.State = tt.gameState
 			g.Score = tt.score
 
-			PaintGame(mockRenderer, g)
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
 
-			mockRenderer.AssertCalled(t, "Clear")
+			mockRenderer.AssertCalled(t, "Clear", colorBackground)
 			if tt.expectBall {
-				mockRenderer.AssertCalled(t, "DrawBall", mock.Anything, mock.Anything, mock.Anything)
+				mockRenderer.AssertCalled(t, "DrawRect", g.BallX, g.BallY, g.BallSize, g.BallSize, styleEntity)
 			}
 			if tt.expectPaddle {
-				mockRenderer.AssertCalled(t, "DrawPaddle", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				mockRenderer.AssertCalled(t, "DrawRect", g.PaddleX, g.PaddleY, g.PaddleW, g.PaddleH, styleEntity)
 			}
 			if tt.expectDebugInfo {
-				mockRenderer.AssertCalled(t, "DrawDebugText", mock.Anything, mock.Anything, mock.Anything)
+				mockRenderer.AssertCalled(t, "DrawText", mock.Anything, mock.Anything, mock.Anything, styleDebug)
 			}
 		})
 	}
@@ -205,9 +233,9 @@ func TestPaintGameStatePaused(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("Clear").Return()
-			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything).Return()
-			mockRenderer.On("MeasureText", mock.Anything).Return(tt.textWidth)
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(tt.textWidth)
 
 			cfg := app.Config{
 				Debug:          false,
@@ -221,11 +249,11 @@ func TestPaintGameStatePaused(t *testing.T) {
 			g := app.NewSquash(tt.width, tt.height, cfg)
 			g.State = tt.gameState
 
-			PaintGame(mockRenderer, g)
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
 
-			mockRenderer.AssertCalled(t, "Clear")
+			mockRenderer.AssertCalled(t, "Clear", colorBackground)
 			if tt.expectMeasureText {
-				mockRenderer.AssertCalled(t, "MeasureText", mock.Anything)
+				mockRenderer.AssertCalled(t, "MeasureText", mock.Anything, mock.Anything)
 			}
 		})
 	}
@@ -276,9 +304,9 @@ func TestPaintGameStateGameOver(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("Clear").Return()
-			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything).Return()
-			mockRenderer.On("MeasureText", mock.Anything).Return(tt.textWidth)
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(tt.textWidth)
 
 			cfg := app.Config{
 				Debug:          false,
@@ -293,11 +321,11 @@ func TestPaintGameStateGameOver(t *testing.T) {
 			g.State = tt.gameState
 			g.Score = tt.score
 
-			PaintGame(mockRenderer, g)
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
 
-			mockRenderer.AssertCalled(t, "Clear")
+			mockRenderer.AssertCalled(t, "Clear", colorBackground)
 			if tt.expectMeasureText {
-				mockRenderer.AssertCalled(t, "MeasureText", mock.Anything)
+				mockRenderer.AssertCalled(t, "MeasureText", mock.Anything, mock.Anything)
 			}
 		})
 	}
@@ -349,8 +377,8 @@ func TestDrawGameElements(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("DrawBall", tt.ballX, tt.ballY, tt.ballSize).Return()
-			mockRenderer.On("DrawPaddle", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH).Return()
+			mockRenderer.On("DrawRect", tt.ballX, tt.ballY, tt.ballSize, tt.ballSize, styleEntity).Return()
+			mockRenderer.On("DrawRect", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH, styleEntity).Return()
 
 			cfg := app.Config{
 				Debug:          false,
@@ -370,10 +398,10 @@ func TestDrawGameElements(t *testing.T) {
 			g.PaddleW = tt.paddleW
 			g.PaddleH = tt.paddleH
 
-			drawGameElements(mockRenderer, g)
+			drawGameElements(mockRenderer, g, ThemeClassic)
 
-			mockRenderer.AssertCalled(t, "DrawBall", tt.ballX, tt.ballY, tt.ballSize)
-			mockRenderer.AssertCalled(t, "DrawPaddle", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH)
+			mockRenderer.AssertCalled(t, "DrawRect", tt.ballX, tt.ballY, tt.ballSize, tt.ballSize, styleEntity)
+			mockRenderer.AssertCalled(t, "DrawRect", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH, styleEntity)
 		})
 	}
 }
@@ -436,7 +464,8 @@ func TestDrawDebugInfo(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("DrawDebugText", mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, styleDebug).Return()
+			mockRenderer.On("MeasureText", mock.Anything, ThemeClassic.DebugFont).Return(150.0)
 
 			cfg := app.Config{
 				Debug:          true,
@@ -456,10 +485,10 @@ func TestDrawDebugInfo(t *testing.T) {
 			g.BallDX = tt.ballDX
 			g.BallDY = tt.ballDY
 
-			debugInfo := getDebugInfo(g)
-			drawDebugInfo(mockRenderer, debugInfo)
+			debugInfo := getDebugInfo(&hudText{}, g)
+			drawDebugInfo(mockRenderer, NewLayout(800, 600), ThemeClassic, Box{}, debugInfo)
 
-			mockRenderer.AssertNumberOfCalls(t, "DrawDebugText", tt.expectedDebugCalls)
+			assertDebugTextCalls(t, mockRenderer, tt.expectedDebugCalls)
 		})
 	}
 }
@@ -498,9 +527,9 @@ func TestPaintGameTextPositioning(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("Clear").Return()
-			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything).Return()
-			mockRenderer.On("MeasureText", mock.Anything).Return(tt.textWidth)
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(tt.textWidth)
 
 			cfg := app.Config{
 				Debug:          false,
@@ -514,9 +543,9 @@ func TestPaintGameTextPositioning(t *testing.T) {
 			g := app.NewSquash(tt.width, tt.height, cfg)
 			g.State = tt.state
 
-			PaintGame(mockRenderer, g)
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
 
-			mockRenderer.AssertCalled(t, "Clear")
+			mockRenderer.AssertCalled(t, "Clear", colorBackground)
 		})
 	}
 }
@@ -540,7 +569,7 @@ func TestGetTextScore(t *testing.T) {
 		{
 			name:  "Score high value",
 			score: 999999,
-			want:  "Score: 999999",
+			want:  "Score: 999,999",
 		},
 	}
 
@@ -558,7 +587,7 @@ func TestGetTextScore(t *testing.T) {
 			g := app.NewSquash(800, 600, cfg)
 			g.Score = tt.score
 
-			got := getTextScore(g)
+			got := getTextScore(LocaleEnglish, g)
 			if got != tt.want {
 				t.Errorf("getTextScore() = %v, want %v", got, tt.want)
 			}
@@ -602,7 +631,7 @@ func TestGetTextLives(t *testing.T) {
 			}
 			g := app.NewSquash(800, 600, cfg)
 
-			got := getTextLives(g)
+			got := getTextLives(LocaleEnglish, g)
 			if got != tt.want {
 				t.Errorf("getTextLives() = %v, want %v", got, tt.want)
 			}
@@ -659,7 +688,7 @@ func TestGetDebugInfoLines(t *testing.T) {
 			g.BallDX = tt.ballDX
 			g.BallDY = tt.ballDY
 
-			got := getDebugInfo(g)
+			got := getDebugInfo(&hudText{}, g)
 			if len(got) != tt.wantLines {
 				t.Errorf("getDebugInfo() lines = %v, want %v", len(got), tt.wantLines)
 			}
@@ -667,63 +696,51 @@ func TestGetDebugInfoLines(t *testing.T) {
 	}
 }
 
-func TestDrawTextScore(t *testing.T) {
+func TestDrawHUD(t *testing.T) {
 	tests := []struct {
-		name string
-		text string
+		name       string
+		scoreWidth float64
+		livesWidth float64
+		wantSize   float64
 	}{
 		{
-			name: "Draw score text",
-			text: "Score: 100",
+			name:       "Score and lives fit",
+			scoreWidth: 100.0,
+			livesWidth: 80.0,
+			wantSize:   20.0,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("DrawText", tt.text, 30.0, 30.0).Return()
-
-			drawTextScore(mockRenderer, tt.text)
-
-			mockRenderer.AssertCalled(t, "DrawText", tt.text, 30.0, 30.0)
-		})
-	}
-}
-
-func TestDrawTextLives(t *testing.T) {
-	tests := []struct {
-		name   string
-		text   string
-		width  float64
-		height float64
-	}{
 		{
-			name:   "Draw lives text",
-			text:   "Lives: 3",
-			width:  800.0,
-			height: 600.0,
+			name:       "Overlapping texts shrink together",
+			scoreWidth: 1000.0,
+			livesWidth: 525.0, // 1000 + 15 + 525 is twice the 770 available
+			wantSize:   10.0,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("DrawText", tt.text, tt.width-120, 30.0).Return()
+			l := NewLayout(800, 600)
+			font := ThemeClassic.TextFont
 
-			cfg := app.Config{
-				Debug:          false,
-				Fps:            60,
-				DeltaTime:      0.016,
-				InitialLives:   3,
-				InitialLevel:   0,
-				SpeedIncrement: 0.5,
-				BallScale:      0.5,
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("MeasureText", "Score: 100", font).Return(tt.scoreWidth)
+			mockRenderer.On("MeasureText", "Lives: 3", font).Return(tt.livesWidth)
+			mockRenderer.On("MeasureText", "Score: 100", mock.Anything).Return(tt.scoreWidth * tt.wantSize / 20)
+			mockRenderer.On("MeasureText", "Lives: 3", mock.Anything).Return(tt.livesWidth * tt.wantSize / 20)
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			box := drawHUD(mockRenderer, l, ThemeClassic, "Score: 100", "Lives: 3")
+
+			font.Size = tt.wantSize
+			style := ports.TextStyle{Font: font, Color: ThemeClassic.Text}
+			baseline := l.Padding + tt.wantSize*ascent
+			livesX := 800 - l.Padding - tt.livesWidth*tt.wantSize/20
+
+			mockRenderer.AssertCalled(t, "DrawText", "Score: 100", l.Padding, baseline, style)
+			mockRenderer.AssertCalled(t, "DrawText", "Lives: 3", livesX, baseline, style)
+			if box.Bottom() != l.Padding+tt.wantSize {
+				t.Errorf("drawHUD() bottom = %v, want %v", box.Bottom(), l.Padding+tt.wantSize)
 			}
-			g := app.NewSquash(tt.width, tt.height, cfg)
-
-			drawTextLives(mockRenderer, g, tt.text)
-
-			mockRenderer.AssertCalled(t, "DrawText", tt.text, tt.width-120, 30.0)
 		})
 	}
 }
@@ -731,10 +748,12 @@ func TestDrawTextLives(t *testing.T) {
 func TestDrawDebugInfoCall(t *testing.T) {
 	tests := []struct {
 		name      string
+		hud       Box
 		debugInfo []string
 	}{
 		{
-			name: "Draw debug info",
+			name: "Draw debug info below the HUD",
+			hud:  Box{X: 15, Y: 15, W: 100, H: 20},
 			debugInfo: []string{
 				"Game:.....",
 				"FPS:      60",
@@ -745,15 +764,19 @@ func TestDrawDebugInfoCall(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			l := NewLayout(800, 600)
+			font := ThemeClassic.DebugFont
+
 			mockRenderer := mocks.NewRenderer(t)
-			for i := range tt.debugInfo {
-				mockRenderer.On("DrawDebugText", mock.Anything, 10.0, 80+float64(i*15)).Return()
-			}
+			mockRenderer.On("MeasureText", mock.Anything, font).Return(90.0)
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, styleDebug).Return()
 
-			drawDebugInfo(mockRenderer, tt.debugInfo)
+			drawDebugInfo(mockRenderer, l, ThemeClassic, tt.hud, tt.debugInfo)
 
-			for i := range tt.debugInfo {
-				mockRenderer.AssertCalled(t, "DrawDebugText", mock.Anything, 10.0, 80+float64(i*15))
+			top := l.Padding + tt.hud.Bottom()
+			for i, line := range tt.debugInfo {
+				y := top + font.Size*ascent + font.Size*l.LineHeight*float64(i)
+				mockRenderer.AssertCalled(t, "DrawText", line, l.Padding, y, styleDebug)
 			}
 		})
 	}
@@ -774,37 +797,75 @@ func TestDrawTextCenter(t *testing.T) {
 			height:    600.0,
 			textWidth: 100.0,
 		},
+		{
+			name:      "Draw centered text on a small court",
+			text:      []string{"Line 1", "Line 2", "Line 3"},
+			width:     320.0,
+			height:    240.0,
+			textWidth: 60.0,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			l := NewLayout(tt.width, tt.height)
+			size := styleText.Font.Size
+			blockH := size + size*l.LineHeight*float64(len(tt.text)-1)
+
 			mockRenderer := mocks.NewRenderer(t)
 			for i := range tt.text {
-				mockRenderer.On("MeasureText", tt.text[i]).Return(tt.textWidth)
-				mockRenderer.On("DrawText", tt.text[i], (tt.width-tt.textWidth)/2, tt.height/2+float64(i*20)).Return()
+				mockRenderer.On("MeasureText", tt.text[i], styleText.Font).Return(tt.textWidth)
+				mockRenderer.On("DrawText", tt.text[i], mock.Anything, mock.Anything, styleText).Return()
 			}
 
-			cfg := app.Config{
-				Debug:          false,
-				Fps:            60,
-				DeltaTime:      0.016,
-				InitialLives:   3,
-				InitialLevel:   0,
-				SpeedIncrement: 0.5,
-				BallScale:      0.5,
-			}
-			g := app.NewSquash(tt.width, tt.height, cfg)
-
-			drawTextCenter(mockRenderer, g, tt.text)
+			drawTextCenter(mockRenderer, l, ThemeClassic, tt.text)
 
 			for i := range tt.text {
-				mockRenderer.AssertCalled(t, "MeasureText", tt.text[i])
-				mockRenderer.AssertCalled(t, "DrawText", tt.text[i], (tt.width-tt.textWidth)/2, tt.height/2+float64(i*20))
+				y := (tt.height-blockH)/2 + size*ascent + size*l.LineHeight*float64(i)
+				mockRenderer.AssertCalled(t, "MeasureText", tt.text[i], styleText.Font)
+				mockRenderer.AssertCalled(t, "DrawText", tt.text[i], (tt.width-tt.textWidth)/2, y, styleText)
 			}
 		})
 	}
 }
 
+// The same check recorded with a CommandBuffer: the expected frame reads as
+// the commands drawn, in order.
+func TestDrawTextCenterCommands(t *testing.T) {
+	b := record.NewCommandBuffer(func(text string, font ports.Font) float64 { return 100 })
+
+	drawTextCenter(b, NewLayout(800, 600), ThemeClassic, []string{"Line 1", "Line 2"})
+
+	want := []string{
+		`text 350 293.5 "Line 1" font="Arial" size=20 color=#ffffff`,
+		`text 350 318.5 "Line 2" font="Arial" size=20 color=#ffffff`,
+	}
+	var got []string
+	for _, cmd := range b.Commands() {
+		got = append(got, cmd.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
+
+func TestDrawGameElementsRoundBall(t *testing.T) {
+	mockRenderer := mocks.NewRenderer(t)
+	mockRenderer.On("DrawCircle", 407.5, 307.5, 7.5, styleEntity).Return()
+	mockRenderer.On("DrawRect", mock.Anything, mock.Anything, mock.Anything, mock.Anything, styleEntity).Return()
+
+	cfg := app.NewDefaultConfig()
+	cfg.BallShape = app.BallRound
+	g := app.NewSquash(800, 600, cfg)
+	g.BallX = 400
+	g.BallY = 300
+	g.BallSize = 15
+
+	drawGameElements(mockRenderer, g, ThemeClassic)
+
+	mockRenderer.AssertNumberOfCalls(t, "DrawRect", 1) // paddle only
+}
+
 func TestDrawGameElementsCall(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -831,8 +892,8 @@ func TestDrawGameElementsCall(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRenderer := mocks.NewRenderer(t)
-			mockRenderer.On("DrawBall", tt.ballX, tt.ballY, tt.ballS).Return()
-			mockRenderer.On("DrawPaddle", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH).Return()
+			mockRenderer.On("DrawRect", tt.ballX, tt.ballY, tt.ballS, tt.ballS, styleEntity).Return()
+			mockRenderer.On("DrawRect", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH, styleEntity).Return()
 
 			cfg := app.Config{
 				Debug:          false,
@@ -852,10 +913,345 @@ func TestDrawGameElementsCall(t *testing.T) {
 			g.PaddleW = tt.paddleW
 			g.PaddleH = tt.paddleH
 
-			drawGameElements(mockRenderer, g)
+			drawGameElements(mockRenderer, g, ThemeClassic)
+
+			mockRenderer.AssertCalled(t, "DrawRect", tt.ballX, tt.ballY, tt.ballS, tt.ballS, styleEntity)
+			mockRenderer.AssertCalled(t, "DrawRect", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH, styleEntity)
+		})
+	}
+}
+
+func TestGetDebugInfoConfigTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		origin    map[string]string
+		wantLines int
+		wantLine  string
+	}{
+		{
+			name:      "Without origin - no config lines",
+			origin:    nil,
+			wantLines: 8,
+		},
+		{
+			name:      "With origin - one line per parameter",
+			origin:    map[string]string{app.ParamLives: "url"},
+			wantLines: 8 + 1 + len(app.ConfigParams),
+			wantLine:  "lives:    3 (url)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := app.NewDefaultConfig()
+			cfg.Debug = true
+			cfg.Origin = tt.origin
+			g := app.NewSquash(800, 600, cfg)
+
+			got := getDebugInfo(&hudText{}, g)
+			if len(got) != tt.wantLines {
+				t.Errorf("getDebugInfo() lines = %v, want %v", len(got), tt.wantLines)
+			}
+
+			if tt.wantLine == "" {
+				return
+			}
+
+			found := false
+			for _, line := range got {
+				if line == tt.wantLine {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("getDebugInfo() missing line %q in %v", tt.wantLine, got)
+			}
+		})
+	}
+}
+
+func TestPaintGameMenuConfigIssues(t *testing.T) {
+	tests := []struct {
+		name      string
+		issues    []app.ConfigIssue
+		wantLines []string
+	}{
+		{
+			name:      "No issues",
+			issues:    nil,
+			wantLines: []string{},
+		},
+		{
+			name: "Rejected and ignored parameters",
+			issues: []app.ConfigIssue{
+				{Param: "lives", Value: "500", Origin: "url", Severity: app.SeverityError, Kind: app.IssueOutOfRange, Reason: "500 is out of range [1, 99]"},
+				{Param: "speed", Value: "9", Origin: "server", Severity: app.SeverityWarning, Kind: app.IssueUnknownParam, Reason: "unknown parameter"},
+			},
+			wantLines: []string{
+				"REJECTED lives=500 (url): OUT OF RANGE",
+				"IGNORED speed=9 (server): UNKNOWN PARAMETER",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(200.0)
+
+			cfg := app.NewDefaultConfig()
+			cfg.Issues = tt.issues
+			g := app.NewSquash(800, 600, cfg)
+
+			got := getTextConfigIssues(LocaleEnglish, g)
+			if len(got) != len(tt.wantLines) {
+				t.Fatalf("getTextConfigIssues() = %v, want %v", got, tt.wantLines)
+			}
+
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
+
+			assertDebugTextCalls(t, mockRenderer, len(tt.wantLines))
+
+			l := NewLayout(800, 600)
+			size := styleDebug.Font.Size
+			top := 600 - l.Padding - (size + size*l.LineHeight*float64(len(tt.wantLines)-1))
+			for i, line := range tt.wantLines {
+				y := top + size*ascent + size*l.LineHeight*float64(i)
+				mockRenderer.AssertCalled(t, "DrawText", line, l.Padding, y, styleDebug)
+			}
+		})
+	}
+}
+
+func TestGetTextPreset(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		mode   string
+		want   string
+	}{
+		{
+			name:   "Normal preset",
+			preset: app.PresetNormal,
+			want:   "< NORMAL > (WHEEL: DIFFICULTY)",
+		},
+		{
+			name:   "Challenge shows the seed",
+			preset: app.PresetNormal,
+			mode:   app.ModeChallenge,
+			want:   "CHALLENGE - SEED 42",
+		},
+		{
+			name:   "Custom preset",
+			preset: app.PresetCustom,
+			want:   "< CUSTOM > (WHEEL: DIFFICULTY)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := app.NewDefaultConfig()
+			cfg.Preset = tt.preset
+			cfg.Seed = 42
+			if tt.mode != "" {
+				cfg.Mode = tt.mode
+			}
+			g := app.NewSquash(800, 600, cfg)
+
+			if got := getTextPreset(LocaleEnglish, g); got != tt.want {
+				t.Errorf("getTextPreset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTextStateSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected int
+		want     []string
+	}{
+		{
+			name:     "First field selected",
+			selected: 0,
+			want: []string{
+				"SETTINGS",
+				"> LIVES: 3",
+				"  BOOST: 0.25",
+				"  BALL SIZE: 0",
+				"  BALL SHAPE: SQUARE",
+				"  FPS: 30",
+				"  THEME: CLASSIC",
+				"  REDUCED MOTION: OFF",
+				"(WHEEL: SELECT - LEFT CLICK: CHANGE)",
+				"(RIGHT CLICK: SAVE)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := app.NewSquash(800, 600, app.NewDefaultConfig())
+			g.OpenSettings(app.NewDefaultConfig())
+			g.Settings.Selected = tt.selected
+
+			got := getTextStateSettings(LocaleEnglish, g)
+			if len(got) != len(tt.want) {
+				t.Fatalf("getTextStateSettings() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("getTextStateSettings()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+
+			mockRenderer := mocks.NewRenderer(t)
+			mockRenderer.On("Clear", colorBackground).Return()
+			mockRenderer.On("DrawText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+			mockRenderer.On("MeasureText", mock.Anything, mock.Anything).Return(100.0)
+
+			PaintGame(mockRenderer, g, ThemeClassic, nil)
+
+			mockRenderer.AssertNumberOfCalls(t, "MeasureText", len(tt.want)+4) // + HUD row fit and placement
+		})
+	}
+}
+
+func TestGetTextStateGameOver(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		shareToken string
+		want       []string
+	}{
+		{
+			name: "Offers the challenge link",
+			want: []string{"GAME OVER - 120 POINTS", "(LEFT CLICK TO RESTART)", "(RIGHT CLICK: COPY CHALLENGE LINK)"},
+		},
+		{
+			name:       "Confirms the copied link",
+			shareToken: "1.abc.00000000",
+			want: []string{
+				"GAME OVER - 120 POINTS", "(LEFT CLICK TO RESTART)", "CHALLENGE LINK COPIED",
+				"(CHALLENGE LINKS ARE NOT VERIFIED)",
+			},
+		},
+		{
+			name: "Warns in a challenge",
+			mode: app.ModeChallenge,
+			want: []string{
+				"GAME OVER - 120 POINTS", "(LEFT CLICK TO RESTART)", "(RIGHT CLICK: COPY CHALLENGE LINK)",
+				"(CHALLENGE LINKS ARE NOT VERIFIED)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := app.NewSquash(800, 600, app.NewDefaultConfig())
+			g.Score = 120
+			g.ShareToken = tt.shareToken
+			if tt.mode != "" {
+				g.Mode = tt.mode
+			}
+
+			if got := getTextStateGameOver(LocaleEnglish, g); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getTextStateGameOver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// layeredBuffer records the layers begun and keeps the background while its
+// key is unchanged.
+type layeredBuffer struct {
+	*record.CommandBuffer
+	layers []ports.Layer
+	key    any
+}
+
+func (b *layeredBuffer) BeginLayer(layer ports.Layer, key any) bool {
+	b.layers = append(b.layers, layer)
+	if layer != ports.LayerBackground {
+		return true
+	}
+	if key == b.key {
+		return false
+	}
+
+	b.key = key
+	return true
+}
+
+func TestPaintGameLayers(t *testing.T) {
+	g := newGoldenGame(app.StatePlaying, nil)
+	g.DebugMode = true
+	b := &layeredBuffer{CommandBuffer: record.NewCommandBuffer(nil)}
+
+	tests := []struct {
+		name      string
+		theme     Theme
+		wantClear bool
+	}{
+		{name: "First frame draws the background", theme: ThemeNeon, wantClear: true},
+		{name: "Same theme keeps it", theme: ThemeNeon, wantClear: false},
+		{name: "Theme change draws it again", theme: ThemeClassic, wantClear: true},
+	}
 
-			mockRenderer.AssertCalled(t, "DrawBall", tt.ballX, tt.ballY, tt.ballS)
-			mockRenderer.AssertCalled(t, "DrawPaddle", tt.paddleX, tt.paddleY, tt.paddleW, tt.paddleH)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b.Reset()
+			b.layers = nil
+			PaintGame(b, g, tt.theme, nil)
+
+			want := []ports.Layer{ports.LayerBackground, ports.LayerEntities, ports.LayerHUD, ports.LayerDebug}
+			if !reflect.DeepEqual(b.layers, want) {
+				t.Errorf("layers = %v, want %v", b.layers, want)
+			}
+			cleared := false
+			for _, cmd := range b.Commands() {
+				cleared = cleared || cmd.Op == record.OpClear
+			}
+			if cleared != tt.wantClear {
+				t.Errorf("background drawn = %v, want %v", cleared, tt.wantClear)
+			}
+		})
+	}
+}
+
+func TestPainterExporting(t *testing.T) {
+	tests := []struct {
+		name      string
+		lang      string
+		exporting bool
+		progress  float64
+		want      string
+	}{
+		{name: "No export", lang: app.LangEnglish, want: ""},
+		{name: "Halfway", lang: app.LangEnglish, exporting: true, progress: 0.5, want: "SAVING CLIP - 50%"},
+		{name: "Portuguese", lang: app.LangPortuguese, exporting: true, progress: 1, want: "SALVANDO CLIPE - 100%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGoldenGame(app.StatePlaying, nil)
+			g.Lang = tt.lang
+			b := record.NewCommandBuffer(nil)
+
+			pt := Painter{Exporting: tt.exporting, ExportProgress: tt.progress}
+			pt.Paint(b, g, ThemeClassic, nil)
+
+			got := ""
+			for _, cmd := range b.Commands() {
+				if cmd.Op == record.OpText && strings.Contains(cmd.Text, "%") {
+					got = cmd.Text
+				}
+			}
+			if got != tt.want {
+				t.Errorf("export text = %q, want %q", got, tt.want)
+			}
 		})
 	}
 }
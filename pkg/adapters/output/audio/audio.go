@@ -0,0 +1,36 @@
+package audio
+
+import (
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// eventEffects maps the engine events to the sound each one plays.
+var eventEffects = map[app.EventKind]ports.SoundEffect{
+	app.EventPaddleHit:  ports.SoundPaddleHit,
+	app.EventWallBounce: ports.SoundWallBounce,
+	app.EventLifeLost:   ports.SoundLifeLost,
+	app.EventLevelUp:    ports.SoundLevelUp,
+	app.EventGameOver:   ports.SoundGameOver,
+}
+
+// PlayEvents plays the sound of each event drained from the engine; an effect
+// is played once per call even when the frame emitted it several times.
+func PlayEvents(player ports.AudioPlayer, events []app.Event) {
+	played := make(map[ports.SoundEffect]bool, len(events))
+	for _, event := range events {
+		effect, ok := eventEffects[event.Kind]
+		if !ok || played[effect] {
+			continue
+		}
+
+		played[effect] = true
+		player.Play(effect)
+	}
+}
+
+// Configure applies the volume and mute settings of cfg to player.
+func Configure(player ports.AudioPlayer, cfg app.Config) {
+	player.SetVolume(cfg.Volume)
+	player.SetMuted(cfg.Mute)
+}
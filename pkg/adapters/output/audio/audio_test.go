@@ -0,0 +1,99 @@
+package audio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+func TestPlayEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []app.Event
+		want   []ports.SoundEffect
+	}{
+		{
+			name:   "No events",
+			events: nil,
+			want:   nil,
+		},
+		{
+			name: "Every event has a sound",
+			events: []app.Event{
+				{Kind: app.EventPaddleHit},
+				{Kind: app.EventWallBounce},
+				{Kind: app.EventLifeLost},
+				{Kind: app.EventLevelUp},
+				{Kind: app.EventGameOver},
+			},
+			want: []ports.SoundEffect{
+				ports.SoundPaddleHit,
+				ports.SoundWallBounce,
+				ports.SoundLifeLost,
+				ports.SoundLevelUp,
+				ports.SoundGameOver,
+			},
+		},
+		{
+			name: "Repeated events in a frame play once",
+			events: []app.Event{
+				{Kind: app.EventWallBounce, X: 790, Y: 5},
+				{Kind: app.EventWallBounce, X: 795, Y: 5},
+				{Kind: app.EventPaddleHit},
+			},
+			want: []ports.SoundEffect{ports.SoundWallBounce, ports.SoundPaddleHit},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := NewRecorder()
+
+			PlayEvents(recorder, tt.events)
+
+			if !reflect.DeepEqual(recorder.Played, tt.want) {
+				t.Errorf("PlayEvents() played %v, want %v", recorder.Played, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayEventsFromEngine(t *testing.T) {
+	cfg := app.NewDefaultConfig()
+	cfg.DeltaTime = 0.016
+	cfg.InitialLives = 1
+	game := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	game.State = app.StatePlaying
+	game.BallX, game.BallY, game.BallDX, game.BallDY = -50, 300, -200, 0
+
+	recorder := NewRecorder()
+	game.Update()
+	PlayEvents(recorder, game.DrainEvents())
+	game.Update()
+	PlayEvents(recorder, game.DrainEvents())
+
+	want := []ports.SoundEffect{ports.SoundGameOver}
+	if !reflect.DeepEqual(recorder.Played, want) {
+		t.Errorf("played %v, want %v (once, only when the event happens)", recorder.Played, want)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	cfg := app.NewDefaultConfig()
+	cfg.Volume = 0.3
+	cfg.Mute = true
+
+	recorder := NewRecorder()
+	Configure(recorder, cfg)
+
+	if recorder.Volume != 0.3 || !recorder.Muted {
+		t.Errorf("Configure() volume = %v, muted = %v, want 0.3, true", recorder.Volume, recorder.Muted)
+	}
+
+	recorder.Reset()
+	if recorder.Played != nil {
+		t.Errorf("Reset() kept %v", recorder.Played)
+	}
+}
@@ -0,0 +1,18 @@
+package audio
+
+import "github.com/psaraiva/squash/internal/ports"
+
+// Nop is the AudioPlayer of headless builds and browsers without Web Audio.
+type Nop struct{}
+
+func NewNop() *Nop {
+	return &Nop{}
+}
+
+func (n *Nop) Play(effect ports.SoundEffect) {}
+
+func (n *Nop) SetVolume(volume float64) {}
+
+func (n *Nop) SetMuted(muted bool) {}
+
+var _ ports.AudioPlayer = (*Nop)(nil)
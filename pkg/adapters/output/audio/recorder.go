@@ -0,0 +1,34 @@
+package audio
+
+import "github.com/psaraiva/squash/internal/ports"
+
+// Recorder is an AudioPlayer for tests: it keeps every effect played and the
+// last volume and mute settings.
+type Recorder struct {
+	Played []ports.SoundEffect
+	Volume float64
+	Muted  bool
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{Volume: 1}
+}
+
+func (r *Recorder) Play(effect ports.SoundEffect) {
+	r.Played = append(r.Played, effect)
+}
+
+func (r *Recorder) SetVolume(volume float64) {
+	r.Volume = volume
+}
+
+func (r *Recorder) SetMuted(muted bool) {
+	r.Muted = muted
+}
+
+// Reset forgets the effects played so far.
+func (r *Recorder) Reset() {
+	r.Played = nil
+}
+
+var _ ports.AudioPlayer = (*Recorder)(nil)
@@ -0,0 +1,201 @@
+package clip
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+func newClipGame(fps int) *app.Squash {
+	cfg := app.NewDefaultConfig()
+	cfg.Fps = fps
+	return app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+}
+
+func TestRecorderSampling(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		fps     int
+		gameFps int
+		ticks   int
+		want    int
+	}{
+		{name: "Game at 30, clip at 15", seconds: 10, fps: 15, gameFps: 30, ticks: 30, want: 15},
+		{name: "Game at 60, clip at 15", seconds: 10, fps: 15, gameFps: 60, ticks: 60, want: 15},
+		{name: "Game at 30, clip at 20", seconds: 10, fps: 20, gameFps: 30, ticks: 60, want: 40},
+		{name: "Game at 30, clip at 25", seconds: 10, fps: 25, gameFps: 30, ticks: 30, want: 25},
+		{name: "Clip faster than the game keeps every tick", seconds: 10, fps: 30, gameFps: 30, ticks: 10, want: 10},
+		{name: "Only the last seconds are kept", seconds: 2, fps: 10, gameFps: 30, ticks: 300, want: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewRecorder(tt.seconds, tt.fps)
+			g := newClipGame(tt.gameFps)
+
+			for i := 0; i < tt.ticks; i++ {
+				rec.Record(g)
+			}
+
+			if got := len(rec.Frames()); got != tt.want {
+				t.Errorf("len(Frames()) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorderOrder(t *testing.T) {
+	rec := NewRecorder(1, 3)
+	g := newClipGame(30)
+	g.Fps = 3 // one frame per tick
+
+	for score := 1; score <= 5; score++ {
+		g.Score = score
+		rec.Record(g)
+	}
+
+	frames := rec.Frames()
+	for i, want := range []int{3, 4, 5} {
+		if frames[i].Score != want {
+			t.Errorf("Frames()[%d].Score = %d, want %d", i, frames[i].Score, want)
+		}
+	}
+
+	// the frames are copies
+	g.Score = 99
+	if rec.Frames()[2].Score != 5 {
+		t.Error("recorded frame changed with the game")
+	}
+
+	rec.Reset()
+	if len(rec.Frames()) != 0 {
+		t.Error("Reset() kept frames")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	g := newClipGame(30)
+	frames := []app.Squash{*g, *g, *g}
+	colors := []ports.Color{ports.RGB(0, 0, 0), ports.RGB(255, 0, 0)}
+
+	painted := 0
+	paint := func(r ports.Renderer, p *app.Squash) {
+		painted++
+		r.Clear(colors[0])
+		r.DrawRect(0, 0, 400, 600, ports.Style{Fill: colors[1]})
+	}
+
+	var buf bytes.Buffer
+	err := Encode(&buf, frames, paint, Options{Fps: 10, Scale: 0.1, Palette: ThemePalette(colors)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if painted != len(frames) || len(out.Image) != len(frames) {
+		t.Fatalf("painted %d, encoded %d frames, want %d", painted, len(out.Image), len(frames))
+	}
+	if out.Delay[0] != 10 {
+		t.Errorf("Delay = %d, want 10 (hundredths of a second)", out.Delay[0])
+	}
+	if size := out.Image[0].Bounds().Size(); size != (image.Point{X: 80, Y: 60}) {
+		t.Errorf("size = %v, want 80x60", size)
+	}
+	if got := color.RGBAModel.Convert(out.Image[0].At(10, 30)); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("left half = %v, want red", got)
+	}
+	if got := color.RGBAModel.Convert(out.Image[0].At(70, 30)); got != (color.RGBA{A: 255}) {
+		t.Errorf("right half = %v, want black", got)
+	}
+}
+
+func TestEncoderSteps(t *testing.T) {
+	g := newClipGame(30)
+	frames := []app.Squash{*g, *g, *g, *g}
+
+	painted := 0
+	paint := func(r ports.Renderer, p *app.Squash) { painted++ }
+
+	e, err := NewEncoder(frames, paint, Options{Fps: 10, Scale: 0.1, Palette: palette.Plan9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if painted != 0 || e.Progress() != 0 {
+		t.Fatalf("NewEncoder() painted %d frames, progress %v", painted, e.Progress())
+	}
+
+	// one frame per step, so the caller can keep ticking in between
+	for i := 1; i <= len(frames); i++ {
+		more := e.Step()
+		if painted != i || more != (i < len(frames)) {
+			t.Fatalf("Step() %d: painted %d frames, more = %v", i, painted, more)
+		}
+	}
+	if e.Progress() != 1 {
+		t.Errorf("Progress() = %v, want 1", e.Progress())
+	}
+
+	var buf bytes.Buffer
+	if err := e.Finish(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if painted != len(frames) || len(out.Image) != len(frames) {
+		t.Errorf("painted %d, encoded %d frames, want %d", painted, len(out.Image), len(frames))
+	}
+}
+
+func TestEncodeNoFrames(t *testing.T) {
+	err := Encode(&bytes.Buffer{}, nil, nil, Options{Fps: 10, Scale: 0.5, Palette: palette.Plan9})
+	if !errors.Is(err, ErrNoFrames) {
+		t.Errorf("Encode() error = %v, want ErrNoFrames", err)
+	}
+}
+
+func TestLookupPalette(t *testing.T) {
+	colors := []ports.Color{ports.RGB(0, 0, 0), ports.RGB(255, 255, 255), {}, ports.RGB(255, 0, 0)}
+
+	tests := []struct {
+		name    string
+		palette string
+		want    int
+	}{
+		{name: "Plan 9", palette: app.PalettePlan9, want: len(palette.Plan9)},
+		{name: "Web safe", palette: app.PaletteWebSafe, want: len(palette.WebSafe)},
+		// background + 16 fades of white and of red; the transparent color is skipped
+		{name: "Theme", palette: app.PaletteTheme, want: 1 + 2*themeSteps},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(LookupPalette(tt.palette, colors)); got != tt.want {
+				t.Errorf("len(LookupPalette(%q)) = %d, want %d", tt.palette, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	cfg := app.NewDefaultConfig()
+	cfg.ClipFps, cfg.ClipScale, cfg.ClipPalette = 12, 0.25, app.PaletteWebSafe
+
+	opts := NewOptions(cfg, nil)
+	if opts.Fps != 12 || opts.Scale != 0.25 || len(opts.Palette) != len(palette.WebSafe) {
+		t.Errorf("NewOptions() = %d fps, %v scale, %d colors", opts.Fps, opts.Scale, len(opts.Palette))
+	}
+}
@@ -0,0 +1,180 @@
+package clip
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"io"
+	"math"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+)
+
+var ErrNoFrames = errors.New("clip: no frames recorded")
+
+// themeSteps is the number of fades from the background to each theme color.
+const themeSteps = 16
+
+// Paint draws one frame of the clip; the frontend passes its UI, e.g. web.PaintGame.
+type Paint func(r ports.Renderer, p *app.Squash)
+
+// Options of an exported GIF.
+type Options struct {
+	Fps     int
+	Scale   float64 // pixels per court unit
+	Palette color.Palette
+}
+
+// NewOptions reads the clip options of cfg; colors are the theme colors, the
+// background first, used by the theme palette.
+func NewOptions(cfg app.Config, colors []ports.Color) Options {
+	return Options{
+		Fps:     cfg.ClipFps,
+		Scale:   cfg.ClipScale,
+		Palette: LookupPalette(cfg.ClipPalette, colors),
+	}
+}
+
+// Encode draws every frame with the raster renderer and writes them as an
+// animated GIF that loops forever.
+func Encode(w io.Writer, frames []app.Squash, paint Paint, opts Options) error {
+	e, err := NewEncoder(frames, paint, opts)
+	if err != nil {
+		return err
+	}
+
+	return e.Finish(w)
+}
+
+// Encoder draws a clip one frame per Step, so that a frontend with a single
+// thread (js/wasm) can spread the export over its ticks instead of stopping
+// the game while it runs.
+type Encoder struct {
+	frames []app.Squash
+	paint  Paint
+	r      *raster.Renderer
+	q      *quantizer
+	delay  int // hundredths of a second
+	out    gif.GIF
+}
+
+// NewEncoder prepares the export of frames; it draws none of them yet.
+func NewEncoder(frames []app.Squash, paint Paint, opts Options) (*Encoder, error) {
+	if len(frames) == 0 {
+		return nil, ErrNoFrames
+	}
+
+	first := frames[0]
+	return &Encoder{
+		frames: frames,
+		paint:  paint,
+		r:      raster.NewScaled(first.Width, first.Height, opts.Scale),
+		q:      newQuantizer(opts.Palette),
+		delay:  int(math.Round(100 / float64(max(opts.Fps, 1)))),
+	}, nil
+}
+
+// Step draws the next frame and reports whether any is left.
+func (e *Encoder) Step() bool {
+	if i := len(e.out.Image); i < len(e.frames) {
+		e.paint(e.r, &e.frames[i])
+		e.out.Image = append(e.out.Image, e.q.convert(e.r.Image()))
+		e.out.Delay = append(e.out.Delay, e.delay)
+	}
+
+	return len(e.out.Image) < len(e.frames)
+}
+
+// Progress is the share of the frames drawn, from 0 to 1.
+func (e *Encoder) Progress() float64 {
+	return float64(len(e.out.Image)) / float64(len(e.frames))
+}
+
+// Finish draws the frames left and writes the GIF.
+func (e *Encoder) Finish(w io.Writer) error {
+	for e.Step() {
+	}
+
+	return gif.EncodeAll(w, &e.out)
+}
+
+// LookupPalette returns the named palette; the theme palette is built from colors.
+func LookupPalette(name string, colors []ports.Color) color.Palette {
+	switch name {
+	case app.PalettePlan9:
+		return palette.Plan9
+	case app.PaletteWebSafe:
+		return palette.WebSafe
+	}
+
+	return ThemePalette(colors)
+}
+
+// ThemePalette holds the background (the first color) and themeSteps fades
+// from it to every other opaque color, so faded particles and trails keep
+// their hue. Transparent colors are skipped.
+func ThemePalette(colors []ports.Color) color.Palette {
+	if len(colors) == 0 {
+		return palette.Plan9
+	}
+
+	bg := raster.Blend(ports.RGB(0, 0, 0), colors[0])
+	seen := map[ports.Color]bool{bg: true}
+	pal := color.Palette{toRGBA(bg)}
+
+	for _, c := range colors[1:] {
+		if c.A == 0 {
+			continue
+		}
+
+		for step := 1; step <= themeSteps; step++ {
+			faded := c
+			faded.A = uint8(math.Round(float64(c.A) * float64(step) / themeSteps))
+			mixed := raster.Blend(bg, faded)
+			if !seen[mixed] && len(pal) < 256 {
+				seen[mixed] = true
+				pal = append(pal, toRGBA(mixed))
+			}
+		}
+	}
+
+	return pal
+}
+
+// quantizer maps the frame colors to the palette, caching the nearest index
+// of each color: a frame has few distinct colors.
+type quantizer struct {
+	palette color.Palette
+	cache   map[color.RGBA]uint8
+}
+
+func newQuantizer(p color.Palette) *quantizer {
+	return &quantizer{palette: p, cache: make(map[color.RGBA]uint8)}
+}
+
+func (q *quantizer) convert(src *image.RGBA) *image.Paletted {
+	b := src.Bounds()
+	dst := image.NewPaletted(b, q.palette)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := src.RGBAAt(x, y)
+			idx, ok := q.cache[c]
+			if !ok {
+				idx = uint8(q.palette.Index(c))
+				q.cache[c] = idx
+			}
+			dst.SetColorIndex(x, y, idx)
+		}
+	}
+
+	return dst
+}
+
+func toRGBA(c ports.Color) color.RGBA {
+	return color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}
+}
@@ -0,0 +1,61 @@
+package clip
+
+import "github.com/psaraiva/squash/internal/app"
+
+// Recorder keeps the game state of the last seconds of play, sampled at the
+// clip frame rate, in a ring of fixed size. Frames are drawn only on export, so
+// recording costs one struct copy per sample. It is not safe for concurrent use.
+type Recorder struct {
+	fps    int
+	frames []app.Squash
+	head   int
+	count  int
+	tick   int // clip frames owed, times the game fps
+}
+
+// NewRecorder keeps seconds of play at fps frames per second.
+func NewRecorder(seconds, fps int) *Recorder {
+	fps = max(fps, 1)
+	return &Recorder{
+		fps:    fps,
+		frames: make([]app.Squash, max(seconds, 1)*fps),
+	}
+}
+
+// Fps is the frame rate of the recorded clip.
+func (r *Recorder) Fps() int {
+	return r.fps
+}
+
+// Record is called once per game tick; it keeps fps states per second of play,
+// spread evenly over the ticks, so that the clip runs at its own frame rate
+// whatever the game FPS, even when it does not divide it.
+func (r *Recorder) Record(p *app.Squash) {
+	gameFps := max(p.Fps, 1)
+	r.tick += r.fps
+	if r.tick < gameFps {
+		return
+	}
+	r.tick %= gameFps
+
+	r.frames[r.head] = *p
+	r.head = (r.head + 1) % len(r.frames)
+	if r.count < len(r.frames) {
+		r.count++
+	}
+}
+
+// Frames returns a copy of the recorded states, oldest first.
+func (r *Recorder) Frames() []app.Squash {
+	frames := make([]app.Squash, 0, r.count)
+	for i := 0; i < r.count; i++ {
+		frames = append(frames, r.frames[(r.head-r.count+i+len(r.frames))%len(r.frames)])
+	}
+
+	return frames
+}
+
+// Reset drops the recorded states.
+func (r *Recorder) Reset() {
+	r.head, r.count, r.tick = 0, 0, 0
+}
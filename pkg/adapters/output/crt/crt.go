@@ -0,0 +1,239 @@
+// Package crt decorates a Renderer with the look of an old CRT monitor:
+// scanlines, a phosphor glow, a slightly curved screen and the afterimage the
+// phosphor leaves behind the moving ball.
+package crt
+
+import (
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/record"
+)
+
+// afterimageAlpha is the alpha of the most recent afterimage; older frames
+// fade linearly from it.
+const afterimageAlpha = 0.4
+
+const (
+	// ScanlinePeriod is the height of a scanline and its gap, in court units.
+	ScanlinePeriod = 4
+	// GlowSpread is how far the glow reaches out of the shapes, in court units.
+	GlowSpread = 3
+)
+
+// Options tune the effect; zero values turn each part off.
+type Options struct {
+	Scanlines  float64 // darkness of the gaps between scanlines, 0 to 1
+	Glow       float64 // alpha of the halo around the shapes, 0 to 1
+	Curvature  float64 // how much the corners are pulled in, as a fraction of the half size
+	Afterimage int     // number of past frames of the entities kept on screen
+}
+
+// DefaultOptions is a subtle look that keeps the game readable.
+func DefaultOptions() Options {
+	return Options{Scanlines: 0.35, Glow: 0.25, Curvature: 0.04, Afterimage: 3}
+}
+
+// Shader is a renderer that draws scanlines, glow and curvature itself, e.g.
+// in a post-processing pass on the GPU; zero Options turn them off. The
+// decorator still draws the afterimages.
+type Shader interface {
+	SetCRT(opts Options)
+}
+
+// Renderer draws the game on the renderer it decorates and, when enabled,
+// adds the CRT look. Without a Shader everything is composed with the
+// primitives of ports.Renderer: the shapes are bent and given a halo as they
+// are drawn, and EndFrame lays the scanlines and the dark bezel over the
+// frame. When disabled it only forwards the calls.
+//
+// The afterimages are the entities layer of the previous frames, recorded and
+// drawn again fading under the current one.
+type Renderer struct {
+	ports.Renderer // where the calls of the current layer go
+
+	inner  ports.Renderer
+	shader Shader
+	warp   *warp
+	opts   Options
+	w, h   float64
+	on     bool
+
+	frames    []*record.CommandBuffer // past entity frames, oldest first
+	recording *record.CommandBuffer   // the entities of this frame, while they are drawn
+
+	bezel [4][]ports.Point
+}
+
+// crtKey keeps the background layer of a LayeredRenderer apart for each look,
+// so that it is drawn again when the look is toggled.
+type crtKey struct {
+	key any
+	on  bool
+}
+
+// New decorates r, whose court is w by h, disabled.
+func New(r ports.Renderer, w, h float64, opts Options) *Renderer {
+	c := &Renderer{Renderer: r, inner: r, warp: newWarp(r, w, h), opts: opts, w: w, h: h}
+	c.shader, _ = r.(Shader)
+	c.warp.curve = opts.Curvature
+	c.warp.glow = opts.Glow
+
+	for range opts.Afterimage {
+		c.frames = append(c.frames, record.NewCommandBuffer(r.MeasureText))
+	}
+	c.recording = record.NewCommandBuffer(r.MeasureText)
+
+	c.bezel = c.newBezel()
+	return c
+}
+
+// Enabled reports whether the CRT look is on.
+func (c *Renderer) Enabled() bool {
+	return c.on
+}
+
+// SetEnabled turns the CRT look on or off, from the next layer on; turning
+// it off forgets the afterimages.
+func (c *Renderer) SetEnabled(on bool) {
+	if on == c.on {
+		return
+	}
+
+	c.on = on
+	c.Renderer = c.target()
+	if c.shader != nil {
+		if on {
+			c.shader.SetCRT(c.opts)
+		} else {
+			c.shader.SetCRT(Options{})
+		}
+	}
+
+	if !on {
+		for _, frame := range c.frames {
+			frame.Reset()
+		}
+		c.recording.Reset()
+	}
+}
+
+// target is where the calls are drawn: bent by the warp, unless the look is
+// off or the renderer below draws it itself.
+func (c *Renderer) target() ports.Renderer {
+	if c.on && c.shader == nil {
+		c.warp.invalidate()
+		return c.warp
+	}
+
+	return c.inner
+}
+
+// BeginLayer implements ports.LayeredRenderer: the layers are passed on to
+// the renderer below when it has layers, and the entities are recorded for
+// the afterimages.
+func (c *Renderer) BeginLayer(layer ports.Layer, key any) bool {
+	c.endEntities()
+
+	draw := true
+	if lr, ok := c.inner.(ports.LayeredRenderer); ok {
+		if key != nil {
+			key = crtKey{key: key, on: c.on}
+		}
+		draw = lr.BeginLayer(layer, key)
+	}
+
+	c.Renderer = c.target()
+	if layer == ports.LayerEntities && c.on && len(c.frames) > 0 {
+		c.Renderer = c.recording
+	}
+
+	return draw
+}
+
+// EndFrame draws what is left of the frame: the recorded entities and, when
+// the look is drawn here, the scanlines and the bezel on top of it all.
+func (c *Renderer) EndFrame() {
+	c.endEntities()
+	c.Renderer = c.target()
+	if !c.on || c.shader != nil {
+		return
+	}
+
+	// the renderer below is left at full alpha: a canvas keeps it across
+	// frames, and the game is drawn on it unwarped once the look is off
+	black := ports.Style{Fill: ports.RGB(0, 0, 0)}
+	if c.opts.Scanlines > 0 {
+		// rects, not an image stretched over the court, which the canvas would blur
+		c.warp.setAlpha(min(c.opts.Scanlines, 1))
+		for y := ScanlinePeriod / 2.0; y < c.h; y += ScanlinePeriod {
+			c.inner.DrawRect(0, y, c.w, ScanlinePeriod/2.0, black)
+		}
+	}
+	c.warp.setAlpha(1)
+	if c.opts.Curvature > 0 {
+		for _, side := range c.bezel {
+			c.inner.DrawPolygon(side, black)
+		}
+	}
+}
+
+// endEntities draws the recorded entities over their afterimages, when the
+// entities layer was being recorded, and keeps them for the next frames.
+func (c *Renderer) endEntities() {
+	if c.Renderer != c.recording {
+		return
+	}
+
+	draw := c.target()
+	n := len(c.frames)
+	for i, frame := range c.frames {
+		alpha := afterimageAlpha * float64(i+1) / float64(n+1)
+		faded := &faded{Renderer: draw, alpha: alpha}
+		draw.Save()
+		draw.SetAlpha(alpha)
+		frame.Replay(faded)
+		draw.Restore()
+	}
+	c.recording.Replay(draw)
+
+	// the oldest frame is reused to record the next one
+	oldest := c.frames[0]
+	oldest.Reset()
+	copy(c.frames, c.frames[1:])
+	c.frames[n-1] = c.recording
+	c.recording = oldest
+	c.Renderer = draw
+}
+
+// faded draws with its alpha times the alpha that is set.
+type faded struct {
+	ports.Renderer
+	alpha float64
+}
+
+func (f *faded) SetAlpha(alpha float64) {
+	f.Renderer.SetAlpha(alpha * f.alpha)
+}
+
+// newBezel is the dark border the curved screen leaves inside the court, as
+// one polygon per side: from the corners of the court to the bent edge.
+func (c *Renderer) newBezel() [4][]ports.Point {
+	var bezel [4][]ports.Point
+	if c.opts.Curvature <= 0 {
+		return bezel
+	}
+
+	corners := [5]ports.Point{{}, {X: c.w}, {X: c.w, Y: c.h}, {Y: c.h}, {}}
+	for i := range 4 {
+		a, b := corners[i], corners[i+1]
+		side := []ports.Point{a, b}
+		// the edge is walked back from b to a, so the polygon does not cross itself
+		edge := c.warp.edge(nil, b, a)
+		side = append(side, edge...)
+		side = append(side, c.warp.bend(a))
+		bezel[i] = side
+	}
+
+	return bezel
+}
+
+var _ ports.LayeredRenderer = (*Renderer)(nil)
@@ -0,0 +1,313 @@
+package crt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/record"
+)
+
+var white = ports.RGB(255, 255, 255)
+
+// frame draws a frame the way PaintGame does: a background, a ball on the
+// entities layer and a score on the HUD.
+func frame(r *Renderer, ballX float64) {
+	if r.BeginLayer(ports.LayerBackground, "court") {
+		r.Clear(ports.RGB(0, 0, 0))
+		r.DrawRect(0, 0, 800, 600, ports.Style{Stroke: white, LineWidth: 2})
+	}
+	r.BeginLayer(ports.LayerEntities, nil)
+	r.DrawCircle(ballX, 300, 10, ports.Style{Fill: white})
+	r.BeginLayer(ports.LayerHUD, nil)
+	r.DrawText("Score: 1", 10, 30, ports.TextStyle{Font: ports.Font{Size: 20}, Color: white})
+	r.EndFrame()
+}
+
+func ops(cmds []record.Command) []record.Op {
+	out := make([]record.Op, len(cmds))
+	for i, c := range cmds {
+		out[i] = c.Op
+	}
+
+	return out
+}
+
+func circles(cmds []record.Command) []record.Command {
+	var out []record.Command
+	for _, c := range cmds {
+		if c.Op == record.OpCircle {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// circleAlphas is the alpha each circle is drawn with.
+func circleAlphas(cmds []record.Command) []float64 {
+	var out []float64
+	alpha := 1.0
+	for _, c := range cmds {
+		switch c.Op {
+		case record.OpAlpha:
+			alpha = c.X
+		case record.OpCircle:
+			out = append(out, alpha)
+		}
+	}
+
+	return out
+}
+
+func TestDisabledForwards(t *testing.T) {
+	direct, decorated := record.NewCommandBuffer(nil), record.NewCommandBuffer(nil)
+	frame(New(direct, 800, 600, Options{}), 100)
+
+	r := New(decorated, 800, 600, DefaultOptions())
+	frame(r, 100)
+	frame(r, 100)
+
+	assert.False(t, r.Enabled())
+	assert.Equal(t, direct.Commands(), decorated.Commands()[:len(direct.Commands())])
+}
+
+func TestAfterimage(t *testing.T) {
+	b := record.NewCommandBuffer(nil)
+	r := New(b, 800, 600, Options{Afterimage: 2})
+	r.SetEnabled(true)
+
+	frame(r, 100)
+	frame(r, 200)
+	b.Reset()
+	frame(r, 300)
+
+	// the two past balls are drawn fading, oldest first, under the current one
+	got := circles(b.Commands())
+	require.Len(t, got, 3)
+	assert.Equal(t, []float64{100, 200, 300}, []float64{got[0].X, got[1].X, got[2].X})
+	assert.InDeltaSlice(t, []float64{afterimageAlpha / 3, 2 * afterimageAlpha / 3, 1}, circleAlphas(b.Commands()), 1e-9)
+
+	// turning the look off forgets them
+	r.SetEnabled(false)
+	r.SetEnabled(true)
+	b.Reset()
+	frame(r, 400)
+	assert.Len(t, circles(b.Commands()), 1)
+}
+
+func TestAfterimageKeepsAlpha(t *testing.T) {
+	b := record.NewCommandBuffer(nil)
+	r := New(b, 800, 600, Options{Afterimage: 1})
+	r.SetEnabled(true)
+
+	for range 2 {
+		r.BeginLayer(ports.LayerEntities, nil)
+		r.SetAlpha(0.5)
+		r.DrawCircle(100, 300, 10, ports.Style{Fill: white})
+		r.EndFrame()
+	}
+
+	// the alpha set by the past frame is faded too
+	assert.InDeltaSlice(t, []float64{0.5, 0.5 * afterimageAlpha / 2, 0.5}, circleAlphas(b.Commands()), 1e-9)
+}
+
+func TestWarp(t *testing.T) {
+	b := record.NewCommandBuffer(nil)
+	r := New(b, 800, 600, Options{Curvature: 0.1})
+	r.SetEnabled(true)
+
+	r.BeginLayer(ports.LayerEntities, nil)
+	r.DrawCircle(400, 300, 10, ports.Style{Fill: white})
+	r.DrawCircle(0, 0, 10, ports.Style{Fill: white})
+	r.DrawRect(0, 0, 800, 600, ports.Style{Stroke: white, LineWidth: 2})
+	r.Save()
+	r.Translate(400, 300)
+	r.Scale(2, 2)
+	r.DrawText("Hi", 0, 0, ports.TextStyle{Font: ports.Font{Size: 10}, Color: white})
+	r.Restore()
+
+	cmds := b.Commands()
+	require.Equal(t, []record.Op{record.OpAlpha, record.OpCircle, record.OpCircle, record.OpPolygon, record.OpText}, ops(cmds))
+
+	// the center stays, the corners are pulled in and shrink
+	assert.Equal(t, 400.0, cmds[1].X)
+	assert.Equal(t, 300.0, cmds[1].Y)
+	assert.Equal(t, 10.0, cmds[1].W)
+	assert.InDelta(t, 80, cmds[2].X, 1e-9)
+	assert.InDelta(t, 60, cmds[2].Y, 1e-9)
+	assert.InDelta(t, 8, cmds[2].W, 1e-9)
+
+	// the edges of the rect are split so they bend
+	points := cmds[3].Points
+	assert.Len(t, points, 2*(800+600)/maxSegment)
+	assert.InDelta(t, 80, points[0].X, 1e-9)
+	top := points[800/maxSegment/2]
+	assert.Equal(t, 400.0, top.X)
+	assert.InDelta(t, 30, top.Y, 1e-9)
+
+	// the transforms are applied here, not on the renderer below
+	assert.Equal(t, 400.0, cmds[4].X)
+	assert.Equal(t, 300.0, cmds[4].Y)
+	assert.Equal(t, 20.0, cmds[4].TextStyle.Font.Size)
+}
+
+func TestGlow(t *testing.T) {
+	b := record.NewCommandBuffer(nil)
+	r := New(b, 800, 600, Options{Glow: 0.5})
+	r.SetEnabled(true)
+
+	r.BeginLayer(ports.LayerEntities, nil)
+	r.SetAlpha(0.5)
+	r.DrawCircle(400, 300, 10, ports.Style{Fill: white})
+	r.DrawLine(0, 0, 10, 0, ports.Style{Stroke: white})
+
+	cmds := b.Commands()
+	// a line without width has no halo
+	require.Equal(t, []record.Op{record.OpAlpha, record.OpCircle, record.OpAlpha, record.OpCircle, record.OpLine}, ops(cmds))
+	assert.Equal(t, 0.25, cmds[0].X)
+	assert.Equal(t, 10.0+GlowSpread, cmds[1].W)
+	assert.Equal(t, 0.5, cmds[2].X)
+	assert.Equal(t, 10.0, cmds[3].W)
+}
+
+func TestEndFrame(t *testing.T) {
+	b := record.NewCommandBuffer(nil)
+	r := New(b, 800, 600, DefaultOptions())
+	r.SetEnabled(true)
+	r.EndFrame()
+
+	cmds := b.Commands()
+	gaps := 600 / ScanlinePeriod
+	want := []record.Op{record.OpAlpha}
+	for range gaps {
+		want = append(want, record.OpRect)
+	}
+	want = append(want, record.OpAlpha, record.OpPolygon, record.OpPolygon, record.OpPolygon, record.OpPolygon)
+	require.Equal(t, want, ops(cmds))
+
+	// a dark rect over the lower half of every scanline
+	assert.Equal(t, DefaultOptions().Scanlines, cmds[0].X)
+	gap := cmds[1]
+	assert.Equal(t, []float64{0, ScanlinePeriod / 2, 800, ScanlinePeriod / 2}, []float64{gap.X, gap.Y, gap.W, gap.H})
+	assert.Equal(t, ports.RGB(0, 0, 0), gap.Style.Fill)
+	assert.Equal(t, 600-ScanlinePeriod/2.0, cmds[gaps].Y)
+	assert.Equal(t, 1.0, cmds[gaps+1].X)
+
+	// the bezel starts at the corners of the court and ends at the bent edge
+	top := cmds[gaps+2].Points
+	assert.Equal(t, ports.Point{}, top[0])
+	assert.Equal(t, ports.Point{X: 800}, top[1])
+	last := top[len(top)-1]
+	assert.Greater(t, last.X, 0.0)
+	assert.Greater(t, last.Y, 0.0)
+}
+
+func TestEndFrameResetsAlpha(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "Scanlines without curvature", opts: Options{Scanlines: 0.5}},
+		{name: "Glow only", opts: Options{Glow: 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := record.NewCommandBuffer(nil)
+			r := New(b, 800, 600, tt.opts)
+			r.SetEnabled(true)
+			frame(r, 100)
+
+			alpha := 1.0
+			for _, c := range b.Commands() {
+				if c.Op == record.OpAlpha {
+					alpha = c.X
+				}
+			}
+			assert.Equal(t, 1.0, alpha, "alpha left on the renderer below")
+		})
+	}
+}
+
+func TestEndFrameDisabled(t *testing.T) {
+	b := record.NewCommandBuffer(nil)
+	New(b, 800, 600, DefaultOptions()).EndFrame()
+	assert.Empty(t, b.Commands())
+}
+
+type shader struct {
+	*record.CommandBuffer
+	opts []Options
+}
+
+func (s *shader) SetCRT(opts Options) {
+	s.opts = append(s.opts, opts)
+}
+
+func TestShader(t *testing.T) {
+	s := &shader{CommandBuffer: record.NewCommandBuffer(nil)}
+	r := New(s, 800, 600, DefaultOptions())
+
+	r.SetEnabled(true)
+	r.SetEnabled(true)
+	frame(r, 100)
+	frame(r, 200)
+	r.SetEnabled(false)
+	assert.Equal(t, []Options{DefaultOptions(), {}}, s.opts)
+
+	// the shapes are drawn as they are, with their afterimages
+	got := circles(s.Commands())
+	require.Len(t, got, 3)
+	assert.Equal(t, 10.0, got[2].W)
+	for _, c := range s.Commands() {
+		assert.NotEqual(t, record.OpPolygon, c.Op)
+		assert.NotEqual(t, record.OpImage, c.Op)
+	}
+}
+
+// layered keeps the background key, like web.Layers.
+type layered struct {
+	*record.CommandBuffer
+	key any
+}
+
+func (l *layered) BeginLayer(layer ports.Layer, key any) bool {
+	if layer != ports.LayerBackground || key == nil {
+		return true
+	}
+	if key == l.key {
+		return false
+	}
+
+	l.key = key
+	return true
+}
+
+func TestLayeredKeys(t *testing.T) {
+	l := &layered{CommandBuffer: record.NewCommandBuffer(nil)}
+	r := New(l, 800, 600, DefaultOptions())
+
+	clears := func() int {
+		n := 0
+		for _, c := range l.Commands() {
+			if c.Op == record.OpClear {
+				n++
+			}
+		}
+		return n
+	}
+
+	frame(r, 100)
+	frame(r, 100)
+	assert.Equal(t, 1, clears())
+
+	// the background is drawn again in the other look
+	r.SetEnabled(true)
+	frame(r, 100)
+	assert.Equal(t, 2, clears())
+	frame(r, 100)
+	assert.Equal(t, 2, clears())
+}
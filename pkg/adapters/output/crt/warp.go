@@ -0,0 +1,204 @@
+package crt
+
+import (
+	"image"
+	"math"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/transform"
+)
+
+// Curved edges are split about every maxSegment court units.
+const maxSegment = 50
+
+// glowWidth is the width of the halo stroked around the shapes.
+const glowWidth = 2 * GlowSpread
+
+// warp bends the court like the glass of a CRT and adds a glow around the
+// shapes, with nothing but the primitives of the renderer below: it keeps the
+// transforms itself and draws every shape in court units, so a rect becomes a
+// polygon whose edges bend, and the court transform of the renderer below is
+// never changed.
+type warp struct {
+	transform.State
+
+	inner  ports.Renderer
+	cx, cy float64
+	curve  float64
+	glow   float64
+	sent   float64 // alpha last set on inner; NaN when unknown
+	poly   []ports.Point
+}
+
+func newWarp(inner ports.Renderer, w, h float64) *warp {
+	wp := &warp{inner: inner, cx: w / 2, cy: h / 2, sent: math.NaN()}
+	wp.Reset(transform.Identity)
+	return wp
+}
+
+// point maps x, y to the screen: through the transform, then pulled toward
+// the center by the curvature, more the further from the center it is.
+func (w *warp) point(x, y float64) ports.Point {
+	return w.bend(w.Matrix().Apply(x, y))
+}
+
+// bend pulls p, on the screen, toward the center.
+func (w *warp) bend(p ports.Point) ports.Point {
+	f := w.factor(p)
+	return ports.Point{X: w.cx + (p.X-w.cx)*f, Y: w.cy + (p.Y-w.cy)*f}
+}
+
+// factor is how much the curvature shrinks the shapes around p, on the screen.
+func (w *warp) factor(p ports.Point) float64 {
+	u, v := (p.X-w.cx)/w.cx, (p.Y-w.cy)/w.cy
+	return 1 - w.curve*(u*u+v*v)
+}
+
+// edge appends the points of the edge from a to b to path, without b, split
+// so that it bends with the screen.
+func (w *warp) edge(path []ports.Point, a, b ports.Point) []ports.Point {
+	n := 1
+	if w.curve > 0 {
+		pa, pb := w.Matrix().Apply(a.X, a.Y), w.Matrix().Apply(b.X, b.Y)
+		n = max(1, int(math.Ceil(math.Hypot(pb.X-pa.X, pb.Y-pa.Y)/maxSegment)))
+	}
+
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n)
+		path = append(path, w.point(a.X+(b.X-a.X)*t, a.Y+(b.Y-a.Y)*t))
+	}
+
+	return path
+}
+
+// invalidate forgets the alpha of inner, e.g. when it moves to another layer.
+func (w *warp) invalidate() {
+	w.sent = math.NaN()
+}
+
+// setAlpha sets the alpha of inner when it changed.
+func (w *warp) setAlpha(alpha float64) {
+	if alpha != w.sent {
+		w.inner.SetAlpha(alpha)
+		w.sent = alpha
+	}
+}
+
+// style scales the line width to court units.
+func (w *warp) style(style ports.Style) ports.Style {
+	style.LineWidth *= w.Matrix().Scale()
+	return style
+}
+
+// glowColor is the color of the halo of a shape: its fill, else its outline.
+func glowColor(style ports.Style) (ports.Color, bool) {
+	if style.Fill.A > 0 {
+		return style.Fill, true
+	}
+	if style.Stroke.A > 0 && style.LineWidth > 0 {
+		return style.Stroke, true
+	}
+
+	return ports.Color{}, false
+}
+
+func (w *warp) Clear(c ports.Color) {
+	w.inner.Clear(c)
+	w.Reset(transform.Identity)
+	w.invalidate()
+}
+
+func (w *warp) DrawRect(x, y, width, height float64, style ports.Style) {
+	w.poly = w.poly[:0]
+	corners := [5]ports.Point{{X: x, Y: y}, {X: x + width, Y: y}, {X: x + width, Y: y + height}, {X: x, Y: y + height}, {X: x, Y: y}}
+	for i := 0; i < 4; i++ {
+		w.poly = w.edge(w.poly, corners[i], corners[i+1])
+	}
+	w.drawPolygon(w.poly, w.style(style))
+}
+
+func (w *warp) DrawPolygon(points []ports.Point, style ports.Style) {
+	if len(points) < 2 {
+		return
+	}
+
+	w.poly = w.poly[:0]
+	for i, p := range points {
+		w.poly = w.edge(w.poly, p, points[(i+1)%len(points)])
+	}
+	w.drawPolygon(w.poly, w.style(style))
+}
+
+func (w *warp) drawPolygon(poly []ports.Point, style ports.Style) {
+	if c, ok := glowColor(style); ok && w.glow > 0 {
+		w.setAlpha(w.Alpha() * w.glow)
+		w.inner.DrawPolygon(poly, ports.Style{Stroke: c, LineWidth: style.LineWidth + glowWidth})
+	}
+
+	w.setAlpha(w.Alpha())
+	w.inner.DrawPolygon(poly, style)
+}
+
+func (w *warp) DrawCircle(x, y, radius float64, style ports.Style) {
+	p := w.Matrix().Apply(x, y)
+	center := w.bend(p)
+	radius *= w.Matrix().Scale() * w.factor(p)
+	style = w.style(style)
+
+	if c, ok := glowColor(style); ok && w.glow > 0 {
+		w.setAlpha(w.Alpha() * w.glow)
+		w.inner.DrawCircle(center.X, center.Y, radius+GlowSpread, ports.Style{Fill: c})
+	}
+
+	w.setAlpha(w.Alpha())
+	w.inner.DrawCircle(center.X, center.Y, radius, style)
+}
+
+func (w *warp) DrawLine(x1, y1, x2, y2 float64, style ports.Style) {
+	a, b := ports.Point{X: x1, Y: y1}, ports.Point{X: x2, Y: y2}
+	w.poly = append(w.edge(w.poly[:0], a, b), w.point(x2, y2))
+	style = w.style(style)
+
+	if style.Stroke.A > 0 && style.LineWidth > 0 && w.glow > 0 {
+		w.setAlpha(w.Alpha() * w.glow)
+		w.polyline(w.poly, ports.Style{Stroke: style.Stroke, LineWidth: style.LineWidth + glowWidth})
+	}
+
+	w.setAlpha(w.Alpha())
+	w.polyline(w.poly, style)
+}
+
+func (w *warp) polyline(points []ports.Point, style ports.Style) {
+	for i := 1; i < len(points); i++ {
+		w.inner.DrawLine(points[i-1].X, points[i-1].Y, points[i].X, points[i].Y, style)
+	}
+}
+
+// DrawImage draws img over the box of its bent corners.
+func (w *warp) DrawImage(img image.Image, x, y, width, height float64) {
+	lo := ports.Point{X: math.Inf(1), Y: math.Inf(1)}
+	hi := ports.Point{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, p := range [4]ports.Point{w.point(x, y), w.point(x+width, y), w.point(x+width, y+height), w.point(x, y+height)} {
+		lo.X, lo.Y = math.Min(lo.X, p.X), math.Min(lo.Y, p.Y)
+		hi.X, hi.Y = math.Max(hi.X, p.X), math.Max(hi.Y, p.Y)
+	}
+
+	w.setAlpha(w.Alpha())
+	w.inner.DrawImage(img, lo.X, lo.Y, hi.X-lo.X, hi.Y-lo.Y)
+}
+
+// DrawText moves the text with the screen and scales its font with the
+// transform; the text itself is not bent, nor rotated.
+func (w *warp) DrawText(text string, x, y float64, style ports.TextStyle) {
+	p := w.point(x, y)
+	style.Font.Size *= w.Matrix().Scale()
+
+	w.setAlpha(w.Alpha())
+	w.inner.DrawText(text, p.X, p.Y, style)
+}
+
+func (w *warp) MeasureText(text string, font ports.Font) float64 {
+	return w.inner.MeasureText(text, font)
+}
+
+var _ ports.Renderer = (*warp)(nil)
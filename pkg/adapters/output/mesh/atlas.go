@@ -0,0 +1,82 @@
+package mesh
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+)
+
+// atlasColumns is the number of cells in a row of the atlas.
+const atlasColumns = 16
+
+// uvRect is a rectangle of the atlas in texture coordinates.
+type uvRect struct {
+	u0, v0, u1, v1 float32
+}
+
+// fontAtlas is the texture of the shapes and of the text: a white texel that
+// shapes sample, then a cell per rune of the bundled font with one texel per
+// dot, white where the dots are and transparent elsewhere. Cells are one texel
+// apart so that neighbors never bleed into a glyph.
+type fontAtlas struct {
+	img   *image.NRGBA
+	solid uvRect
+	cells map[rune]uvRect
+	blank map[rune]bool
+}
+
+var atlas = newAtlas()
+
+// Atlas is the texture the triangles of a Span without an image sample.
+func Atlas() *image.NRGBA {
+	return atlas.img
+}
+
+func newAtlas() *fontAtlas {
+	cell := raster.GlyphCell
+	runes := raster.Runes()
+	cellW, cellH := cell.Dx()+1, cell.Dy()+1
+	rows := (len(runes) + 1 + atlasColumns - 1) / atlasColumns
+
+	a := &fontAtlas{
+		img:   image.NewNRGBA(image.Rect(0, 0, atlasColumns*cellW, rows*cellH)),
+		cells: make(map[rune]uvRect, len(runes)),
+		blank: make(map[rune]bool),
+	}
+	size := a.img.Bounds().Size()
+	// the first cell holds the white texel; shapes sample its center
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	a.img.SetNRGBA(0, 0, white)
+	u, v := 0.5/float32(size.X), 0.5/float32(size.Y)
+	a.solid = uvRect{u0: u, v0: v, u1: u, v1: v}
+
+	for i, ch := range runes {
+		x, y := (i+1)%atlasColumns*cellW, (i+1)/atlasColumns*cellH
+		dots := 0
+		raster.EachDot(ch, func(col, row int) {
+			a.img.SetNRGBA(x+col, y+row-cell.Min.Y, white)
+			dots++
+		})
+
+		a.cells[ch] = uvRect{
+			u0: float32(x) / float32(size.X), v0: float32(y) / float32(size.Y),
+			u1: float32(x+cell.Dx()) / float32(size.X), v1: float32(y+cell.Dy()) / float32(size.Y),
+		}
+		a.blank[ch] = dots == 0
+	}
+
+	return a
+}
+
+// glyph is the cell of ch, or of '?' when the font does not have it, and
+// whether it has no dots.
+func (a *fontAtlas) glyph(ch rune) (uvRect, bool) {
+	uv, ok := a.cells[ch]
+	if !ok {
+		ch = '?'
+		uv = a.cells[ch]
+	}
+
+	return uv, a.blank[ch]
+}
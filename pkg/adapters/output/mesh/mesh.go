@@ -0,0 +1,360 @@
+package mesh
+
+import (
+	"image"
+	"math"
+	"reflect"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+	"github.com/psaraiva/squash/pkg/adapters/output/transform"
+)
+
+// Stride is the number of float32 of a vertex: x and y in pixels, u and v in
+// the texture, then red, green, blue and alpha in 0..1, not premultiplied.
+const Stride = 8
+
+// Circles get a segment about every 3 pixels of their outline.
+const (
+	minSegments = 12
+	maxSegments = 96
+	segmentLen  = 3
+)
+
+// miterLimit caps the corners of thin angles, as the canvas default does.
+const miterLimit = 10
+
+type rgba [4]float32
+
+// Span is a run of triangles drawn with one texture: Image, or the Atlas when
+// Image is nil.
+type Span struct {
+	Image image.Image
+	First int // first vertex
+	Count int // vertices
+}
+
+// Mesh records a frame as triangles, in pixels of a width x height target that
+// shows the logical court (w x h units). Shapes sample the white texel of the
+// Atlas and text the cells of the bundled bitmap font, so a frame without
+// images is a single draw call. Polygons are filled as fans and must be convex.
+type Mesh struct {
+	w, h          float64
+	width, height int
+
+	transform.State
+
+	verts      []float32
+	spans      []Span
+	background ports.Color
+	cleared    bool
+
+	outer, inner []ports.Point
+}
+
+// NewMesh records frames of the court for a width x height target.
+func NewMesh(w, h float64, width, height int) *Mesh {
+	m := &Mesh{w: w, h: h}
+	m.Resize(width, height)
+	return m
+}
+
+// Resize changes the target; the transform is reset on the next Clear.
+func (m *Mesh) Resize(width, height int) {
+	m.width, m.height = max(width, 1), max(height, 1)
+	m.reset()
+}
+
+// Size is the target size in pixels.
+func (m *Mesh) Size() (int, int) {
+	return m.width, m.height
+}
+
+// Vertices are the triangles recorded since the last Clear or Reset, Stride
+// float32 per vertex.
+func (m *Mesh) Vertices() []float32 {
+	return m.verts
+}
+
+// Spans splits Vertices by texture, in drawing order.
+func (m *Mesh) Spans() []Span {
+	n := len(m.verts) / Stride
+	for i := len(m.spans) - 1; i >= 0; i-- {
+		m.spans[i].Count = n - m.spans[i].First
+		n = m.spans[i].First
+	}
+
+	return m.spans
+}
+
+// Background is the color of the frame's Clear, if it had one.
+func (m *Mesh) Background() (ports.Color, bool) {
+	return m.background, m.cleared
+}
+
+// Reset drops the recorded frame; the transform and the alpha are kept, as on a canvas.
+func (m *Mesh) Reset() {
+	m.verts = m.verts[:0]
+	m.spans = m.spans[:0]
+	m.cleared = false
+}
+
+// Clear starts a frame: it drops what was drawn, resets the court transform
+// and records the background.
+func (m *Mesh) Clear(c ports.Color) {
+	m.Reset()
+	m.reset()
+	m.background, m.cleared = c, true
+}
+
+func (m *Mesh) reset() {
+	m.State.Reset(transform.Matrix{A: float64(m.width) / m.w, D: float64(m.height) / m.h})
+}
+
+func (m *Mesh) DrawRect(x, y, w, h float64, style ports.Style) {
+	corners := [4]ports.Point{{X: x, Y: y}, {X: x + w, Y: y}, {X: x + w, Y: y + h}, {X: x, Y: y + h}}
+	m.paint(corners[:], style)
+}
+
+func (m *Mesh) DrawPolygon(points []ports.Point, style ports.Style) {
+	if len(points) < 2 {
+		return
+	}
+
+	m.paint(points, style)
+}
+
+// DrawCircle draws an ellipse when the target is not scaled evenly on both axes.
+func (m *Mesh) DrawCircle(x, y, radius float64, style ports.Style) {
+	if radius <= 0 {
+		return
+	}
+
+	n := int(math.Ceil(2 * math.Pi * radius * m.pixelSize() / segmentLen))
+	n = max(minSegments, min(n, maxSegments))
+
+	if fill, ok := m.color(style.Fill); ok {
+		m.use(nil)
+		center := m.Matrix().Apply(x, y)
+		prev := m.Matrix().Apply(x+radius, y)
+		for i := 1; i <= n; i++ {
+			sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+			p := m.Matrix().Apply(x+radius*cos, y+radius*sin)
+			m.triangle(center, prev, p, fill)
+			prev = p
+		}
+	}
+
+	if stroke, ok := m.stroke(style); ok {
+		hw := m.halfWidth(style.LineWidth)
+		m.outer, m.inner = m.outer[:0], m.inner[:0]
+		for i := 0; i < n; i++ {
+			sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+			m.outer = append(m.outer, m.Matrix().Apply(x+(radius+hw)*cos, y+(radius+hw)*sin))
+			m.inner = append(m.inner, m.Matrix().Apply(x+max(radius-hw, 0)*cos, y+max(radius-hw, 0)*sin))
+		}
+		m.ring(stroke)
+	}
+}
+
+func (m *Mesh) DrawLine(x1, y1, x2, y2 float64, style ports.Style) {
+	stroke, ok := m.stroke(style)
+	length := math.Hypot(x2-x1, y2-y1)
+	if !ok || length == 0 {
+		return
+	}
+
+	hw := m.halfWidth(style.LineWidth)
+	nx, ny := -(y2-y1)/length*hw, (x2-x1)/length*hw
+	m.use(nil)
+	m.quad([4]ports.Point{
+		m.Matrix().Apply(x1+nx, y1+ny), m.Matrix().Apply(x2+nx, y2+ny),
+		m.Matrix().Apply(x2-nx, y2-ny), m.Matrix().Apply(x1-nx, y1-ny),
+	}, atlas.solid, stroke)
+}
+
+// DrawImage draws img with a texture of its own, which starts a new Span.
+func (m *Mesh) DrawImage(img image.Image, x, y, w, h float64) {
+	white, ok := m.color(ports.RGB(255, 255, 255))
+	if !ok || w == 0 || h == 0 {
+		return
+	}
+
+	m.use(img)
+	m.quad([4]ports.Point{
+		m.Matrix().Apply(x, y), m.Matrix().Apply(x+w, y),
+		m.Matrix().Apply(x+w, y+h), m.Matrix().Apply(x, y+h),
+	}, uvRect{u0: 0, v0: 0, u1: 1, v1: 1}, white)
+}
+
+// DrawText draws a quad per rune, textured with its cell of the Atlas, so the
+// text looks as in the raster renderer.
+func (m *Mesh) DrawText(text string, x, y float64, style ports.TextStyle) {
+	c, ok := m.color(style.Color)
+	if !ok {
+		return
+	}
+
+	switch style.Align {
+	case ports.AlignCenter:
+		x -= m.MeasureText(text, style.Font) / 2
+	case ports.AlignRight:
+		x -= m.MeasureText(text, style.Font)
+	}
+
+	m.use(nil)
+	dot := raster.DotSize(style.Font)
+	cell := raster.GlyphCell
+	top, bottom := y+float64(cell.Min.Y)*dot, y+float64(cell.Max.Y)*dot
+	for _, ch := range text {
+		left, right := x+float64(cell.Min.X)*dot, x+float64(cell.Max.X)*dot
+		x += float64(cell.Dx()) * dot
+
+		uv, blank := atlas.glyph(ch)
+		if blank {
+			continue
+		}
+		m.quad([4]ports.Point{
+			m.Matrix().Apply(left, top), m.Matrix().Apply(right, top),
+			m.Matrix().Apply(right, bottom), m.Matrix().Apply(left, bottom),
+		}, uv, c)
+	}
+}
+
+// MeasureText is the advance of the bundled font.
+func (m *Mesh) MeasureText(text string, font ports.Font) float64 {
+	return raster.TextWidth(text, font)
+}
+
+// paint fills the convex polygon points as a fan and strokes its outline.
+func (m *Mesh) paint(points []ports.Point, style ports.Style) {
+	if fill, ok := m.color(style.Fill); ok && len(points) > 2 {
+		m.use(nil)
+		first := m.Matrix().Apply(points[0].X, points[0].Y)
+		prev := m.Matrix().Apply(points[1].X, points[1].Y)
+		for _, p := range points[2:] {
+			next := m.Matrix().Apply(p.X, p.Y)
+			m.triangle(first, prev, next, fill)
+			prev = next
+		}
+	}
+
+	if stroke, ok := m.stroke(style); ok {
+		m.outline(points, m.halfWidth(style.LineWidth))
+		m.ring(stroke)
+	}
+}
+
+// outline offsets every corner of the closed path points by hw on both sides,
+// along the bisector of its edges, so the stroke has mitered corners.
+func (m *Mesh) outline(points []ports.Point, hw float64) {
+	m.outer, m.inner = m.outer[:0], m.inner[:0]
+	n := len(points)
+	for i, p := range points {
+		n0 := normal(points[(i+n-1)%n], p)
+		n1 := normal(p, points[(i+1)%n])
+
+		mx, my := n0.X+n1.X, n0.Y+n1.Y
+		length := math.Hypot(mx, my)
+		offset := hw
+		if length > 1e-9 {
+			mx, my = mx/length, my/length
+			offset = hw / math.Max(mx*n0.X+my*n0.Y, 1.0/miterLimit)
+		} else {
+			mx, my = n0.X, n0.Y
+		}
+
+		m.outer = append(m.outer, m.Matrix().Apply(p.X+mx*offset, p.Y+my*offset))
+		m.inner = append(m.inner, m.Matrix().Apply(p.X-mx*offset, p.Y-my*offset))
+	}
+}
+
+// ring fills the band between the closed paths m.outer and m.inner.
+func (m *Mesh) ring(c rgba) {
+	m.use(nil)
+	n := len(m.outer)
+	for i := range m.outer {
+		j := (i + 1) % n
+		m.quad([4]ports.Point{m.outer[i], m.outer[j], m.inner[j], m.inner[i]}, atlas.solid, c)
+	}
+}
+
+// normal is the unit normal of the edge from a to b, or zero when it is empty.
+func normal(a, b ports.Point) ports.Point {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return ports.Point{}
+	}
+
+	return ports.Point{X: -dy / length, Y: dx / length}
+}
+
+// pixelSize is the number of pixels of a court unit with the current transform.
+func (m *Mesh) pixelSize() float64 {
+	return m.Matrix().Scale()
+}
+
+// halfWidth is half of a line width, in court units; lines are at least a
+// pixel wide, as the canvas shows hairlines.
+func (m *Mesh) halfWidth(w float64) float64 {
+	px := m.pixelSize()
+	if px == 0 {
+		return 0
+	}
+
+	return math.Max(w, 1/px) / 2
+}
+
+// color converts c with the current alpha; it reports false for an invisible color.
+func (m *Mesh) color(c ports.Color) (rgba, bool) {
+	a := float32(c.A) / 255 * float32(m.Alpha())
+	return rgba{float32(c.R) / 255, float32(c.G) / 255, float32(c.B) / 255, a}, a > 0
+}
+
+// stroke is the color of the outline; as on the canvas, a line without width
+// is not drawn.
+func (m *Mesh) stroke(style ports.Style) (rgba, bool) {
+	c, ok := m.color(style.Stroke)
+	return c, ok && style.LineWidth > 0
+}
+
+// use starts a Span when the next triangles sample another texture.
+func (m *Mesh) use(img image.Image) {
+	if n := len(m.spans); n > 0 && sameImage(m.spans[n-1].Image, img) {
+		return
+	}
+
+	m.spans = append(m.spans, Span{Image: img, First: len(m.verts) / Stride})
+}
+
+// sameImage tells images apart by identity. Images of a type that cannot be
+// compared, which would panic with ==, are never the same.
+func sameImage(a, b image.Image) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && (t == nil || t.Comparable()) && a == b
+}
+
+func (m *Mesh) triangle(a, b, c ports.Point, col rgba) {
+	s := atlas.solid
+	m.vertex(a, s.u0, s.v0, col)
+	m.vertex(b, s.u0, s.v0, col)
+	m.vertex(c, s.u0, s.v0, col)
+}
+
+// quad draws the corners p clockwise from the top-left of the texture rectangle uv.
+func (m *Mesh) quad(p [4]ports.Point, uv uvRect, c rgba) {
+	m.vertex(p[0], uv.u0, uv.v0, c)
+	m.vertex(p[1], uv.u1, uv.v0, c)
+	m.vertex(p[2], uv.u1, uv.v1, c)
+
+	m.vertex(p[0], uv.u0, uv.v0, c)
+	m.vertex(p[2], uv.u1, uv.v1, c)
+	m.vertex(p[3], uv.u0, uv.v1, c)
+}
+
+func (m *Mesh) vertex(p ports.Point, u, v float32, c rgba) {
+	m.verts = append(m.verts, float32(p.X), float32(p.Y), u, v, c[0], c[1], c[2], c[3])
+}
+
+var _ ports.Renderer = (*Mesh)(nil)
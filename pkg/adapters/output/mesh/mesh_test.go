@@ -0,0 +1,360 @@
+package mesh
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+)
+
+var (
+	white = ports.RGB(255, 255, 255)
+	red   = ports.RGB(255, 0, 0)
+)
+
+// newTestMesh maps the 800x600 court on a 1600x1200 target.
+func newTestMesh() *Mesh {
+	m := NewMesh(800, 600, 1600, 1200)
+	m.Clear(ports.RGB(0, 0, 0))
+	return m
+}
+
+// positions are the x, y of every vertex.
+func positions(m *Mesh) []ports.Point {
+	var points []ports.Point
+	verts := m.Vertices()
+	for i := 0; i < len(verts); i += Stride {
+		points = append(points, ports.Point{X: float64(verts[i]), Y: float64(verts[i+1])})
+	}
+
+	return points
+}
+
+// bounds is the bounding box of the vertices.
+func bounds(m *Mesh) (ports.Point, ports.Point) {
+	lo, hi := ports.Point{X: math.Inf(1), Y: math.Inf(1)}, ports.Point{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, p := range positions(m) {
+		lo.X, lo.Y = math.Min(lo.X, p.X), math.Min(lo.Y, p.Y)
+		hi.X, hi.Y = math.Max(hi.X, p.X), math.Max(hi.Y, p.Y)
+	}
+
+	return lo, hi
+}
+
+func near(a, b ports.Point) bool {
+	return math.Abs(a.X-b.X) < 1e-3 && math.Abs(a.Y-b.Y) < 1e-3
+}
+
+func TestMeshShapes(t *testing.T) {
+	tests := []struct {
+		name      string
+		draw      func(m *Mesh)
+		wantVerts int
+		wantLo    ports.Point
+		wantHi    ports.Point
+	}{
+		{
+			name:      "Filled rect is a quad",
+			draw:      func(m *Mesh) { m.DrawRect(10, 20, 30, 40, ports.Style{Fill: white}) },
+			wantVerts: 6,
+			wantLo:    ports.Point{X: 20, Y: 40},
+			wantHi:    ports.Point{X: 80, Y: 120},
+		},
+		{
+			name:      "Stroked rect is a mitered ring",
+			draw:      func(m *Mesh) { m.DrawRect(10, 20, 30, 40, ports.Style{Stroke: white, LineWidth: 4}) },
+			wantVerts: 24,
+			wantLo:    ports.Point{X: 16, Y: 36},
+			wantHi:    ports.Point{X: 84, Y: 124},
+		},
+		{
+			name:      "Filled and stroked rect",
+			draw:      func(m *Mesh) { m.DrawRect(10, 20, 30, 40, ports.Style{Fill: red, Stroke: white, LineWidth: 4}) },
+			wantVerts: 30,
+			wantLo:    ports.Point{X: 16, Y: 36},
+			wantHi:    ports.Point{X: 84, Y: 124},
+		},
+		{
+			name:      "Hairline stroke is a pixel wide",
+			draw:      func(m *Mesh) { m.DrawRect(10, 20, 30, 40, ports.Style{Stroke: white, LineWidth: 0.1}) },
+			wantVerts: 24,
+			wantLo:    ports.Point{X: 19.5, Y: 39.5},
+			wantHi:    ports.Point{X: 80.5, Y: 120.5},
+		},
+		{
+			name: "Stroke without width draws nothing",
+			draw: func(m *Mesh) {
+				m.DrawRect(10, 20, 30, 40, ports.Style{Stroke: white})
+				m.DrawCircle(10, 20, 30, ports.Style{Stroke: white})
+				m.DrawLine(10, 20, 30, 40, ports.Style{Stroke: white})
+			},
+		},
+		{
+			name:      "Small circle has the fewest segments",
+			draw:      func(m *Mesh) { m.DrawCircle(100, 100, 1, ports.Style{Fill: white}) },
+			wantVerts: 3 * minSegments,
+			wantLo:    ports.Point{X: 198, Y: 198},
+			wantHi:    ports.Point{X: 202, Y: 202},
+		},
+		{
+			name:      "Large circle has the most segments",
+			draw:      func(m *Mesh) { m.DrawCircle(400, 300, 250, ports.Style{Fill: white}) },
+			wantVerts: 3 * maxSegments,
+			wantLo:    ports.Point{X: 300, Y: 100},
+			wantHi:    ports.Point{X: 1300, Y: 1100},
+		},
+		{
+			name:      "Line is a quad as wide as the line",
+			draw:      func(m *Mesh) { m.DrawLine(0, 300, 800, 300, ports.Style{Stroke: white, LineWidth: 2}) },
+			wantVerts: 6,
+			wantLo:    ports.Point{X: 0, Y: 598},
+			wantHi:    ports.Point{X: 1600, Y: 602},
+		},
+		{
+			name:      "Thin line is a pixel wide",
+			draw:      func(m *Mesh) { m.DrawLine(0, 300, 800, 300, ports.Style{Stroke: white, LineWidth: 0.25}) },
+			wantVerts: 6,
+			wantLo:    ports.Point{X: 0, Y: 599.5},
+			wantHi:    ports.Point{X: 1600, Y: 600.5},
+		},
+		{
+			name: "Polygon is filled as a fan",
+			draw: func(m *Mesh) {
+				m.DrawPolygon([]ports.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 15, Y: 10}, {X: 5, Y: 15}, {X: 0, Y: 10}}, ports.Style{Fill: white})
+			},
+			wantVerts: 9,
+			wantLo:    ports.Point{X: 0, Y: 0},
+			wantHi:    ports.Point{X: 30, Y: 30},
+		},
+		{
+			name: "Transforms apply to the vertices",
+			draw: func(m *Mesh) {
+				m.Save()
+				m.Translate(100, 50)
+				m.Scale(2, 2)
+				m.DrawRect(0, 0, 10, 10, ports.Style{Fill: white})
+				m.Restore()
+				m.DrawRect(0, 0, 1, 1, ports.Style{Fill: white})
+			},
+			wantVerts: 12,
+			wantLo:    ports.Point{X: 0, Y: 0},
+			wantHi:    ports.Point{X: 240, Y: 140},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMesh()
+			tt.draw(m)
+
+			if got := len(m.Vertices()) / Stride; got != tt.wantVerts {
+				t.Fatalf("vertices = %d, want %d", got, tt.wantVerts)
+			}
+			if tt.wantVerts == 0 {
+				return
+			}
+			if lo, hi := bounds(m); !near(lo, tt.wantLo) || !near(hi, tt.wantHi) {
+				t.Errorf("bounds = %v..%v, want %v..%v", lo, hi, tt.wantLo, tt.wantHi)
+			}
+		})
+	}
+}
+
+func TestMeshColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		alpha     float64
+		color     ports.Color
+		wantVerts int
+		want      rgba
+	}{
+		{name: "Opaque", alpha: 1, color: red, wantVerts: 6, want: rgba{1, 0, 0, 1}},
+		{name: "Global alpha", alpha: 0.5, color: red, wantVerts: 6, want: rgba{1, 0, 0, 0.5}},
+		{name: "Translucent color", alpha: 0.5, color: ports.Color{R: 255, A: 51}, wantVerts: 6, want: rgba{1, 0, 0, 0.1}},
+		{name: "Zero alpha draws nothing", alpha: 0, color: red},
+		{name: "Transparent color draws nothing", alpha: 1, color: ports.Color{R: 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMesh()
+			m.SetAlpha(tt.alpha)
+			m.DrawRect(0, 0, 10, 10, ports.Style{Fill: tt.color})
+
+			verts := m.Vertices()
+			if len(verts)/Stride != tt.wantVerts {
+				t.Fatalf("vertices = %d, want %d", len(verts)/Stride, tt.wantVerts)
+			}
+			for i := 0; i < len(verts); i += Stride {
+				got := rgba{verts[i+4], verts[i+5], verts[i+6], verts[i+7]}
+				for c := range got {
+					if math.Abs(float64(got[c]-tt.want[c])) > 1e-6 {
+						t.Fatalf("vertex %d color = %v, want %v", i/Stride, got, tt.want)
+					}
+				}
+				if u, v := verts[i+2], verts[i+3]; u != atlas.solid.u0 || v != atlas.solid.v0 {
+					t.Fatalf("vertex %d samples %v,%v, want the white texel", i/Stride, u, v)
+				}
+			}
+		})
+	}
+}
+
+func TestMeshClear(t *testing.T) {
+	m := NewMesh(800, 600, 800, 600)
+	if _, ok := m.Background(); ok {
+		t.Error("a new mesh has a background")
+	}
+
+	m.Translate(50, 50)
+	m.SetAlpha(0.5)
+	m.DrawRect(0, 0, 10, 10, ports.Style{Fill: white})
+	m.Clear(red)
+
+	if got, ok := m.Background(); !ok || got != red {
+		t.Errorf("Background() = %v, %v, want %v, true", got, ok, red)
+	}
+	if len(m.Vertices()) != 0 || len(m.Spans()) != 0 {
+		t.Errorf("Clear kept %d vertices", len(m.Vertices())/Stride)
+	}
+
+	// the transform and the alpha are back to the court
+	m.DrawRect(0, 0, 10, 10, ports.Style{Fill: white})
+	if lo, _ := bounds(m); !near(lo, ports.Point{}) || m.Vertices()[7] != 1 {
+		t.Errorf("after Clear the rect starts at %v with alpha %v", lo, m.Vertices()[7])
+	}
+
+	m.Reset()
+	if _, ok := m.Background(); ok || len(m.Vertices()) != 0 {
+		t.Error("Reset kept the frame")
+	}
+}
+
+func TestMeshSpans(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	m := newTestMesh()
+	m.DrawRect(0, 0, 10, 10, ports.Style{Fill: white})
+	m.DrawText("Hi", 0, 50, ports.TextStyle{Font: ports.Font{Size: 10}, Color: white})
+	m.DrawImage(img, 20, 20, 8, 8)
+	m.DrawImage(img, 30, 20, 8, 8)
+	m.DrawCircle(50, 50, 1, ports.Style{Fill: white})
+
+	want := []Span{
+		{First: 0, Count: 18},
+		{Image: img, First: 18, Count: 12},
+		{First: 30, Count: 3 * minSegments},
+	}
+	got := m.Spans()
+	if len(got) != len(want) {
+		t.Fatalf("spans = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("span %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// sliceImage is an image of a type that cannot be compared.
+type sliceImage struct {
+	pix []color.NRGBA
+}
+
+func (sliceImage) ColorModel() color.Model   { return color.NRGBAModel }
+func (sliceImage) Bounds() image.Rectangle   { return image.Rect(0, 0, 1, 1) }
+func (s sliceImage) At(x, y int) color.Color { return s.pix[0] }
+
+func TestMeshSpansUncomparableImage(t *testing.T) {
+	img := sliceImage{pix: []color.NRGBA{{R: 255, A: 255}}}
+	m := newTestMesh()
+	m.DrawImage(img, 20, 20, 8, 8)
+	m.DrawImage(img, 30, 20, 8, 8)
+
+	// each draw gets a span of its own instead of a panic
+	if got := len(m.Spans()); got != 2 {
+		t.Errorf("spans = %d, want 2", got)
+	}
+}
+
+func TestMeshText(t *testing.T) {
+	font := ports.Font{Family: "Arial", Size: 20}
+	dot := raster.DotSize(font)
+	cell := raster.GlyphCell
+
+	tests := []struct {
+		name      string
+		text      string
+		align     ports.TextAlign
+		wantQuads int
+		wantLeft  float64 // court units
+	}{
+		{name: "Left", text: "AB", wantQuads: 2, wantLeft: 100},
+		{name: "Center", text: "AB", align: ports.AlignCenter, wantQuads: 2, wantLeft: 100 - raster.TextWidth("AB", font)/2},
+		{name: "Right", text: "AB", align: ports.AlignRight, wantQuads: 2, wantLeft: 100 - raster.TextWidth("AB", font)},
+		{name: "Spaces take no quad", text: " A ", wantQuads: 1, wantLeft: 100 + float64(cell.Dx())*dot},
+		{name: "Accents have their own cell", text: "ÇÃO", wantQuads: 3, wantLeft: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMesh(800, 600, 800, 600)
+			m.DrawText(tt.text, 100, 200, ports.TextStyle{Font: font, Color: white, Align: tt.align})
+
+			if got := len(m.Vertices()) / Stride; got != 6*tt.wantQuads {
+				t.Fatalf("vertices = %d, want %d quads", got, tt.wantQuads)
+			}
+			lo, hi := bounds(m)
+			if !near(lo, ports.Point{X: tt.wantLeft, Y: 200 + float64(cell.Min.Y)*dot}) {
+				t.Errorf("top-left = %v, want %v,%v", lo, tt.wantLeft, 200+float64(cell.Min.Y)*dot)
+			}
+			if want := 200 + float64(cell.Max.Y)*dot; math.Abs(hi.Y-want) > 1e-3 {
+				t.Errorf("bottom = %v, want %v", hi.Y, want)
+			}
+		})
+	}
+}
+
+func TestMeshTextSamplesGlyph(t *testing.T) {
+	m := NewMesh(800, 600, 800, 600)
+	m.DrawText("A€", 0, 100, ports.TextStyle{Font: ports.Font{Size: 10}, Color: white})
+
+	verts := m.Vertices()
+	for i, want := range []rune{'A', '?'} {
+		uv := atlas.cells[want]
+		v := verts[i*6*Stride:]
+		if v[2] != uv.u0 || v[3] != uv.v0 || v[2*Stride+2] != uv.u1 || v[2*Stride+3] != uv.v1 {
+			t.Errorf("quad %d samples %v,%v..%v,%v, want the cell of %q", i, v[2], v[3], v[2*Stride+2], v[2*Stride+3], want)
+		}
+	}
+}
+
+func TestAtlas(t *testing.T) {
+	img := Atlas()
+	size := img.Bounds().Size()
+	cell := raster.GlyphCell
+
+	if img.NRGBAAt(0, 0).A != 255 {
+		t.Error("the white texel is not opaque")
+	}
+
+	for _, ch := range raster.Runes() {
+		uv := atlas.cells[ch]
+		x0, y0 := int(math.Round(float64(uv.u0)*float64(size.X))), int(math.Round(float64(uv.v0)*float64(size.Y)))
+		if x1, y1 := int(math.Round(float64(uv.u1)*float64(size.X))), int(math.Round(float64(uv.v1)*float64(size.Y))); x1-x0 != cell.Dx() || y1-y0 != cell.Dy() {
+			t.Fatalf("cell of %q is %dx%d texels, want %dx%d", ch, x1-x0, y1-y0, cell.Dx(), cell.Dy())
+		}
+
+		want := map[image.Point]bool{}
+		raster.EachDot(ch, func(col, row int) { want[image.Point{X: col, Y: row - cell.Min.Y}] = true })
+		for y := 0; y < cell.Dy(); y++ {
+			for x := 0; x < cell.Dx(); x++ {
+				if got := img.NRGBAAt(x0+x, y0+y).A == 255; got != want[image.Point{X: x, Y: y}] {
+					t.Fatalf("texel %d,%d of %q = %v, want %v", x, y, ch, got, !got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package raster
+
+import (
+	"image"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// The bundled font is a classic 5x7 dot matrix on a 6-dot advance. The font
+// size is emDots tall: the capitals take the 7 dots above the baseline, accents
+// the 2 above them and the cedilla the dot below. Family and Bold are ignored.
+const (
+	emDots       = 10
+	advanceDots  = 6
+	baselineDots = 7
+)
+
+// glyphs are the printable ASCII characters from ' ', one byte per column
+// (left to right) with bit 0 on the top row.
+var glyphs = [95][5]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x00, 0x00, 0x5f, 0x00, 0x00}, // !
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // "
+	{0x14, 0x7f, 0x14, 0x7f, 0x14}, // #
+	{0x24, 0x2a, 0x7f, 0x2a, 0x12}, // $
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // %
+	{0x36, 0x49, 0x55, 0x22, 0x50}, // &
+	{0x00, 0x05, 0x03, 0x00, 0x00}, // '
+	{0x00, 0x1c, 0x22, 0x41, 0x00}, // (
+	{0x00, 0x41, 0x22, 0x1c, 0x00}, // )
+	{0x08, 0x2a, 0x1c, 0x2a, 0x08}, // *
+	{0x08, 0x08, 0x3e, 0x08, 0x08}, // +
+	{0x00, 0x50, 0x30, 0x00, 0x00}, // ,
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // -
+	{0x00, 0x60, 0x60, 0x00, 0x00}, // .
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // /
+	{0x3e, 0x51, 0x49, 0x45, 0x3e}, // 0
+	{0x00, 0x42, 0x7f, 0x40, 0x00}, // 1
+	{0x42, 0x61, 0x51, 0x49, 0x46}, // 2
+	{0x21, 0x41, 0x45, 0x4b, 0x31}, // 3
+	{0x18, 0x14, 0x12, 0x7f, 0x10}, // 4
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // 5
+	{0x3c, 0x4a, 0x49, 0x49, 0x30}, // 6
+	{0x01, 0x71, 0x09, 0x05, 0x03}, // 7
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // 8
+	{0x06, 0x49, 0x49, 0x29, 0x1e}, // 9
+	{0x00, 0x36, 0x36, 0x00, 0x00}, // :
+	{0x00, 0x56, 0x36, 0x00, 0x00}, // ;
+	{0x08, 0x14, 0x22, 0x41, 0x00}, // <
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // =
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // >
+	{0x02, 0x01, 0x51, 0x09, 0x06}, // ?
+	{0x32, 0x49, 0x79, 0x41, 0x3e}, // @
+	{0x7e, 0x11, 0x11, 0x11, 0x7e}, // A
+	{0x7f, 0x49, 0x49, 0x49, 0x36}, // B
+	{0x3e, 0x41, 0x41, 0x41, 0x22}, // C
+	{0x7f, 0x41, 0x41, 0x22, 0x1c}, // D
+	{0x7f, 0x49, 0x49, 0x49, 0x41}, // E
+	{0x7f, 0x09, 0x09, 0x09, 0x01}, // F
+	{0x3e, 0x41, 0x49, 0x49, 0x7a}, // G
+	{0x7f, 0x08, 0x08, 0x08, 0x7f}, // H
+	{0x00, 0x41, 0x7f, 0x41, 0x00}, // I
+	{0x20, 0x40, 0x41, 0x3f, 0x01}, // J
+	{0x7f, 0x08, 0x14, 0x22, 0x41}, // K
+	{0x7f, 0x40, 0x40, 0x40, 0x40}, // L
+	{0x7f, 0x02, 0x0c, 0x02, 0x7f}, // M
+	{0x7f, 0x04, 0x08, 0x10, 0x7f}, // N
+	{0x3e, 0x41, 0x41, 0x41, 0x3e}, // O
+	{0x7f, 0x09, 0x09, 0x09, 0x06}, // P
+	{0x3e, 0x41, 0x51, 0x21, 0x5e}, // Q
+	{0x7f, 0x09, 0x19, 0x29, 0x46}, // R
+	{0x46, 0x49, 0x49, 0x49, 0x31}, // S
+	{0x01, 0x01, 0x7f, 0x01, 0x01}, // T
+	{0x3f, 0x40, 0x40, 0x40, 0x3f}, // U
+	{0x1f, 0x20, 0x40, 0x20, 0x1f}, // V
+	{0x3f, 0x40, 0x38, 0x40, 0x3f}, // W
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // X
+	{0x07, 0x08, 0x70, 0x08, 0x07}, // Y
+	{0x61, 0x51, 0x49, 0x45, 0x43}, // Z
+	{0x00, 0x7f, 0x41, 0x41, 0x00}, // [
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // \
+	{0x00, 0x41, 0x41, 0x7f, 0x00}, // ]
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // ^
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // _
+	{0x00, 0x01, 0x02, 0x04, 0x00}, // `
+	{0x20, 0x54, 0x54, 0x54, 0x78}, // a
+	{0x7f, 0x48, 0x44, 0x44, 0x38}, // b
+	{0x38, 0x44, 0x44, 0x44, 0x20}, // c
+	{0x38, 0x44, 0x44, 0x48, 0x7f}, // d
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // e
+	{0x08, 0x7e, 0x09, 0x01, 0x02}, // f
+	{0x0c, 0x52, 0x52, 0x52, 0x3e}, // g
+	{0x7f, 0x08, 0x04, 0x04, 0x78}, // h
+	{0x00, 0x44, 0x7d, 0x40, 0x00}, // i
+	{0x20, 0x40, 0x44, 0x3d, 0x00}, // j
+	{0x7f, 0x10, 0x28, 0x44, 0x00}, // k
+	{0x00, 0x41, 0x7f, 0x40, 0x00}, // l
+	{0x7c, 0x04, 0x18, 0x04, 0x78}, // m
+	{0x7c, 0x08, 0x04, 0x04, 0x78}, // n
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // o
+	{0x7c, 0x14, 0x14, 0x14, 0x08}, // p
+	{0x08, 0x14, 0x14, 0x18, 0x7c}, // q
+	{0x7c, 0x08, 0x04, 0x04, 0x08}, // r
+	{0x48, 0x54, 0x54, 0x54, 0x20}, // s
+	{0x04, 0x3f, 0x44, 0x40, 0x20}, // t
+	{0x3c, 0x40, 0x40, 0x20, 0x7c}, // u
+	{0x1c, 0x20, 0x40, 0x20, 0x1c}, // v
+	{0x3c, 0x40, 0x30, 0x40, 0x3c}, // w
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // x
+	{0x0c, 0x50, 0x50, 0x50, 0x3c}, // y
+	{0x44, 0x64, 0x54, 0x4c, 0x44}, // z
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // {
+	{0x00, 0x00, 0x7f, 0x00, 0x00}, // |
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // }
+	{0x08, 0x04, 0x08, 0x10, 0x08}, // ~
+}
+
+// mark is a diacritic drawn over (or under) a base glyph: two rows of five
+// dots, left to right.
+type mark [2]string
+
+var (
+	markAcute      = mark{"...#.", "..#.."}
+	markGrave      = mark{".#...", "..#.."}
+	markCircumflex = mark{"..#..", ".#.#."}
+	markTilde      = mark{".##.#", "#..#."}
+	markCedilla    = mark{"..#..", ".##.."}
+)
+
+// accented are the letters of the Portuguese catalog, as base glyph and mark.
+var accented = map[rune]struct {
+	base rune
+	mark mark
+}{
+	'Á': {'A', markAcute}, 'É': {'E', markAcute}, 'Í': {'I', markAcute}, 'Ó': {'O', markAcute}, 'Ú': {'U', markAcute},
+	'á': {'a', markAcute}, 'é': {'e', markAcute}, 'í': {'i', markAcute}, 'ó': {'o', markAcute}, 'ú': {'u', markAcute},
+	'À': {'A', markGrave}, 'à': {'a', markGrave},
+	'Â': {'A', markCircumflex}, 'Ê': {'E', markCircumflex}, 'Ô': {'O', markCircumflex},
+	'â': {'a', markCircumflex}, 'ê': {'e', markCircumflex}, 'ô': {'o', markCircumflex},
+	'Ã': {'A', markTilde}, 'Õ': {'O', markTilde}, 'ã': {'a', markTilde}, 'õ': {'o', markTilde},
+	'Ç': {'C', markCedilla}, 'ç': {'c', markCedilla},
+}
+
+// TextWidth is the width of text in the bundled font: every rune has the same advance.
+func TextWidth(text string, font ports.Font) float64 {
+	return float64(utf8.RuneCountInString(text)) * advanceDots * font.Size / emDots
+}
+
+// GlyphCell is the box of the dots of every rune, in dots from the pen
+// position on the baseline: accents reach 2 dots above the capitals and the
+// cedilla 2 below the baseline. Runes are GlyphCell.Dx() dots apart.
+var GlyphCell = image.Rect(0, -baselineDots-2, advanceDots, 2)
+
+// Runes are the runes of the bundled font, in order; any other is drawn as '?'.
+func Runes() []rune {
+	runes := make([]rune, 0, len(glyphs)+len(accented))
+	for ch := rune(' '); ch <= '~'; ch++ {
+		runes = append(runes, ch)
+	}
+	for ch := range accented {
+		runes = append(runes, ch)
+	}
+	slices.Sort(runes)
+
+	return runes
+}
+
+// DotSize is the size of a dot of the bundled font at the size of font.
+func DotSize(font ports.Font) float64 {
+	return font.Size / emDots
+}
+
+// EachDot calls fn with the column and row of every dot of ch, inside GlyphCell.
+func EachDot(ch rune, fn func(col, row int)) {
+	eachDot(ch, func(col, row int) { fn(col, row-baselineDots) })
+}
+
+// eachDot calls fn with the column and row of every dot of ch, rows counted from
+// the top of the capitals. Runes outside the font are drawn as '?'.
+func eachDot(ch rune, fn func(col, row int)) {
+	var m *mark
+	markRow := -2
+	if a, ok := accented[ch]; ok {
+		ch, m = a.base, &a.mark
+		switch {
+		case a.mark == markCedilla:
+			markRow = baselineDots
+		case ch >= 'a' && ch <= 'z':
+			markRow = 0 // above the x-height
+		}
+	}
+
+	if ch < ' ' || ch > '~' {
+		ch = '?'
+	}
+	for col, bits := range glyphs[ch-' '] {
+		for row := 0; row < baselineDots; row++ {
+			if bits&(1<<row) != 0 {
+				fn(col, row)
+			}
+		}
+	}
+
+	if m == nil {
+		return
+	}
+	for i, line := range m {
+		for col, dot := range line {
+			if dot == '#' {
+				fn(col, markRow+i)
+			}
+		}
+	}
+}
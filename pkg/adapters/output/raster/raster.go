@@ -0,0 +1,323 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/transform"
+)
+
+// Renderer draws the logical court (w x h units) on an RGBA image, scaled to
+// the image size. Shapes cover the pixels whose center is inside them, without
+// anti-aliasing, so the same frame always gives the same pixels; text uses the
+// bundled bitmap font. A shape smaller than a pixel still lights one pixel.
+type Renderer struct {
+	w, h float64
+	img  *image.RGBA
+
+	transform.State
+
+	poly []ports.Point
+	quad [4]ports.Point
+	xs   []float64
+}
+
+// NewRenderer draws the court on a width x height image.
+func NewRenderer(w, h float64, width, height int) *Renderer {
+	r := &Renderer{w: w, h: h}
+	r.Resize(width, height)
+	return r
+}
+
+// NewScaled draws the court on an image of scale pixels per court unit.
+func NewScaled(w, h, scale float64) *Renderer {
+	return NewRenderer(w, h, int(math.Round(w*scale)), int(math.Round(h*scale)))
+}
+
+// Resize replaces the image with a blank width x height one.
+func (r *Renderer) Resize(width, height int) {
+	r.img = image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	r.reset()
+}
+
+// Image is the frame drawn so far; it is reused by the next frames.
+func (r *Renderer) Image() *image.RGBA {
+	return r.img
+}
+
+// At is the color of the pixel at px, py.
+func (r *Renderer) At(px, py int) ports.Color {
+	c := r.img.RGBAAt(px, py)
+	return ports.Color{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+// EncodePNG writes the current frame as PNG.
+func (r *Renderer) EncodePNG(w io.Writer) error {
+	return png.Encode(w, r.img)
+}
+
+// ToPixel converts court coordinates to image pixels with the current transform.
+func (r *Renderer) ToPixel(x, y float64) (float64, float64) {
+	p := r.Matrix().Apply(x, y)
+	return p.X, p.Y
+}
+
+// Clear starts a frame: it resets the court transform and fills the background.
+func (r *Renderer) Clear(c ports.Color) {
+	r.reset()
+	c = blend(ports.RGB(0, 0, 0), c, 1)
+	rgba := color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}
+
+	pix := r.img.Pix
+	for i := 0; i < len(pix); i += 4 {
+		pix[i], pix[i+1], pix[i+2], pix[i+3] = rgba.R, rgba.G, rgba.B, rgba.A
+	}
+}
+
+func (r *Renderer) reset() {
+	size := r.img.Bounds().Size()
+	r.Reset(transform.Matrix{A: float64(size.X) / r.w, D: float64(size.Y) / r.h})
+}
+
+func (r *Renderer) DrawRect(x, y, w, h float64, style ports.Style) {
+	r.poly = r.poly[:0]
+	for _, p := range [4]ports.Point{{X: x, Y: y}, {X: x + w, Y: y}, {X: x + w, Y: y + h}, {X: x, Y: y + h}} {
+		r.poly = append(r.poly, r.toPixel(p.X, p.Y))
+	}
+	r.paintPolygon(style)
+}
+
+func (r *Renderer) DrawPolygon(points []ports.Point, style ports.Style) {
+	if len(points) < 2 {
+		return
+	}
+
+	r.poly = r.poly[:0]
+	for _, p := range points {
+		r.poly = append(r.poly, r.toPixel(p.X, p.Y))
+	}
+	r.paintPolygon(style)
+}
+
+// DrawCircle draws an ellipse when the image is not scaled evenly on both axes.
+func (r *Renderer) DrawCircle(x, y, radius float64, style ports.Style) {
+	m := r.Matrix()
+	cx, cy := r.ToPixel(x, y)
+	rx := radius * math.Hypot(m.A, m.B)
+	ry := radius * math.Hypot(m.C, m.D)
+
+	if visible(style.Fill) && rx > 0 && ry > 0 {
+		hits := 0
+		for py := int(math.Floor(cy - ry)); py <= int(math.Ceil(cy+ry)); py++ {
+			for px := int(math.Floor(cx - rx)); px <= int(math.Ceil(cx+rx)); px++ {
+				dx := (float64(px) + 0.5 - cx) / rx
+				dy := (float64(py) + 0.5 - cy) / ry
+				if dx*dx+dy*dy <= 1 {
+					r.plot(px, py, style.Fill)
+					hits++
+				}
+			}
+		}
+		if hits == 0 {
+			r.plot(int(math.Floor(cx)), int(math.Floor(cy)), style.Fill)
+		}
+	}
+
+	if visible(style.Stroke) {
+		const segments = 32
+		width := r.lineWidth(style.LineWidth)
+		for i := 0; i < segments; i++ {
+			a0 := 2 * math.Pi * float64(i) / segments
+			a1 := 2 * math.Pi * float64(i+1) / segments
+			r.line(cx+rx*math.Cos(a0), cy+ry*math.Sin(a0), cx+rx*math.Cos(a1), cy+ry*math.Sin(a1), width, style.Stroke)
+		}
+	}
+}
+
+func (r *Renderer) DrawLine(x1, y1, x2, y2 float64, style ports.Style) {
+	if !visible(style.Stroke) {
+		return
+	}
+
+	p1, p2 := r.toPixel(x1, y1), r.toPixel(x2, y2)
+	r.line(p1.X, p1.Y, p2.X, p2.Y, r.lineWidth(style.LineWidth), style.Stroke)
+}
+
+// DrawImage samples img at the nearest pixel; rotation is ignored.
+func (r *Renderer) DrawImage(img image.Image, x, y, w, h float64) {
+	p1, p2 := r.toPixel(x, y), r.toPixel(x+w, y+h)
+	x0, x1 := math.Min(p1.X, p2.X), math.Max(p1.X, p2.X)
+	y0, y1 := math.Min(p1.Y, p2.Y), math.Max(p1.Y, p2.Y)
+	if x1 <= x0 || y1 <= y0 {
+		return
+	}
+
+	bounds := img.Bounds()
+	for py := int(math.Floor(y0)); py < int(math.Ceil(y1)); py++ {
+		for px := int(math.Floor(x0)); px < int(math.Ceil(x1)); px++ {
+			u := (float64(px) + 0.5 - x0) / (x1 - x0)
+			v := (float64(py) + 0.5 - y0) / (y1 - y0)
+			if u < 0 || u >= 1 || v < 0 || v >= 1 {
+				continue
+			}
+
+			src := img.At(bounds.Min.X+int(u*float64(bounds.Dx())), bounds.Min.Y+int(v*float64(bounds.Dy())))
+			c := color.NRGBAModel.Convert(src).(color.NRGBA)
+			r.plot(px, py, ports.Color{R: c.R, G: c.G, B: c.B, A: c.A})
+		}
+	}
+}
+
+// DrawText draws text with the bitmap font, one filled square per dot, so the
+// transforms and the alpha apply as for any shape.
+func (r *Renderer) DrawText(text string, x, y float64, style ports.TextStyle) {
+	width := r.MeasureText(text, style.Font)
+	switch style.Align {
+	case ports.AlignCenter:
+		x -= width / 2
+	case ports.AlignRight:
+		x -= width
+	}
+
+	dot := style.Font.Size / emDots
+	fill := ports.Style{Fill: style.Color}
+	for _, ch := range text {
+		eachDot(ch, func(col, row int) {
+			r.DrawRect(x+float64(col)*dot, y+float64(row-baselineDots)*dot, dot, dot, fill)
+		})
+		x += advanceDots * dot
+	}
+}
+
+// MeasureText is the advance of the monospaced bitmap font.
+func (r *Renderer) MeasureText(text string, font ports.Font) float64 {
+	return TextWidth(text, font)
+}
+
+func (r *Renderer) toPixel(x, y float64) ports.Point {
+	return r.Matrix().Apply(x, y)
+}
+
+// lineWidth converts a line width to pixels; 0 is the thinnest line.
+func (r *Renderer) lineWidth(w float64) float64 {
+	return w * r.Matrix().Scale()
+}
+
+// paintPolygon fills and strokes r.poly, already in pixel coordinates.
+func (r *Renderer) paintPolygon(style ports.Style) {
+	if visible(style.Fill) {
+		r.fillPolygon(r.poly, style.Fill)
+	}
+
+	if visible(style.Stroke) {
+		width := r.lineWidth(style.LineWidth)
+		n := len(r.poly)
+		for i := 0; i < n; i++ {
+			p, q := r.poly[i], r.poly[(i+1)%n]
+			r.line(p.X, p.Y, q.X, q.Y, width, style.Stroke)
+		}
+	}
+}
+
+// fillPolygon fills the pixels whose center is inside the polygon (even-odd
+// rule). A polygon that covers no pixel center lights the pixel under its centroid.
+func (r *Renderer) fillPolygon(poly []ports.Point, c ports.Color) {
+	size := r.img.Bounds().Size()
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	var cx, cy float64
+	for _, p := range poly {
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		cx, cy = cx+p.X, cy+p.Y
+	}
+
+	hits := 0
+	for py := max(int(math.Floor(minY)), 0); py <= min(int(math.Ceil(maxY)), size.Y-1); py++ {
+		y := float64(py) + 0.5
+		r.xs = r.xs[:0]
+		for i, p := range poly {
+			q := poly[(i+1)%len(poly)]
+			if (p.Y <= y) != (q.Y <= y) {
+				r.xs = append(r.xs, p.X+(y-p.Y)/(q.Y-p.Y)*(q.X-p.X))
+			}
+		}
+		sort.Float64s(r.xs)
+
+		for i := 0; i+1 < len(r.xs); i += 2 {
+			last := min(int(math.Ceil(r.xs[i+1]-0.5))-1, size.X-1)
+			for px := max(int(math.Ceil(r.xs[i]-0.5)), 0); px <= last; px++ {
+				r.plot(px, py, c)
+				hits++
+			}
+		}
+	}
+
+	if hits == 0 {
+		n := float64(len(poly))
+		r.plot(int(math.Floor(cx/n)), int(math.Floor(cy/n)), c)
+	}
+}
+
+// line draws a line between two points in pixel coordinates: one pixel wide
+// up to a width of 1.5 pixels, a filled quad above.
+func (r *Renderer) line(x1, y1, x2, y2, width float64, c ports.Color) {
+	length := math.Hypot(x2-x1, y2-y1)
+	if width > 1.5 && length > 0 {
+		nx, ny := -(y2-y1)/length*width/2, (x2-x1)/length*width/2
+		r.quad = [4]ports.Point{{X: x1 + nx, Y: y1 + ny}, {X: x2 + nx, Y: y2 + ny}, {X: x2 - nx, Y: y2 - ny}, {X: x1 - nx, Y: y1 - ny}}
+		r.fillPolygon(r.quad[:], c)
+		return
+	}
+
+	steps := int(math.Ceil(math.Max(math.Abs(x2-x1), math.Abs(y2-y1))))
+	if steps == 0 {
+		r.plot(int(math.Floor(x1)), int(math.Floor(y1)), c)
+		return
+	}
+
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		r.plot(int(math.Floor(x1+(x2-x1)*t)), int(math.Floor(y1+(y2-y1)*t)), c)
+	}
+}
+
+// plot blends c over the pixel at px, py with the current alpha.
+func (r *Renderer) plot(px, py int, c ports.Color) {
+	if !(image.Point{X: px, Y: py}.In(r.img.Rect)) {
+		return
+	}
+
+	i := r.img.PixOffset(px, py)
+	pix := r.img.Pix[i : i+4 : i+4]
+	out := blend(ports.RGB(pix[0], pix[1], pix[2]), c, r.Alpha())
+	pix[0], pix[1], pix[2], pix[3] = out.R, out.G, out.B, 255
+}
+
+// blend composites src over the opaque dst, with src's alpha scaled by alpha.
+func blend(dst, src ports.Color, alpha float64) ports.Color {
+	a := float64(src.A) / 255 * alpha
+	if a <= 0 {
+		return dst
+	}
+
+	return ports.RGB(mixChannel(dst.R, src.R, a), mixChannel(dst.G, src.G, a), mixChannel(dst.B, src.B, a))
+}
+
+// Blend composites src over the opaque dst.
+func Blend(dst, src ports.Color) ports.Color {
+	return blend(dst, src, 1)
+}
+
+func mixChannel(d, s uint8, a float64) uint8 {
+	return uint8(math.Round(float64(d) + (float64(s)-float64(d))*a))
+}
+
+func visible(c ports.Color) bool {
+	return c.A > 0
+}
+
+var _ ports.Renderer = (*Renderer)(nil)
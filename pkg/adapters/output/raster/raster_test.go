@@ -0,0 +1,302 @@
+package raster
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+var (
+	black = ports.RGB(0, 0, 0)
+	white = ports.RGB(255, 255, 255)
+	red   = ports.RGB(255, 0, 0)
+)
+
+// newTestRenderer maps the 800x600 court on 8x6 pixels of 100x100 units.
+func newTestRenderer() *Renderer {
+	r := NewRenderer(800, 600, 8, 6)
+	r.Clear(black)
+	return r
+}
+
+func TestRendererRasterize(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(r *Renderer)
+		x, y int
+		want ports.Color
+	}{
+		{
+			name: "Background",
+			draw: func(r *Renderer) {},
+			want: black,
+		},
+		{
+			name: "Rect covers the pixel centers inside it",
+			draw: func(r *Renderer) { r.DrawRect(100, 100, 200, 100, ports.Style{Fill: white}) },
+			x:    2,
+			y:    1,
+			want: white,
+		},
+		{
+			name: "Rect does not cover the pixel centers outside it",
+			draw: func(r *Renderer) { r.DrawRect(100, 100, 140, 100, ports.Style{Fill: white}) },
+			x:    2,
+			y:    1,
+			want: black,
+		},
+		{
+			name: "Translate moves the court",
+			draw: func(r *Renderer) {
+				r.Translate(300, 100)
+				r.DrawRect(0, 0, 100, 100, ports.Style{Fill: white})
+			},
+			x:    3,
+			y:    1,
+			want: white,
+		},
+		{
+			name: "Scale grows the shape",
+			draw: func(r *Renderer) {
+				r.Scale(2, 2)
+				r.DrawRect(0, 0, 100, 100, ports.Style{Fill: white})
+			},
+			x:    1,
+			y:    1,
+			want: white,
+		},
+		{
+			name: "Rotate turns the shape around the origin",
+			draw: func(r *Renderer) {
+				r.Translate(400, 300)
+				r.Rotate(math.Pi / 2)
+				r.DrawRect(0, 0, 200, 100, ports.Style{Fill: white}) // now goes down and left
+			},
+			x:    3,
+			y:    4,
+			want: white,
+		},
+		{
+			name: "Restore drops the transform and the alpha",
+			draw: func(r *Renderer) {
+				r.Save()
+				r.Translate(300, 0)
+				r.SetAlpha(0)
+				r.Restore()
+				r.DrawRect(0, 0, 100, 100, ports.Style{Fill: white})
+			},
+			want: white,
+		},
+		{
+			name: "Alpha blends over the background",
+			draw: func(r *Renderer) {
+				r.SetAlpha(0.5)
+				r.DrawRect(0, 0, 100, 100, ports.Style{Fill: white})
+			},
+			want: ports.RGB(128, 128, 128),
+		},
+		{
+			name: "Color alpha blends over the background",
+			draw: func(r *Renderer) { r.DrawRect(0, 0, 100, 100, ports.Style{Fill: ports.Color{R: 255, A: 51}}) },
+			want: ports.RGB(51, 0, 0),
+		},
+		{
+			name: "Circle",
+			draw: func(r *Renderer) { r.DrawCircle(400, 300, 150, ports.Style{Fill: red}) },
+			x:    3,
+			y:    2,
+			want: red,
+		},
+		{
+			name: "Circle smaller than a pixel lights its center",
+			draw: func(r *Renderer) { r.DrawCircle(250, 150, 10, ports.Style{Fill: red}) },
+			x:    2,
+			y:    1,
+			want: red,
+		},
+		{
+			name: "Polygon",
+			draw: func(r *Renderer) {
+				r.DrawPolygon([]ports.Point{{X: 400, Y: 0}, {X: 600, Y: 0}, {X: 400, Y: 200}}, ports.Style{Fill: red})
+			},
+			x:    4,
+			y:    0,
+			want: red,
+		},
+		{
+			name: "Thin line",
+			draw: func(r *Renderer) { r.DrawLine(750, 0, 750, 600, ports.Style{Stroke: white}) },
+			x:    7,
+			y:    5,
+			want: white,
+		},
+		{
+			name: "Thick line",
+			draw: func(r *Renderer) { r.DrawLine(0, 300, 800, 300, ports.Style{Stroke: white, LineWidth: 200}) },
+			x:    4,
+			y:    2,
+			want: white,
+		},
+		{
+			name: "Stroked rect leaves the inside",
+			draw: func(r *Renderer) { r.DrawRect(50, 50, 700, 500, ports.Style{Stroke: white}) },
+			x:    3,
+			y:    3,
+			want: black,
+		},
+		{
+			name: "Image",
+			draw: func(r *Renderer) {
+				img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+				img.Set(1, 0, color.NRGBA{R: 255, A: 255})
+				r.DrawImage(img, 0, 0, 200, 100)
+			},
+			x:    1,
+			want: red,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRenderer()
+			tt.draw(r)
+
+			if got := r.At(tt.x, tt.y); got != tt.want {
+				t.Errorf("At(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendererText(t *testing.T) {
+	font := ports.Font{Size: 10} // one dot per court unit
+	r := NewRenderer(100, 20, 100, 20)
+
+	tests := []struct {
+		name  string
+		text  string
+		align ports.TextAlign
+		x     float64
+		want  []image.Point // lit pixels
+		off   []image.Point // background pixels
+	}{
+		{
+			// 'I': middle column full height, serifs on the top and bottom rows
+			name:  "Left",
+			text:  "I",
+			align: ports.AlignLeft,
+			x:     0,
+			want:  []image.Point{{X: 2, Y: 10}, {X: 2, Y: 13}, {X: 1, Y: 10}, {X: 3, Y: 16}},
+			off:   []image.Point{{X: 0, Y: 13}, {X: 2, Y: 17}, {X: 2, Y: 9}},
+		},
+		{
+			name:  "Center",
+			text:  "II",
+			align: ports.AlignCenter,
+			x:     50,
+			want:  []image.Point{{X: 46, Y: 13}, {X: 52, Y: 13}},
+		},
+		{
+			name:  "Right",
+			text:  "I",
+			align: ports.AlignRight,
+			x:     100,
+			want:  []image.Point{{X: 96, Y: 13}},
+		},
+		{
+			name:  "Accent above the capital",
+			text:  "Ó",
+			align: ports.AlignLeft,
+			x:     0,
+			want:  []image.Point{{X: 3, Y: 8}, {X: 2, Y: 9}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r.Clear(black)
+			r.DrawText(tt.text, tt.x, 17, ports.TextStyle{Font: font, Color: white, Align: tt.align})
+
+			for _, p := range tt.want {
+				if got := r.At(p.X, p.Y); got != white {
+					t.Errorf("At(%d, %d) = %v, want the text color", p.X, p.Y, got)
+				}
+			}
+			for _, p := range tt.off {
+				if got := r.At(p.X, p.Y); got != black {
+					t.Errorf("At(%d, %d) = %v, want the background", p.X, p.Y, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		size float64
+		want float64
+	}{
+		{name: "Empty", text: "", size: 20, want: 0},
+		{name: "ASCII", text: "Score", size: 20, want: 60},
+		{name: "Accents count as one rune", text: "AÇÃO", size: 10, want: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TextWidth(tt.text, ports.Font{Size: tt.size}); got != tt.want {
+				t.Errorf("TextWidth(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEachDot(t *testing.T) {
+	runes := Runes()
+	if len(runes) != len(glyphs)+len(accented) {
+		t.Errorf("Runes() has %d runes, want %d", len(runes), len(glyphs)+len(accented))
+	}
+
+	for _, ch := range runes {
+		EachDot(ch, func(col, row int) {
+			if !(image.Point{X: col, Y: row}).In(GlyphCell) {
+				t.Errorf("dot %d,%d of %q is outside %v", col, row, ch, GlyphCell)
+			}
+		})
+	}
+
+	// the capitals stand on the baseline
+	var rows []int
+	EachDot('I', func(col, row int) { rows = append(rows, row) })
+	if slices.Min(rows) != -baselineDots || slices.Max(rows) != -1 {
+		t.Errorf("rows of I = %d..%d, want %d..-1", slices.Min(rows), slices.Max(rows), -baselineDots)
+	}
+}
+
+func TestRendererEncodePNG(t *testing.T) {
+	r := NewScaled(800, 600, 0.1)
+	r.Clear(red)
+
+	var buf bytes.Buffer
+	if err := r.EncodePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size != (image.Point{X: 80, Y: 60}) {
+		t.Errorf("PNG size = %v, want 80x60", size)
+	}
+	if got := color.RGBAModel.Convert(img.At(40, 30)); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("PNG pixel = %v, want red", got)
+	}
+}
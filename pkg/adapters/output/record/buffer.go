@@ -0,0 +1,124 @@
+package record
+
+import (
+	"image"
+	"io"
+	"slices"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+)
+
+// Measure returns the width of text, e.g. the MeasureText of the renderer the
+// commands will be replayed on.
+type Measure func(text string, font ports.Font) float64
+
+// CommandBuffer is a Renderer that records every call as a typed Command
+// instead of drawing it. The commands can be written as text for golden
+// tests, compared with Diff, or replayed on another Renderer, e.g. all at once
+// at the end of a frame.
+type CommandBuffer struct {
+	cmds    []Command
+	measure Measure
+}
+
+// NewCommandBuffer measures text with measure; nil uses the bitmap font of
+// the raster renderer.
+func NewCommandBuffer(measure Measure) *CommandBuffer {
+	if measure == nil {
+		measure = raster.TextWidth
+	}
+
+	return &CommandBuffer{measure: measure}
+}
+
+// Commands are the calls recorded since the last Reset; the slice is reused
+// after Reset.
+func (b *CommandBuffer) Commands() []Command {
+	return b.cmds
+}
+
+// Reset drops the recorded commands and keeps their storage.
+func (b *CommandBuffer) Reset() {
+	clear(b.cmds)
+	b.cmds = b.cmds[:0]
+}
+
+// Replay draws the recorded commands on r, in order.
+func (b *CommandBuffer) Replay(r ports.Renderer) {
+	for _, cmd := range b.cmds {
+		cmd.Replay(r)
+	}
+}
+
+// WriteTo writes the commands one per line.
+func (b *CommandBuffer) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	for _, cmd := range b.cmds {
+		written, err := io.WriteString(w, cmd.String()+"\n")
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+func (b *CommandBuffer) Clear(color ports.Color) {
+	b.cmds = append(b.cmds, Command{Op: OpClear, Style: ports.Style{Fill: color}})
+}
+
+func (b *CommandBuffer) DrawRect(x, y, w, h float64, style ports.Style) {
+	b.cmds = append(b.cmds, Command{Op: OpRect, X: x, Y: y, W: w, H: h, Style: style})
+}
+
+func (b *CommandBuffer) DrawCircle(x, y, radius float64, style ports.Style) {
+	b.cmds = append(b.cmds, Command{Op: OpCircle, X: x, Y: y, W: radius, Style: style})
+}
+
+func (b *CommandBuffer) DrawLine(x1, y1, x2, y2 float64, style ports.Style) {
+	b.cmds = append(b.cmds, Command{Op: OpLine, X: x1, Y: y1, W: x2, H: y2, Style: style})
+}
+
+// DrawPolygon copies points: callers may reuse the slice for the next shape.
+func (b *CommandBuffer) DrawPolygon(points []ports.Point, style ports.Style) {
+	b.cmds = append(b.cmds, Command{Op: OpPolygon, Points: slices.Clone(points), Style: style})
+}
+
+func (b *CommandBuffer) DrawImage(img image.Image, x, y, w, h float64) {
+	b.cmds = append(b.cmds, Command{Op: OpImage, X: x, Y: y, W: w, H: h, Image: img})
+}
+
+func (b *CommandBuffer) DrawText(text string, x, y float64, style ports.TextStyle) {
+	b.cmds = append(b.cmds, Command{Op: OpText, X: x, Y: y, Text: text, TextStyle: style})
+}
+
+// MeasureText is answered right away; it is not recorded.
+func (b *CommandBuffer) MeasureText(text string, font ports.Font) float64 {
+	return b.measure(text, font)
+}
+
+func (b *CommandBuffer) Save() {
+	b.cmds = append(b.cmds, Command{Op: OpSave})
+}
+
+func (b *CommandBuffer) Restore() {
+	b.cmds = append(b.cmds, Command{Op: OpRestore})
+}
+
+func (b *CommandBuffer) Translate(x, y float64) {
+	b.cmds = append(b.cmds, Command{Op: OpTranslate, X: x, Y: y})
+}
+
+func (b *CommandBuffer) Scale(x, y float64) {
+	b.cmds = append(b.cmds, Command{Op: OpScale, X: x, Y: y})
+}
+
+func (b *CommandBuffer) Rotate(angle float64) {
+	b.cmds = append(b.cmds, Command{Op: OpRotate, X: angle})
+}
+
+func (b *CommandBuffer) SetAlpha(alpha float64) {
+	b.cmds = append(b.cmds, Command{Op: OpAlpha, X: alpha})
+}
@@ -0,0 +1,199 @@
+package record
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// Op is the Renderer method a command records.
+type Op uint8
+
+const (
+	OpClear Op = iota
+	OpRect
+	OpCircle
+	OpLine
+	OpPolygon
+	OpImage
+	OpText
+	OpSave
+	OpRestore
+	OpTranslate
+	OpScale
+	OpRotate
+	OpAlpha
+)
+
+var opNames = [...]string{
+	OpClear:     "clear",
+	OpRect:      "rect",
+	OpCircle:    "circle",
+	OpLine:      "line",
+	OpPolygon:   "polygon",
+	OpImage:     "image",
+	OpText:      "text",
+	OpSave:      "save",
+	OpRestore:   "restore",
+	OpTranslate: "translate",
+	OpScale:     "scale",
+	OpRotate:    "rotate",
+	OpAlpha:     "alpha",
+}
+
+func (op Op) String() string {
+	if int(op) < len(opNames) {
+		return opNames[op]
+	}
+
+	return "op(" + strconv.Itoa(int(op)) + ")"
+}
+
+// Command is one Renderer call. The numbers are the arguments in call order:
+// x, y, w, h for rect and image; x, y, radius for circle; x1, y1, x2, y2 for
+// line; x, y for text, translate and scale; the angle or the alpha in X.
+// Clear keeps its color in Style.Fill.
+type Command struct {
+	Op         Op
+	X, Y, W, H float64
+	Points     []ports.Point
+	Style      ports.Style
+	Text       string
+	TextStyle  ports.TextStyle
+	Image      image.Image
+}
+
+// Replay calls the recorded method on r.
+func (c Command) Replay(r ports.Renderer) {
+	switch c.Op {
+	case OpClear:
+		r.Clear(c.Style.Fill)
+	case OpRect:
+		r.DrawRect(c.X, c.Y, c.W, c.H, c.Style)
+	case OpCircle:
+		r.DrawCircle(c.X, c.Y, c.W, c.Style)
+	case OpLine:
+		r.DrawLine(c.X, c.Y, c.W, c.H, c.Style)
+	case OpPolygon:
+		r.DrawPolygon(c.Points, c.Style)
+	case OpImage:
+		if c.Image != nil {
+			r.DrawImage(c.Image, c.X, c.Y, c.W, c.H)
+		}
+	case OpText:
+		r.DrawText(c.Text, c.X, c.Y, c.TextStyle)
+	case OpSave:
+		r.Save()
+	case OpRestore:
+		r.Restore()
+	case OpTranslate:
+		r.Translate(c.X, c.Y)
+	case OpScale:
+		r.Scale(c.X, c.Y)
+	case OpRotate:
+		r.Rotate(c.X)
+	case OpAlpha:
+		r.SetAlpha(c.X)
+	}
+}
+
+// String is the command as one line of text, e.g.
+//
+//	rect 10 270 10 60 fill=#ffffff
+//	text 400 300 "PAUSED" font="Arial" size=20 color=#ffffff align=center
+//
+// Numbers are rounded to three decimals, so a golden file does not change
+// with the last bits of a float. Images are written as their size only.
+func (c Command) String() string {
+	var b strings.Builder
+	b.WriteString(c.Op.String())
+
+	switch c.Op {
+	case OpClear:
+		b.WriteString(" " + formatColor(c.Style.Fill))
+	case OpRect, OpLine:
+		writeNums(&b, c.X, c.Y, c.W, c.H)
+		writeStyle(&b, c.Style)
+	case OpCircle:
+		writeNums(&b, c.X, c.Y, c.W)
+		writeStyle(&b, c.Style)
+	case OpPolygon:
+		for _, p := range c.Points {
+			b.WriteString(" " + formatNum(p.X) + "," + formatNum(p.Y))
+		}
+		writeStyle(&b, c.Style)
+	case OpImage:
+		size := image.Point{}
+		if c.Image != nil {
+			size = c.Image.Bounds().Size()
+		}
+		fmt.Fprintf(&b, " %dx%d", size.X, size.Y)
+		writeNums(&b, c.X, c.Y, c.W, c.H)
+	case OpText:
+		writeNums(&b, c.X, c.Y)
+		b.WriteString(" " + strconv.Quote(c.Text))
+		writeTextStyle(&b, c.TextStyle)
+	case OpTranslate, OpScale:
+		writeNums(&b, c.X, c.Y)
+	case OpRotate, OpAlpha:
+		writeNums(&b, c.X)
+	}
+
+	return b.String()
+}
+
+func writeNums(b *strings.Builder, nums ...float64) {
+	for _, v := range nums {
+		b.WriteString(" " + formatNum(v))
+	}
+}
+
+func writeStyle(b *strings.Builder, s ports.Style) {
+	if s.Fill.A > 0 {
+		b.WriteString(" fill=" + formatColor(s.Fill))
+	}
+	if s.Stroke.A > 0 {
+		b.WriteString(" stroke=" + formatColor(s.Stroke))
+	}
+	if s.LineWidth != 0 {
+		b.WriteString(" width=" + formatNum(s.LineWidth))
+	}
+}
+
+func writeTextStyle(b *strings.Builder, s ports.TextStyle) {
+	b.WriteString(" font=" + strconv.Quote(s.Font.Family) + " size=" + formatNum(s.Font.Size))
+	if s.Font.Bold {
+		b.WriteString(" bold")
+	}
+	b.WriteString(" color=" + formatColor(s.Color))
+
+	switch s.Align {
+	case ports.AlignCenter:
+		b.WriteString(" align=center")
+	case ports.AlignRight:
+		b.WriteString(" align=right")
+	}
+}
+
+// formatNum rounds v to three decimals, without a negative zero.
+func formatNum(v float64) string {
+	v = math.Round(v*1000) / 1000
+	if v == 0 {
+		v = 0
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatColor is #rrggbb, or #rrggbbaa when translucent, as in theme.json.
+func formatColor(c ports.Color) string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
@@ -0,0 +1,54 @@
+package record
+
+import "strconv"
+
+// Diff compares two command lists by their text and returns the commands
+// only in want ("-") or only in got ("+"), each with its position in its own
+// list; it is empty when both draw the same. Unchanged commands are matched by
+// longest common subsequence, so one inserted shape is reported once instead
+// of shifting the rest of the frame.
+func Diff(want, got []Command) []string {
+	a, b := lines(want), lines(got)
+
+	// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, "-"+strconv.Itoa(i+1)+": "+a[i])
+			i++
+		default:
+			out = append(out, "+"+strconv.Itoa(j+1)+": "+b[j])
+			j++
+		}
+	}
+
+	return out
+}
+
+func lines(cmds []Command) []string {
+	out := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		out[i] = cmd.String()
+	}
+
+	return out
+}
@@ -0,0 +1,247 @@
+package record
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+var ErrSyntax = errors.New("record: invalid command")
+
+// Parse reads commands written one per line by String, as in a golden file.
+// Blank lines and lines starting with # are skipped. Images come back as blank
+// images of the recorded size.
+func Parse(r io.Reader) ([]Command, error) {
+	var cmds []Command
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		cmd, err := ParseCommand(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	return cmds, scanner.Err()
+}
+
+// ParseCommand reads one line written by Command.String.
+func ParseCommand(line string) (Command, error) {
+	tokens, err := split(line)
+	if err != nil || len(tokens) == 0 {
+		return Command{}, fmt.Errorf("%w: %q", ErrSyntax, line)
+	}
+
+	op, ok := lookupOp(tokens[0])
+	if !ok {
+		return Command{}, fmt.Errorf("%w: unknown command %q", ErrSyntax, tokens[0])
+	}
+
+	p := parser{cmd: Command{Op: op}, args: tokens[1:]}
+	switch op {
+	case OpClear:
+		p.cmd.Style.Fill = p.color(p.next())
+	case OpRect, OpLine:
+		p.nums(&p.cmd.X, &p.cmd.Y, &p.cmd.W, &p.cmd.H)
+		p.style()
+	case OpCircle:
+		p.nums(&p.cmd.X, &p.cmd.Y, &p.cmd.W)
+		p.style()
+	case OpPolygon:
+		p.points()
+		p.style()
+	case OpImage:
+		var w, h int
+		if _, err := fmt.Sscanf(p.next(), "%dx%d", &w, &h); err != nil {
+			p.fail("image size")
+		}
+		p.cmd.Image = image.NewRGBA(image.Rect(0, 0, w, h))
+		p.nums(&p.cmd.X, &p.cmd.Y, &p.cmd.W, &p.cmd.H)
+	case OpText:
+		p.nums(&p.cmd.X, &p.cmd.Y)
+		p.cmd.Text = p.quoted(p.next())
+		p.textStyle()
+	case OpTranslate, OpScale:
+		p.nums(&p.cmd.X, &p.cmd.Y)
+	case OpRotate, OpAlpha:
+		p.nums(&p.cmd.X)
+	}
+
+	if p.err == nil && len(p.args) > 0 {
+		p.fail(p.args[0])
+	}
+	if p.err != nil {
+		return Command{}, fmt.Errorf("%w: %s in %q", ErrSyntax, p.err, line)
+	}
+
+	return p.cmd, nil
+}
+
+func lookupOp(name string) (Op, bool) {
+	for op, n := range opNames {
+		if n == name {
+			return Op(op), true
+		}
+	}
+
+	return 0, false
+}
+
+// split cuts line at spaces, keeping quoted strings (and key="value") whole.
+func split(line string) ([]string, error) {
+	var tokens []string
+	for line = strings.TrimLeft(line, " "); line != ""; line = strings.TrimLeft(line, " ") {
+		end := strings.IndexByte(line, ' ')
+		if quote := strings.IndexByte(line, '"'); quote >= 0 && (end < 0 || quote < end) {
+			q, err := strconv.QuotedPrefix(line[quote:])
+			if err != nil {
+				return nil, err
+			}
+			end = quote + len(q)
+		} else if end < 0 {
+			end = len(line)
+		}
+
+		tokens = append(tokens, line[:end])
+		line = line[end:]
+	}
+
+	return tokens, nil
+}
+
+// parser consumes the arguments of a command; the first error is kept.
+type parser struct {
+	cmd  Command
+	args []string
+	err  error
+}
+
+func (p *parser) fail(what string) {
+	if p.err == nil {
+		p.err = fmt.Errorf("bad %s", what)
+	}
+}
+
+func (p *parser) next() string {
+	if len(p.args) == 0 {
+		p.fail("argument count")
+		return ""
+	}
+
+	arg := p.args[0]
+	p.args = p.args[1:]
+	return arg
+}
+
+func (p *parser) nums(dst ...*float64) {
+	for _, d := range dst {
+		arg := p.next()
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			p.fail("number " + strconv.Quote(arg))
+		}
+		*d = v
+	}
+}
+
+func (p *parser) points() {
+	for len(p.args) > 0 && !strings.Contains(p.args[0], "=") {
+		xs, ys, ok := strings.Cut(p.next(), ",")
+		x, errX := strconv.ParseFloat(xs, 64)
+		y, errY := strconv.ParseFloat(ys, 64)
+		if !ok || errX != nil || errY != nil {
+			p.fail("point")
+			return
+		}
+		p.cmd.Points = append(p.cmd.Points, ports.Point{X: x, Y: y})
+	}
+}
+
+func (p *parser) style() {
+	for len(p.args) > 0 {
+		key, value, _ := strings.Cut(p.next(), "=")
+		switch key {
+		case "fill":
+			p.cmd.Style.Fill = p.color(value)
+		case "stroke":
+			p.cmd.Style.Stroke = p.color(value)
+		case "width":
+			p.cmd.Style.LineWidth = p.num(value)
+		default:
+			p.fail("option " + strconv.Quote(key))
+		}
+	}
+}
+
+func (p *parser) textStyle() {
+	s := &p.cmd.TextStyle
+	for len(p.args) > 0 {
+		key, value, _ := strings.Cut(p.next(), "=")
+		switch key {
+		case "font":
+			s.Font.Family = p.quoted(value)
+		case "size":
+			s.Font.Size = p.num(value)
+		case "bold":
+			s.Font.Bold = true
+		case "color":
+			s.Color = p.color(value)
+		case "align":
+			switch value {
+			case "center":
+				s.Align = ports.AlignCenter
+			case "right":
+				s.Align = ports.AlignRight
+			default:
+				p.fail("align")
+			}
+		default:
+			p.fail("option " + strconv.Quote(key))
+		}
+	}
+}
+
+func (p *parser) num(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		p.fail("number " + strconv.Quote(s))
+	}
+
+	return v
+}
+
+func (p *parser) quoted(s string) string {
+	v, err := strconv.Unquote(s)
+	if err != nil {
+		p.fail("string " + s)
+	}
+
+	return v
+}
+
+// color reads #rrggbb or #rrggbbaa.
+func (p *parser) color(s string) ports.Color {
+	hex := strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(s) == len(hex) || (len(hex) != 6 && len(hex) != 8) {
+		p.fail("color " + strconv.Quote(s))
+		return ports.Color{}
+	}
+
+	if len(hex) == 6 {
+		v = v<<8 | 0xff
+	}
+	return ports.Color{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}
+}
@@ -0,0 +1,194 @@
+package record
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+)
+
+var (
+	white = ports.RGB(255, 255, 255)
+	red   = ports.RGB(255, 0, 0)
+)
+
+// drawAll calls every Renderer method once.
+func drawAll(r ports.Renderer) {
+	r.Clear(ports.RGB(0, 0, 0))
+	r.Save()
+	r.Translate(1.5, -2)
+	r.Scale(2, 2)
+	r.Rotate(0.25)
+	r.SetAlpha(0.5)
+	r.DrawRect(10, 20, 30, 40, ports.Style{Fill: red, Stroke: white, LineWidth: 2})
+	r.DrawCircle(50, 60, 5, ports.Style{Fill: ports.Color{R: 1, G: 2, B: 3, A: 128}})
+	r.DrawLine(0, 0, 100, 0.0004, ports.Style{Stroke: white, LineWidth: 1})
+	r.DrawPolygon([]ports.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 1.0 / 3}}, ports.Style{Fill: white})
+	r.DrawImage(image.NewRGBA(image.Rect(0, 0, 4, 2)), 1, 2, 8, 4)
+	r.Restore()
+	r.DrawText(`Say "hi"`, 400, 300, ports.TextStyle{
+		Font:  ports.Font{Family: "Courier New", Size: 20, Bold: true},
+		Color: white,
+		Align: ports.AlignCenter,
+	})
+	r.DrawText("Lives: 3", 790, 30, ports.TextStyle{Font: ports.Font{Family: "Arial", Size: 20}, Color: white, Align: ports.AlignRight})
+}
+
+const drawAllText = `clear #000000
+save
+translate 1.5 -2
+scale 2 2
+rotate 0.25
+alpha 0.5
+rect 10 20 30 40 fill=#ff0000 stroke=#ffffff width=2
+circle 50 60 5 fill=#01020380
+line 0 0 100 0 stroke=#ffffff width=1
+polygon 0,0 10,0 5,0.333 fill=#ffffff
+image 4x2 1 2 8 4
+restore
+text 400 300 "Say \"hi\"" font="Courier New" size=20 bold color=#ffffff align=center
+text 790 30 "Lives: 3" font="Arial" size=20 color=#ffffff align=right
+`
+
+func TestCommandBufferWriteTo(t *testing.T) {
+	b := NewCommandBuffer(nil)
+	drawAll(b)
+
+	var got bytes.Buffer
+	if _, err := b.WriteTo(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != drawAllText {
+		t.Errorf("WriteTo() =\n%s\nwant\n%s", got.String(), drawAllText)
+	}
+
+	b.Reset()
+	if len(b.Commands()) != 0 {
+		t.Error("Reset() kept commands")
+	}
+}
+
+func TestParse(t *testing.T) {
+	b := NewCommandBuffer(nil)
+	drawAll(b)
+
+	cmds, err := Parse(strings.NewReader("# a frame\n\n" + drawAllText))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := Diff(b.Commands(), cmds); len(diff) > 0 {
+		t.Errorf("Parse() differs from the recorded frame:\n%s", strings.Join(diff, "\n"))
+	}
+}
+
+func TestParseCommandErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "Unknown command", line: "ellipse 1 2 3"},
+		{name: "Missing argument", line: "rect 1 2 3"},
+		{name: "Bad number", line: "translate 1 x"},
+		{name: "Extra argument", line: "rotate 1 2"},
+		{name: "Bad color", line: "clear #12345"},
+		{name: "Unknown option", line: "rect 1 2 3 4 dash=2"},
+		{name: "Unterminated string", line: `text 1 2 "abc`},
+		{name: "Bad point", line: "polygon 1,2 3 fill=#ffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCommand(tt.line); !errors.Is(err, ErrSyntax) {
+				t.Errorf("ParseCommand(%q) error = %v, want ErrSyntax", tt.line, err)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	rect := func(x float64) Command {
+		return Command{Op: OpRect, X: x, W: 1, H: 1, Style: ports.Style{Fill: white}}
+	}
+
+	tests := []struct {
+		name string
+		want []Command
+		got  []Command
+		diff []string
+	}{
+		{name: "Same frame", want: []Command{rect(1), rect(2)}, got: []Command{rect(1), rect(2)}},
+		{
+			name: "Inserted command",
+			want: []Command{rect(1), rect(3)},
+			got:  []Command{rect(1), rect(2), rect(3)},
+			diff: []string{"+2: rect 2 0 1 1 fill=#ffffff"},
+		},
+		{
+			name: "Changed command",
+			want: []Command{rect(1), rect(2)},
+			got:  []Command{rect(1), rect(5)},
+			diff: []string{"-2: rect 2 0 1 1 fill=#ffffff", "+2: rect 5 0 1 1 fill=#ffffff"},
+		},
+		{
+			name: "Removed command",
+			want: []Command{rect(1), rect(2)},
+			got:  []Command{rect(2)},
+			diff: []string{"-1: rect 1 0 1 1 fill=#ffffff"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.want, tt.got); !reflect.DeepEqual(got, tt.diff) {
+				t.Errorf("Diff() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestCommandBufferReplay(t *testing.T) {
+	direct := raster.NewRenderer(800, 600, 200, 150)
+	drawAll(direct)
+
+	b := NewCommandBuffer(nil)
+	drawAll(b)
+	replayed := raster.NewRenderer(800, 600, 200, 150)
+	b.Replay(replayed)
+
+	if !bytes.Equal(direct.Image().Pix, replayed.Image().Pix) {
+		t.Error("Replay() drew a different frame than the direct calls")
+	}
+}
+
+func TestCommandBufferPolygonCopy(t *testing.T) {
+	b := NewCommandBuffer(nil)
+	points := []ports.Point{{X: 1, Y: 1}, {X: 2, Y: 2}}
+	b.DrawPolygon(points, ports.Style{Fill: white})
+	points[0].X = 99
+
+	if b.Commands()[0].Points[0].X != 1 {
+		t.Error("DrawPolygon() kept the caller's slice")
+	}
+}
+
+func TestCommandBufferMeasureText(t *testing.T) {
+	font := ports.Font{Family: "Arial", Size: 10}
+
+	if got := NewCommandBuffer(nil).MeasureText("abcd", font); got != raster.TextWidth("abcd", font) {
+		t.Errorf("default MeasureText() = %v, want the bitmap font width", got)
+	}
+
+	b := NewCommandBuffer(func(text string, font ports.Font) float64 { return 42 })
+	if got := b.MeasureText("abcd", font); got != 42 {
+		t.Errorf("MeasureText() = %v, want 42", got)
+	}
+	if len(b.Commands()) != 0 {
+		t.Error("MeasureText() was recorded")
+	}
+}
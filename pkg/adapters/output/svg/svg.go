@@ -0,0 +1,262 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+	"github.com/psaraiva/squash/pkg/adapters/output/transform"
+)
+
+// Renderer writes each frame as an SVG document in court units, one element
+// per line, so two frames can be compared with a plain text diff. Coordinates
+// are rounded to two decimals; the transform and the alpha in effect are
+// written on every element instead of nested groups. Text keeps the theme
+// font, spaced to the monospaced advance of the bitmap font so that the HUD
+// laid out with MeasureText fits whatever font the viewer picks.
+type Renderer struct {
+	w, h   float64
+	width  int
+	height int
+
+	transform.State
+
+	body bytes.Buffer
+}
+
+// NewRenderer writes a w x h court; the document is drawn at the court size.
+func NewRenderer(w, h float64) *Renderer {
+	return NewScaled(w, h, 1)
+}
+
+// NewScaled sets the document size to scale pixels per court unit; the
+// viewBox stays in court units.
+func NewScaled(w, h, scale float64) *Renderer {
+	r := &Renderer{
+		w:      w,
+		h:      h,
+		width:  int(math.Round(w * scale)),
+		height: int(math.Round(h * scale)),
+	}
+	r.reset()
+	return r
+}
+
+// WriteTo writes the frame drawn since the last Clear as a standalone document.
+func (r *Renderer) WriteTo(w io.Writer) (int64, error) {
+	var doc bytes.Buffer
+	fmt.Fprintf(&doc, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %s %s">`+"\n",
+		r.width, r.height, num(r.w), num(r.h))
+	doc.Write(r.body.Bytes())
+	doc.WriteString("</svg>\n")
+
+	return doc.WriteTo(w)
+}
+
+// Bytes is the document of the current frame.
+func (r *Renderer) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = r.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// Clear starts a frame: it drops the elements drawn so far, resets the
+// transform and fills the court.
+func (r *Renderer) Clear(c ports.Color) {
+	r.reset()
+	r.body.Reset()
+
+	c = raster.Blend(ports.RGB(0, 0, 0), c)
+	fmt.Fprintf(&r.body, `<rect width="%s" height="%s" fill="%s"/>`+"\n", num(r.w), num(r.h), hex(c))
+}
+
+func (r *Renderer) reset() {
+	r.Reset(transform.Identity)
+}
+
+func (r *Renderer) DrawRect(x, y, w, h float64, style ports.Style) {
+	if !visible(style.Fill) && !visible(style.Stroke) {
+		return
+	}
+
+	r.open("rect")
+	r.attr("x", x)
+	r.attr("y", y)
+	r.attr("width", w)
+	r.attr("height", h)
+	r.close(style)
+}
+
+func (r *Renderer) DrawCircle(x, y, radius float64, style ports.Style) {
+	if !visible(style.Fill) && !visible(style.Stroke) {
+		return
+	}
+
+	r.open("circle")
+	r.attr("cx", x)
+	r.attr("cy", y)
+	r.attr("r", radius)
+	r.close(style)
+}
+
+func (r *Renderer) DrawPolygon(points []ports.Point, style ports.Style) {
+	if len(points) < 2 || (!visible(style.Fill) && !visible(style.Stroke)) {
+		return
+	}
+
+	r.open("polygon")
+	r.body.WriteString(` points="`)
+	for i, p := range points {
+		if i > 0 {
+			r.body.WriteByte(' ')
+		}
+		r.body.WriteString(num(p.X) + "," + num(p.Y))
+	}
+	r.body.WriteByte('"')
+	r.close(style)
+}
+
+func (r *Renderer) DrawLine(x1, y1, x2, y2 float64, style ports.Style) {
+	if !visible(style.Stroke) {
+		return
+	}
+
+	r.open("line")
+	r.attr("x1", x1)
+	r.attr("y1", y1)
+	r.attr("x2", x2)
+	r.attr("y2", y2)
+	r.close(ports.Style{Stroke: style.Stroke, LineWidth: style.LineWidth})
+}
+
+// DrawImage embeds img as a PNG data URI.
+func (r *Renderer) DrawImage(img image.Image, x, y, w, h float64) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		return
+	}
+
+	r.open("image")
+	r.attr("x", x)
+	r.attr("y", y)
+	r.attr("width", w)
+	r.attr("height", h)
+	r.body.WriteString(` preserveAspectRatio="none" href="data:image/png;base64,`)
+	r.body.WriteString(base64.StdEncoding.EncodeToString(data.Bytes()))
+	r.body.WriteString(`"/>` + "\n")
+}
+
+func (r *Renderer) DrawText(text string, x, y float64, style ports.TextStyle) {
+	if !visible(style.Color) || text == "" {
+		return
+	}
+
+	r.open("text")
+	r.attr("x", x)
+	r.attr("y", y)
+	r.attr("font-size", style.Font.Size)
+	r.attr("textLength", r.MeasureText(text, style.Font))
+	r.body.WriteString(` font-family="`)
+	xml.EscapeText(&r.body, []byte(style.Font.Family))
+	r.body.WriteByte('"')
+	if style.Font.Bold {
+		r.body.WriteString(` font-weight="bold"`)
+	}
+	switch style.Align {
+	case ports.AlignCenter:
+		r.body.WriteString(` text-anchor="middle"`)
+	case ports.AlignRight:
+		r.body.WriteString(` text-anchor="end"`)
+	}
+	r.paint("fill", style.Color)
+	r.body.WriteString(` xml:space="preserve">`)
+	xml.EscapeText(&r.body, []byte(text))
+	r.body.WriteString("</text>\n")
+}
+
+// MeasureText is the advance of the monospaced bitmap font, the same as the
+// raster renderer, so both lay out the HUD alike.
+func (r *Renderer) MeasureText(text string, font ports.Font) float64 {
+	return raster.TextWidth(text, font)
+}
+
+// open starts an element with the transform and the alpha in effect.
+func (r *Renderer) open(name string) {
+	r.body.WriteString("<" + name)
+
+	// the SVG matrix() is laid out like the canvas setTransform
+	if m := r.Matrix(); m != transform.Identity {
+		if m.A == 1 && m.B == 0 && m.C == 0 && m.D == 1 {
+			fmt.Fprintf(&r.body, ` transform="translate(%s %s)"`, num(m.E), num(m.F))
+		} else {
+			fmt.Fprintf(&r.body, ` transform="matrix(%s %s %s %s %s %s)"`,
+				num(m.A), num(m.B), num(m.C), num(m.D), num(m.E), num(m.F))
+		}
+	}
+	if alpha := r.Alpha(); alpha < 1 {
+		r.body.WriteString(` opacity="` + num(alpha) + `"`)
+	}
+}
+
+// attr writes a numeric attribute.
+func (r *Renderer) attr(name string, v float64) {
+	r.body.WriteString(" " + name + `="` + num(v) + `"`)
+}
+
+// close writes the fill and the stroke of style and ends the element.
+func (r *Renderer) close(style ports.Style) {
+	if visible(style.Fill) {
+		r.paint("fill", style.Fill)
+	} else {
+		r.body.WriteString(` fill="none"`)
+	}
+
+	if visible(style.Stroke) {
+		r.paint("stroke", style.Stroke)
+		r.body.WriteString(` stroke-width="` + num(lineWidth(style.LineWidth)) + `"`)
+	}
+	r.body.WriteString("/>\n")
+}
+
+// paint writes a color attribute, with its opacity when translucent.
+func (r *Renderer) paint(attr string, c ports.Color) {
+	r.body.WriteString(" " + attr + `="` + hex(c) + `"`)
+	if c.A < 255 {
+		r.body.WriteString(" " + attr + `-opacity="` + num(float64(c.A)/255) + `"`)
+	}
+}
+
+// lineWidth defaults to 1 like the canvas.
+func lineWidth(w float64) float64 {
+	if w <= 0 {
+		return 1
+	}
+
+	return w
+}
+
+func hex(c ports.Color) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// num formats v with at most two decimals and without a negative zero.
+func num(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		v = 0
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func visible(c ports.Color) bool {
+	return c.A > 0
+}
@@ -0,0 +1,142 @@
+package svg
+
+import (
+	"encoding/xml"
+	"image"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+var (
+	white = ports.RGB(255, 255, 255)
+	red   = ports.RGB(255, 0, 0)
+)
+
+func TestRendererElements(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(r *Renderer)
+		want string
+	}{
+		{
+			name: "Filled rect",
+			draw: func(r *Renderer) { r.DrawRect(10, 20, 30, 40, ports.Style{Fill: red}) },
+			want: `<rect x="10" y="20" width="30" height="40" fill="#ff0000"/>`,
+		},
+		{
+			name: "Stroked circle",
+			draw: func(r *Renderer) { r.DrawCircle(50, 60, 7.5, ports.Style{Stroke: white, LineWidth: 2}) },
+			want: `<circle cx="50" cy="60" r="7.5" fill="none" stroke="#ffffff" stroke-width="2"/>`,
+		},
+		{
+			name: "Line ignores the fill and defaults to 1 unit",
+			draw: func(r *Renderer) { r.DrawLine(0, 0, 100, 0, ports.Style{Fill: red, Stroke: white}) },
+			want: `<line x1="0" y1="0" x2="100" y2="0" fill="none" stroke="#ffffff" stroke-width="1"/>`,
+		},
+		{
+			name: "Polygon rounded to two decimals",
+			draw: func(r *Renderer) {
+				r.DrawPolygon([]ports.Point{{X: 0, Y: 0}, {X: 1.234, Y: 0}, {X: 0, Y: 1.0 / 3}}, ports.Style{Fill: white})
+			},
+			want: `<polygon points="0,0 1.23,0 0,0.33" fill="#ffffff"/>`,
+		},
+		{
+			name: "Translucent color",
+			draw: func(r *Renderer) { r.DrawRect(0, 0, 1, 1, ports.Style{Fill: ports.Color{R: 255, A: 51}}) },
+			want: `<rect x="0" y="0" width="1" height="1" fill="#ff0000" fill-opacity="0.2"/>`,
+		},
+		{
+			name: "Translate and alpha",
+			draw: func(r *Renderer) {
+				r.Save()
+				r.Translate(5, -3)
+				r.SetAlpha(0.5)
+				r.DrawRect(0, 0, 1, 1, ports.Style{Fill: red})
+				r.Restore()
+			},
+			want: `<rect transform="translate(5 -3)" opacity="0.5" x="0" y="0" width="1" height="1" fill="#ff0000"/>`,
+		},
+		{
+			name: "Rotation is a matrix",
+			draw: func(r *Renderer) {
+				r.Translate(10, 10)
+				r.Rotate(math.Pi / 2)
+				r.DrawRect(0, 0, 1, 1, ports.Style{Fill: red})
+			},
+			want: `<rect transform="matrix(0 1 -1 0 10 10)" x="0" y="0" width="1" height="1" fill="#ff0000"/>`,
+		},
+		{
+			name: "Text is escaped and aligned",
+			draw: func(r *Renderer) {
+				r.DrawText("A & <B>", 400, 300, ports.TextStyle{
+					Font:  ports.Font{Family: "Courier New", Size: 20, Bold: true},
+					Color: white,
+					Align: ports.AlignCenter,
+				})
+			},
+			want: `<text x="400" y="300" font-size="20" textLength="84" font-family="Courier New" font-weight="bold" text-anchor="middle" fill="#ffffff" xml:space="preserve">A &amp; &lt;B&gt;</text>`,
+		},
+		{
+			name: "Invisible shapes are skipped",
+			draw: func(r *Renderer) {
+				r.DrawRect(0, 0, 1, 1, ports.Style{})
+				r.DrawLine(0, 0, 1, 1, ports.Style{Fill: red})
+				r.DrawText("x", 0, 0, ports.TextStyle{})
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(800, 600)
+			r.Clear(ports.RGB(0, 0, 0))
+			tt.draw(r)
+
+			lines := strings.Split(strings.TrimSpace(string(r.Bytes())), "\n")
+			got := strings.Join(lines[2:len(lines)-1], "\n")
+			if got != tt.want {
+				t.Errorf("elements =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendererDocument(t *testing.T) {
+	r := NewScaled(800, 600, 0.5)
+	r.Clear(ports.Color{R: 255, A: 128}) // blended over black
+	r.DrawImage(image.NewRGBA(image.Rect(0, 0, 2, 2)), 0, 0, 10, 10)
+
+	doc := string(r.Bytes())
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="400" height="300" viewBox="0 0 800 600">`,
+		`<rect width="800" height="600" fill="#800000"/>`,
+		`href="data:image/png;base64,`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document does not contain %q:\n%s", want, doc)
+		}
+	}
+
+	if err := xml.Unmarshal([]byte(doc), new(struct{})); err != nil {
+		t.Errorf("document is not well-formed: %v", err)
+	}
+
+	// Clear starts a new frame
+	r.Clear(ports.RGB(0, 0, 0))
+	if strings.Contains(string(r.Bytes()), "<image") {
+		t.Error("Clear() kept the previous frame")
+	}
+}
+
+func TestRendererMeasureText(t *testing.T) {
+	r := NewRenderer(800, 600)
+	font := ports.Font{Family: "monospace", Size: 10}
+
+	if got := r.MeasureText("abcd", font); got != 24 {
+		t.Errorf("MeasureText() = %v, want 24", got)
+	}
+}
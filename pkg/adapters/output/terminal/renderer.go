@@ -0,0 +1,226 @@
+package terminal
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/raster"
+)
+
+// halfBlock paints the upper half of a cell in the foreground color and the
+// lower half in the background color, so each cell holds two square-ish pixels.
+const halfBlock = '▀'
+
+// textMiddle is where a line of text sits above its baseline, as a fraction of
+// the font size (the middle of a 0.8 ascent).
+const textMiddle = 0.4
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l\x1b[2J"
+	leaveScreen = "\x1b[0m\x1b[?25h\x1b[?1049l"
+	resetColors = "\x1b[0m"
+)
+
+// Cell is one character cell: Rune drawn in FG over BG.
+type Cell struct {
+	Rune   rune
+	FG, BG ports.Color
+}
+
+// Screen rasterizes the logical court (w x h units) into cols x rows character
+// cells with 24-bit ANSI colors. Shapes are drawn by the raster renderer on a
+// grid of two pixels per cell (half blocks) and text is laid over it one rune
+// per cell. Frames are double-buffered: Flush writes only the cells that
+// changed since the last one.
+type Screen struct {
+	*raster.Renderer // cols x rows*2 pixels
+
+	w, h       float64
+	cols, rows int
+
+	text  []Cell // overlay; a zero Rune is no text
+	back  []Cell
+	front []Cell
+	full  bool // the next Flush redraws every cell
+
+	buf []byte
+}
+
+func NewScreen(w, h float64, cols, rows int) *Screen {
+	s := &Screen{Renderer: raster.NewRenderer(w, h, 1, 2), w: w, h: h}
+	s.Resize(cols, rows)
+	return s
+}
+
+// Resize changes the grid to cols x rows cells; the next Flush redraws everything.
+func (s *Screen) Resize(cols, rows int) {
+	cols, rows = max(cols, 1), max(rows, 1)
+	if cols == s.cols && rows == s.rows {
+		return
+	}
+
+	s.cols, s.rows = cols, rows
+	s.Renderer.Resize(cols, rows*2)
+	s.text = make([]Cell, cols*rows)
+	s.back = make([]Cell, cols*rows)
+	s.front = make([]Cell, cols*rows)
+	s.full = true
+}
+
+// Size is the grid size in cells.
+func (s *Screen) Size() (cols, rows int) {
+	return s.cols, s.rows
+}
+
+// CellSize is the size of one cell in court units.
+func (s *Screen) CellSize() (w, h float64) {
+	return s.w / float64(s.cols), s.h / float64(s.rows)
+}
+
+// Open switches to the alternate screen and hides the cursor.
+func (s *Screen) Open(w io.Writer) error {
+	s.full = true
+	_, err := io.WriteString(w, enterScreen)
+	return err
+}
+
+// Close restores the colors, the cursor and the main screen.
+func (s *Screen) Close(w io.Writer) error {
+	_, err := io.WriteString(w, leaveScreen)
+	return err
+}
+
+// Clear starts a frame: it resets the court transform, drops the text and fills the background.
+func (s *Screen) Clear(c ports.Color) {
+	s.Renderer.Clear(c)
+	for i := range s.text {
+		s.text[i] = Cell{}
+	}
+}
+
+// DrawText lays the runes on the cell row around the middle of the text, one
+// rune per cell; text stays on top of the shapes of the frame.
+func (s *Screen) DrawText(text string, x, y float64, style ports.TextStyle) {
+	px, py := s.ToPixel(x, y-style.Font.Size*textMiddle)
+	row := int(math.Floor(py / 2))
+	if row < 0 || row >= s.rows {
+		return
+	}
+
+	n := float64(utf8.RuneCountInString(text))
+	switch style.Align {
+	case ports.AlignCenter:
+		px -= n / 2
+	case ports.AlignRight:
+		px -= n
+	}
+
+	fg := style.Color
+	fg.A = uint8(math.Round(float64(fg.A) * s.Alpha()))
+
+	col := int(math.Round(px))
+	for _, r := range text {
+		if col >= 0 && col < s.cols {
+			s.text[row*s.cols+col] = Cell{Rune: r, FG: fg}
+		}
+		col++
+	}
+}
+
+// MeasureText is the width of one cell per rune; the terminal font has one size.
+func (s *Screen) MeasureText(text string, font ports.Font) float64 {
+	w, _ := s.CellSize()
+	return float64(utf8.RuneCountInString(text)) * w
+}
+
+// Cell composes the cell at col, row of the current frame.
+func (s *Screen) Cell(col, row int) Cell {
+	top, bottom := s.At(col, row*2), s.At(col, row*2+1)
+
+	if t := s.text[row*s.cols+col]; t.Rune != 0 {
+		bg := mix(top, bottom)
+		return Cell{Rune: t.Rune, FG: raster.Blend(bg, t.FG), BG: bg}
+	}
+	if top == bottom {
+		return Cell{Rune: ' ', FG: top, BG: bottom}
+	}
+
+	return Cell{Rune: halfBlock, FG: top, BG: bottom}
+}
+
+// Flush writes the cells that changed since the previous Flush (every cell after
+// Open or Resize), moving the cursor only over gaps and setting a color only
+// when it differs from the last one written.
+func (s *Screen) Flush(w io.Writer) error {
+	out := s.buf[:0]
+	cursor := -1 // cell index the cursor is on, -1 when unknown
+	var fg, bg ports.Color
+	fgSet, bgSet := false, false
+
+	for i := range s.back {
+		cell := s.Cell(i%s.cols, i/s.cols)
+		s.back[i] = cell
+		if !s.full && cell == s.front[i] {
+			continue
+		}
+
+		if cursor != i {
+			out = append(out, "\x1b["...)
+			out = strconv.AppendInt(out, int64(i/s.cols+1), 10)
+			out = append(out, ';')
+			out = strconv.AppendInt(out, int64(i%s.cols+1), 10)
+			out = append(out, 'H')
+		}
+		// a blank cell shows only its background
+		if cell.Rune != ' ' && (!fgSet || cell.FG != fg) {
+			out = appendColor(out, 38, cell.FG)
+			fg, fgSet = cell.FG, true
+		}
+		if !bgSet || cell.BG != bg {
+			out = appendColor(out, 48, cell.BG)
+			bg, bgSet = cell.BG, true
+		}
+		out = utf8.AppendRune(out, cell.Rune)
+
+		// the cursor stays on the last column instead of wrapping
+		cursor = i + 1
+		if i%s.cols == s.cols-1 {
+			cursor = -1
+		}
+	}
+
+	if bgSet {
+		out = append(out, resetColors...)
+	}
+	s.buf = out
+	copy(s.front, s.back)
+	s.full = false
+
+	if len(out) == 0 {
+		return nil
+	}
+	_, err := w.Write(out)
+	return err
+}
+
+func appendColor(out []byte, layer int, c ports.Color) []byte {
+	out = append(out, "\x1b["...)
+	out = strconv.AppendInt(out, int64(layer), 10)
+	out = append(out, ";2;"...)
+	out = strconv.AppendInt(out, int64(c.R), 10)
+	out = append(out, ';')
+	out = strconv.AppendInt(out, int64(c.G), 10)
+	out = append(out, ';')
+	out = strconv.AppendInt(out, int64(c.B), 10)
+	return append(out, 'm')
+}
+
+// mix is the average of two opaque colors: the background behind a text cell.
+func mix(a, b ports.Color) ports.Color {
+	return ports.RGB(uint8((int(a.R)+int(b.R))/2), uint8((int(a.G)+int(b.G))/2), uint8((int(a.B)+int(b.B))/2))
+}
+
+var _ ports.Renderer = (*Screen)(nil)
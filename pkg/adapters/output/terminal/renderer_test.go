@@ -0,0 +1,207 @@
+package terminal
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+var (
+	black = ports.RGB(0, 0, 0)
+	white = ports.RGB(255, 255, 255)
+	red   = ports.RGB(255, 0, 0)
+)
+
+// newTestScreen maps the 800x600 court on 8x3 cells: 8x6 pixels of 100x100 units.
+func newTestScreen() *Screen {
+	s := NewScreen(800, 600, 8, 3)
+	s.Clear(black)
+	return s
+}
+
+func TestScreenRasterize(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(s *Screen)
+		col  int
+		row  int
+		want Cell
+	}{
+		{
+			name: "Background",
+			draw: func(s *Screen) {},
+			want: Cell{Rune: ' ', FG: black, BG: black},
+		},
+		{
+			name: "Rect on the top pixel",
+			draw: func(s *Screen) { s.DrawRect(0, 0, 200, 100, ports.Style{Fill: white}) },
+			col:  1,
+			want: Cell{Rune: halfBlock, FG: white, BG: black},
+		},
+		{
+			name: "Rect on both pixels",
+			draw: func(s *Screen) { s.DrawRect(0, 200, 100, 200, ports.Style{Fill: white}) },
+			row:  1,
+			want: Cell{Rune: ' ', FG: white, BG: white},
+		},
+		{
+			name: "Translated rect",
+			draw: func(s *Screen) {
+				s.Translate(300, 100)
+				s.DrawRect(0, 0, 100, 100, ports.Style{Fill: white})
+			},
+			col:  3,
+			want: Cell{Rune: halfBlock, FG: black, BG: white},
+		},
+		{
+			name: "Restore drops the transform",
+			draw: func(s *Screen) {
+				s.Save()
+				s.Translate(300, 0)
+				s.Restore()
+				s.DrawRect(0, 0, 100, 100, ports.Style{Fill: white})
+			},
+			want: Cell{Rune: halfBlock, FG: white, BG: black},
+		},
+		{
+			name: "Half alpha blends over the background",
+			draw: func(s *Screen) {
+				s.SetAlpha(0.5)
+				s.DrawRect(0, 0, 100, 200, ports.Style{Fill: white})
+			},
+			want: Cell{Rune: ' ', FG: ports.RGB(128, 128, 128), BG: ports.RGB(128, 128, 128)},
+		},
+		{
+			name: "Circle smaller than a pixel lights its center",
+			draw: func(s *Screen) { s.DrawCircle(250, 150, 10, ports.Style{Fill: red}) },
+			col:  2,
+			want: Cell{Rune: halfBlock, FG: black, BG: red},
+		},
+		{
+			name: "Polygon",
+			draw: func(s *Screen) {
+				s.DrawPolygon([]ports.Point{{X: 400, Y: 0}, {X: 500, Y: 0}, {X: 500, Y: 200}, {X: 400, Y: 200}}, ports.Style{Fill: red})
+			},
+			col:  4,
+			want: Cell{Rune: ' ', FG: red, BG: red},
+		},
+		{
+			name: "Line",
+			draw: func(s *Screen) { s.DrawLine(750, 0, 750, 600, ports.Style{Stroke: white}) },
+			col:  7,
+			row:  2,
+			want: Cell{Rune: ' ', FG: white, BG: white},
+		},
+		{
+			name: "Image",
+			draw: func(s *Screen) {
+				img := image.NewNRGBA(image.Rect(0, 0, 1, 2))
+				img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+				img.Set(0, 1, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+				s.DrawImage(img, 100, 0, 100, 200)
+			},
+			col:  1,
+			want: Cell{Rune: halfBlock, FG: red, BG: white},
+		},
+		{
+			name: "Centered text",
+			draw: func(s *Screen) {
+				s.DrawRect(0, 200, 800, 200, ports.Style{Fill: red})
+				s.DrawText("HI", 400, 340, ports.TextStyle{Font: ports.Font{Size: 100}, Color: white, Align: ports.AlignCenter})
+			},
+			col:  4,
+			row:  1,
+			want: Cell{Rune: 'I', FG: white, BG: red},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScreen()
+			tt.draw(s)
+
+			if got := s.Cell(tt.col, tt.row); got != tt.want {
+				t.Errorf("Cell(%d, %d) = %+v, want %+v", tt.col, tt.row, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenMeasureText(t *testing.T) {
+	s := newTestScreen()
+
+	if got := s.MeasureText("SCORE", ports.Font{Size: 20}); got != 500 {
+		t.Errorf("MeasureText() = %v, want 500", got)
+	}
+	if w, h := s.CellSize(); w != 100 || h != 200 {
+		t.Errorf("CellSize() = %v, %v, want 100, 200", w, h)
+	}
+}
+
+func TestScreenFlush(t *testing.T) {
+	s := newTestScreen()
+	var out bytes.Buffer
+
+	if err := s.Flush(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(out.String(), " "); got != 8*3 {
+		t.Errorf("first Flush wrote %d cells, want %d", got, 8*3)
+	}
+
+	// same frame: nothing to write
+	out.Reset()
+	s.Clear(black)
+	if err := s.Flush(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("unchanged frame wrote %q, want nothing", out.String())
+	}
+
+	// one changed cell: one cursor move, its colors and the cell
+	out.Reset()
+	s.Clear(black)
+	s.DrawRect(500, 400, 100, 100, ports.Style{Fill: white})
+	if err := s.Flush(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b[3;6H\x1b[38;2;255;255;255m\x1b[48;2;0;0;0m▀\x1b[0m"
+	if out.String() != want {
+		t.Errorf("Flush() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestScreenResizeRedraws(t *testing.T) {
+	s := newTestScreen()
+	var out bytes.Buffer
+	_ = s.Flush(&out)
+
+	s.Resize(4, 2)
+	s.Clear(black)
+	out.Reset()
+	_ = s.Flush(&out)
+
+	if got := strings.Count(out.String(), " "); got != 4*2 {
+		t.Errorf("Flush after Resize wrote %d cells, want %d", got, 4*2)
+	}
+	if cols, rows := s.Size(); cols != 4 || rows != 2 {
+		t.Errorf("Size() = %d, %d, want 4, 2", cols, rows)
+	}
+}
+
+func TestScreenOpenClose(t *testing.T) {
+	s := newTestScreen()
+	var out bytes.Buffer
+
+	_ = s.Open(&out)
+	_ = s.Close(&out)
+
+	if out.String() != enterScreen+leaveScreen {
+		t.Errorf("Open/Close wrote %q", out.String())
+	}
+}
@@ -0,0 +1,90 @@
+// Package transform keeps the transform and the alpha of the renderers that
+// draw the shapes themselves, the way a canvas keeps them with Save and Restore.
+package transform
+
+import (
+	"math"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// Matrix is a 2D affine transform, laid out like the canvas setTransform.
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+var Identity = Matrix{A: 1, D: 1}
+
+// Apply maps x, y through the transform.
+func (m Matrix) Apply(x, y float64) ports.Point {
+	return ports.Point{X: m.A*x + m.C*y + m.E, Y: m.B*x + m.D*y + m.F}
+}
+
+// Scale is the length of a unit vector after the transform.
+func (m Matrix) Scale() float64 {
+	return math.Sqrt(math.Abs(m.A*m.D - m.B*m.C))
+}
+
+type drawState struct {
+	m     Matrix
+	alpha float64
+}
+
+// State is the transform and the alpha in effect, with the stack of Save.
+// Embedded in a renderer, it provides the Save, Restore, Translate, Scale,
+// Rotate and SetAlpha of ports.Renderer.
+type State struct {
+	state drawState
+	stack []drawState
+}
+
+// Reset sets the transform to m and the alpha to 1, and drops the saved states.
+func (s *State) Reset(m Matrix) {
+	s.state = drawState{m: m, alpha: 1}
+	s.stack = s.stack[:0]
+}
+
+// Matrix is the transform in effect.
+func (s *State) Matrix() Matrix {
+	return s.state.m
+}
+
+// Alpha is the global alpha in effect.
+func (s *State) Alpha() float64 {
+	return s.state.alpha
+}
+
+func (s *State) Save() {
+	s.stack = append(s.stack, s.state)
+}
+
+func (s *State) Restore() {
+	if len(s.stack) == 0 {
+		return
+	}
+
+	s.state = s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+}
+
+func (s *State) Translate(x, y float64) {
+	m := &s.state.m
+	m.E += m.A*x + m.C*y
+	m.F += m.B*x + m.D*y
+}
+
+func (s *State) Scale(x, y float64) {
+	m := &s.state.m
+	m.A, m.B = m.A*x, m.B*x
+	m.C, m.D = m.C*y, m.D*y
+}
+
+func (s *State) Rotate(angle float64) {
+	m := &s.state.m
+	sin, cos := math.Sincos(angle)
+	m.A, m.B, m.C, m.D = m.A*cos+m.C*sin, m.B*cos+m.D*sin, m.C*cos-m.A*sin, m.D*cos-m.B*sin
+}
+
+func (s *State) SetAlpha(alpha float64) {
+	s.state.alpha = math.Max(0, math.Min(1, alpha))
+}
@@ -0,0 +1,58 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+func TestState(t *testing.T) {
+	var s State
+	s.Reset(Matrix{A: 2, D: 2})
+
+	s.Save()
+	s.Translate(10, 20)
+	s.Rotate(math.Pi / 2)
+	s.Scale(3, 1)
+	s.SetAlpha(0.5)
+
+	p := s.Matrix().Apply(1, 0)
+	assert.InDelta(t, 20, p.X, 1e-9)
+	assert.InDelta(t, 46, p.Y, 1e-9)
+	assert.InDelta(t, 2*math.Sqrt(3), s.Matrix().Scale(), 1e-9)
+	assert.Equal(t, 0.5, s.Alpha())
+
+	s.Restore()
+	assert.Equal(t, ports.Point{X: 2, Y: 4}, s.Matrix().Apply(1, 2))
+	assert.Equal(t, 1.0, s.Alpha())
+
+	// an unbalanced Restore keeps the state
+	s.Restore()
+	assert.Equal(t, Matrix{A: 2, D: 2}, s.Matrix())
+}
+
+func TestSetAlphaClamps(t *testing.T) {
+	var s State
+	s.Reset(Identity)
+
+	s.SetAlpha(2)
+	assert.Equal(t, 1.0, s.Alpha())
+	s.SetAlpha(-1)
+	assert.Equal(t, 0.0, s.Alpha())
+}
+
+func TestReset(t *testing.T) {
+	var s State
+	s.Reset(Identity)
+	s.Translate(5, 5)
+	s.Save()
+	s.SetAlpha(0.2)
+
+	s.Reset(Identity)
+	s.Restore()
+	assert.Equal(t, Identity, s.Matrix())
+	assert.Equal(t, 1.0, s.Alpha())
+}
@@ -0,0 +1,153 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"errors"
+	"math"
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+var ErrNoWebAudio = errors.New("web audio is not supported")
+
+// voice is one oscillator of a synthesized effect, ramping from freq to slide Hz
+// while its envelope decays from gain to silence.
+type voice struct {
+	wave  string // OscillatorNode type
+	freq  float64
+	slide float64
+	start float64 // seconds after the effect starts
+	dur   float64
+	gain  float64
+}
+
+// effectVoices synthesizes every effect; nothing is loaded from the network.
+var effectVoices = map[ports.SoundEffect][]voice{
+	ports.SoundPaddleHit: {
+		{wave: "square", freq: 440, slide: 660, dur: 0.06, gain: 0.4},
+	},
+	ports.SoundWallBounce: {
+		{wave: "triangle", freq: 300, slide: 240, dur: 0.05, gain: 0.5},
+	},
+	ports.SoundLifeLost: {
+		{wave: "sawtooth", freq: 400, slide: 110, dur: 0.4, gain: 0.3},
+	},
+	ports.SoundLevelUp: {
+		{wave: "square", freq: 523.25, slide: 523.25, dur: 0.08, gain: 0.3},
+		{wave: "square", freq: 659.25, slide: 659.25, start: 0.08, dur: 0.08, gain: 0.3},
+		{wave: "square", freq: 783.99, slide: 783.99, start: 0.16, dur: 0.08, gain: 0.3},
+		{wave: "square", freq: 1046.5, slide: 1046.5, start: 0.24, dur: 0.2, gain: 0.3},
+	},
+	ports.SoundGameOver: {
+		{wave: "triangle", freq: 392, slide: 392, dur: 0.18, gain: 0.5},
+		{wave: "triangle", freq: 329.63, slide: 329.63, start: 0.18, dur: 0.18, gain: 0.5},
+		{wave: "triangle", freq: 261.63, slide: 130.81, start: 0.36, dur: 0.6, gain: 0.5},
+	},
+}
+
+// silence is the end of an envelope; exponential ramps cannot reach zero.
+const silence = 0.0001
+
+// WebAudio plays synthesized effects through an AudioContext and a master gain.
+type WebAudio struct {
+	ctx    js.Value
+	master js.Value
+	volume float64
+	muted  bool
+}
+
+// NewWebAudio creates the audio context; browsers keep it suspended until the
+// first click or key press, so it is resumed from those events.
+func NewWebAudio() (*WebAudio, error) {
+	ctor := js.Global().Get("AudioContext")
+	if ctor.IsUndefined() {
+		ctor = js.Global().Get("webkitAudioContext")
+	}
+	if ctor.IsUndefined() {
+		return nil, ErrNoWebAudio
+	}
+
+	ctx := ctor.New()
+	a := &WebAudio{ctx: ctx, master: ctx.Call("createGain"), volume: 1}
+	a.master.Call("connect", ctx.Get("destination"))
+	a.apply()
+
+	resume := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if a.ctx.Get("state").String() == "suspended" {
+			a.ctx.Call("resume")
+		}
+		return nil
+	})
+	for _, event := range []string{"pointerdown", "keydown", "touchend"} {
+		js.Global().Call("addEventListener", event, resume)
+	}
+
+	return a, nil
+}
+
+func (a *WebAudio) Play(effect ports.SoundEffect) {
+	if a.muted || a.ctx.Get("state").String() != "running" {
+		return
+	}
+
+	now := a.ctx.Get("currentTime").Float()
+	for _, v := range effectVoices[effect] {
+		a.playVoice(v, now+v.start)
+	}
+}
+
+func (a *WebAudio) SetVolume(volume float64) {
+	a.volume = clampVolume(volume)
+	a.apply()
+}
+
+func (a *WebAudio) SetMuted(muted bool) {
+	a.muted = muted
+	a.apply()
+}
+
+func (a *WebAudio) playVoice(v voice, at float64) {
+	end := at + v.dur
+
+	osc := a.ctx.Call("createOscillator")
+	osc.Set("type", v.wave)
+	freq := osc.Get("frequency")
+	freq.Call("setValueAtTime", v.freq, at)
+	if v.slide != v.freq {
+		freq.Call("exponentialRampToValueAtTime", v.slide, end)
+	}
+
+	env := a.ctx.Call("createGain")
+	gain := env.Get("gain")
+	gain.Call("setValueAtTime", v.gain, at)
+	gain.Call("exponentialRampToValueAtTime", silence, end)
+
+	osc.Call("connect", env)
+	env.Call("connect", a.master)
+	osc.Call("start", at)
+	osc.Call("stop", end)
+}
+
+func (a *WebAudio) apply() {
+	a.master.Get("gain").Set("value", masterGain(a.volume, a.muted))
+}
+
+func masterGain(volume float64, muted bool) float64 {
+	if muted {
+		return 0
+	}
+
+	return clampVolume(volume)
+}
+
+func clampVolume(volume float64) float64 {
+	if math.IsNaN(volume) {
+		return 0
+	}
+
+	return math.Max(0, math.Min(1, volume))
+}
+
+var _ ports.AudioPlayer = (*WebAudio)(nil)
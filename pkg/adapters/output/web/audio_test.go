@@ -0,0 +1,64 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"math"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+func TestEffectVoices(t *testing.T) {
+	effects := []ports.SoundEffect{
+		ports.SoundPaddleHit,
+		ports.SoundWallBounce,
+		ports.SoundLifeLost,
+		ports.SoundLevelUp,
+		ports.SoundGameOver,
+	}
+
+	for _, effect := range effects {
+		t.Run(effect.String(), func(t *testing.T) {
+			voices := effectVoices[effect]
+			if len(voices) == 0 {
+				t.Fatalf("effect %v has no voices", effect)
+			}
+
+			for i, v := range voices {
+				if v.freq <= 0 || v.slide <= 0 {
+					t.Errorf("voice %d: frequencies %v -> %v must be positive for exponential ramps", i, v.freq, v.slide)
+				}
+				if v.dur <= 0 || v.start < 0 {
+					t.Errorf("voice %d: start %v, duration %v", i, v.start, v.dur)
+				}
+				if v.gain <= silence || v.gain > 1 {
+					t.Errorf("voice %d: gain %v out of (silence, 1]", i, v.gain)
+				}
+			}
+		})
+	}
+}
+
+func TestMasterGain(t *testing.T) {
+	tests := []struct {
+		name   string
+		volume float64
+		muted  bool
+		want   float64
+	}{
+		{name: "Volume", volume: 0.5, want: 0.5},
+		{name: "Muted", volume: 0.5, muted: true, want: 0},
+		{name: "Above range", volume: 2, want: 1},
+		{name: "Below range", volume: -1, want: 0},
+		{name: "NaN", volume: math.NaN(), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := masterGain(tt.volume, tt.muted); got != tt.want {
+				t.Errorf("masterGain(%v, %v) = %v, want %v", tt.volume, tt.muted, got, tt.want)
+			}
+		})
+	}
+}
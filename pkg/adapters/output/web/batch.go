@@ -0,0 +1,267 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"encoding/binary"
+	"image"
+	"math"
+	"strings"
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/record"
+)
+
+// replayMethod is installed on the 2D context and draws a whole encoded frame.
+const replayMethod = "squashReplay"
+
+// replaySource decodes the frame written by Batch.encode: the numbers of every
+// command in a Float64Array and the colors, fonts and texts in one string,
+// separated by NUL. It makes the same context calls as Canvas, skipping the
+// same redundant property sets.
+const replaySource = `
+const f = new Float64Array(bytes.buffer, 0, n);
+const s = strs.split("\u0000");
+const align = ["left", "center", "right"];
+let st = {}, stack = [];
+const set = (key, v) => { if (st[key] !== v) { this[key] = v; st[key] = v; } };
+const stroke = (color, width) => { set("strokeStyle", s[color]); set("lineWidth", width); };
+const paint = (fill, color, width) => {
+	if (fill >= 0) { set("fillStyle", s[fill]); this.fill(); }
+	if (color >= 0) { stroke(color, width); this.stroke(); }
+};
+let i = 0;
+while (i < n) {
+	switch (f[i++]) {
+	case 0: { // clear
+		const scale = f[i++], w = f[i++], h = f[i++], erase = f[i++], fill = f[i++];
+		this.setTransform(scale, 0, 0, scale, 0, 0);
+		if (erase) this.clearRect(0, 0, w, h);
+		if (fill >= 0) { set("fillStyle", s[fill]); this.fillRect(0, 0, w, h); }
+		break;
+	}
+	case 1: { // rect
+		const x = f[i++], y = f[i++], w = f[i++], h = f[i++], fill = f[i++], color = f[i++], width = f[i++];
+		if (fill >= 0) { set("fillStyle", s[fill]); this.fillRect(x, y, w, h); }
+		if (color >= 0) { stroke(color, width); this.strokeRect(x, y, w, h); }
+		break;
+	}
+	case 2: // circle
+		this.beginPath();
+		this.arc(f[i++], f[i++], f[i++], 0, 2 * Math.PI);
+		paint(f[i++], f[i++], f[i++]);
+		break;
+	case 3: { // line
+		const x1 = f[i++], y1 = f[i++], x2 = f[i++], y2 = f[i++];
+		stroke(f[i++], f[i++]);
+		this.beginPath();
+		this.moveTo(x1, y1);
+		this.lineTo(x2, y2);
+		this.stroke();
+		break;
+	}
+	case 4: { // polygon
+		const count = f[i++];
+		this.beginPath();
+		this.moveTo(f[i++], f[i++]);
+		for (let p = 1; p < count; p++) this.lineTo(f[i++], f[i++]);
+		this.closePath();
+		paint(f[i++], f[i++], f[i++]);
+		break;
+	}
+	case 5: // image
+		this.drawImage(imgs[f[i++]], f[i++], f[i++], f[i++], f[i++]);
+		break;
+	case 6: { // text
+		const x = f[i++], y = f[i++];
+		set("fillStyle", s[f[i++]]);
+		set("font", s[f[i++]]);
+		set("textAlign", align[f[i++]]);
+		this.fillText(s[f[i++]], x, y);
+		break;
+	}
+	case 7: this.save(); stack.push({ ...st }); break;
+	case 8: this.restore(); st = stack.pop() || {}; break;
+	case 9: this.translate(f[i++], f[i++]); break;
+	case 10: this.scale(f[i++], f[i++]); break;
+	case 11: this.rotate(f[i++]); break;
+	case 12: set("globalAlpha", f[i++]); break;
+	}
+}`
+
+// Batch records a frame and draws it on the canvas with a single call into
+// JavaScript on Flush, instead of one call per context method and property.
+// Text is still measured by the canvas right away.
+type Batch struct {
+	*record.CommandBuffer
+	canvas *Canvas
+
+	nums    []float64
+	strs    []string
+	strIdx  map[string]int
+	bytes   []byte
+	jsBytes js.Value
+
+	imgIdx    map[image.Image]int
+	jsImages  js.Value
+	installed bool
+}
+
+func NewBatch(c *Canvas) *Batch {
+	return &Batch{
+		CommandBuffer: record.NewCommandBuffer(c.MeasureText),
+		canvas:        c,
+		strIdx:        make(map[string]int),
+		imgIdx:        make(map[image.Image]int),
+		jsImages:      js.Global().Get("Array").New(),
+	}
+}
+
+// Flush draws the recorded frame and starts a new one.
+func (b *Batch) Flush() {
+	b.encode(b.Commands())
+	b.Reset()
+	if len(b.nums) == 0 {
+		return
+	}
+
+	if !b.installed {
+		fn := js.Global().Get("Function").New("bytes", "n", "strs", "imgs", replaySource)
+		b.canvas.ctx.Set(replayMethod, fn)
+		b.installed = true
+	}
+
+	size := len(b.nums) * 8
+	if cap(b.bytes) < size {
+		b.bytes = make([]byte, size, 2*size)
+		b.jsBytes = js.Global().Get("Uint8Array").New(cap(b.bytes))
+	}
+	b.bytes = b.bytes[:size]
+	for i, v := range b.nums {
+		binary.LittleEndian.PutUint64(b.bytes[i*8:], math.Float64bits(v))
+	}
+	js.CopyBytesToJS(b.jsBytes, b.bytes)
+
+	b.canvas.ctx.Call(replayMethod, b.jsBytes, len(b.nums), strings.Join(b.strs, "\x00"), b.jsImages)
+
+	// the replay set the styles behind the canvas
+	b.canvas.forgetState()
+}
+
+// encode writes the commands in the layout read by replaySource. Shapes the
+// canvas would not paint are dropped here.
+func (b *Batch) encode(cmds []record.Command) {
+	b.nums = b.nums[:0]
+	b.strs = b.strs[:0]
+	clear(b.strIdx)
+
+	// the frame refers to the images by index, so they are dropped between frames
+	if b.jsImages.Length() >= maxImages {
+		clear(b.imgIdx)
+		b.jsImages = js.Global().Get("Array").New()
+	}
+
+	for _, cmd := range cmds {
+		op := float64(cmd.Op)
+		switch cmd.Op {
+		case record.OpClear:
+			c, color := b.canvas, cmd.Style.Fill
+			erase, fill := 0.0, -1.0
+			if color.A < 255 {
+				erase = 1
+			}
+			if visible(color) {
+				fill = b.str(c.colorCSS(color))
+			}
+			b.push(op, c.scale, c.w, c.h, erase, fill)
+		case record.OpRect:
+			b.push(op, cmd.X, cmd.Y, cmd.W, cmd.H)
+			b.pushStyle(cmd.Style)
+		case record.OpCircle:
+			b.push(op, cmd.X, cmd.Y, cmd.W)
+			b.pushStyle(cmd.Style)
+		case record.OpLine:
+			if !stroked(cmd.Style) {
+				continue
+			}
+			b.push(op, cmd.X, cmd.Y, cmd.W, cmd.H, b.str(b.canvas.colorCSS(cmd.Style.Stroke)), cmd.Style.LineWidth)
+		case record.OpPolygon:
+			if len(cmd.Points) < 2 {
+				continue
+			}
+			b.push(op, float64(len(cmd.Points)))
+			for _, p := range cmd.Points {
+				b.push(p.X, p.Y)
+			}
+			b.pushStyle(cmd.Style)
+		case record.OpImage:
+			if cmd.Image == nil {
+				continue
+			}
+			b.push(op, b.image(cmd.Image), cmd.X, cmd.Y, cmd.W, cmd.H)
+		case record.OpText:
+			s := cmd.TextStyle
+			b.push(op, cmd.X, cmd.Y, b.str(b.canvas.colorCSS(s.Color)), b.str(b.canvas.fontCSS(s.Font)), float64(s.Align), b.str(cmd.Text))
+		case record.OpSave, record.OpRestore:
+			b.push(op)
+		case record.OpTranslate, record.OpScale:
+			b.push(op, cmd.X, cmd.Y)
+		case record.OpRotate, record.OpAlpha:
+			b.push(op, cmd.X)
+		}
+	}
+}
+
+func (b *Batch) push(nums ...float64) {
+	b.nums = append(b.nums, nums...)
+}
+
+// pushStyle writes the fill and stroke string indexes, -1 when not painted,
+// and the line width.
+func (b *Batch) pushStyle(style ports.Style) {
+	fill, stroke := -1.0, -1.0
+	if visible(style.Fill) {
+		fill = b.str(b.canvas.colorCSS(style.Fill))
+	}
+	if stroked(style) {
+		stroke = b.str(b.canvas.colorCSS(style.Stroke))
+	}
+
+	b.push(fill, stroke, style.LineWidth)
+}
+
+// str is the index of s in the frame strings.
+func (b *Batch) str(s string) float64 {
+	idx, ok := b.strIdx[s]
+	if !ok {
+		idx = len(b.strs)
+		b.strs = append(b.strs, s)
+		b.strIdx[s] = idx
+	}
+
+	return float64(idx)
+}
+
+// image is the index of img in the images kept on the JavaScript side; a new
+// image is uploaded once, see cachedByIdentity.
+func (b *Batch) image(img image.Image) float64 {
+	if !cachedByIdentity(img) {
+		b.jsImages.Call("push", b.canvas.imageSource(img))
+		return float64(b.jsImages.Length() - 1)
+	}
+
+	idx, ok := b.imgIdx[img]
+	if !ok {
+		idx = b.jsImages.Length()
+		b.jsImages.Call("push", b.canvas.imageSource(img))
+		b.imgIdx[img] = idx
+	}
+
+	return float64(idx)
+}
+
+// stroked matches Canvas.setStroke: no stroke without a visible color and a width.
+func stroked(style ports.Style) bool {
+	return visible(style.Stroke) && style.LineWidth > 0
+}
@@ -0,0 +1,126 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"image"
+	"image/color"
+	"syscall/js"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// newLoggingContext returns a JavaScript object that logs every method call
+// and property set, as "name(args)" and "name=value"; functions assigned to it
+// are kept, so the replay method can be installed.
+func newLoggingContext() js.Value {
+	return js.Global().Get("Function").New(`
+const calls = [];
+return new Proxy({ calls }, {
+	get(target, key) {
+		if (key in target) return target[key];
+		return (...args) => { calls.push(String(key) + "(" + args.join(",") + ")"); };
+	},
+	set(target, key, value) {
+		if (typeof value === "function") target[key] = value;
+		else calls.push(String(key) + "=" + value);
+		return true;
+	},
+});`).Invoke()
+}
+
+func loggedCalls(ctx js.Value) []string {
+	calls := ctx.Get("calls")
+	out := make([]string, calls.Length())
+	for i := range out {
+		out[i] = calls.Index(i).String()
+	}
+
+	return out
+}
+
+func TestBatchMatchesCanvas(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	white := ports.RGB(255, 255, 255)
+
+	draw := func(r ports.Renderer) {
+		r.Clear(ports.RGB(0, 0, 0))
+		r.Save()
+		r.Translate(3, 4)
+		r.Scale(2, 0.5)
+		r.Rotate(0.25)
+		r.SetAlpha(0.5)
+		r.DrawRect(10, 20, 30, 40, ports.Style{Fill: ports.RGB(255, 0, 0), Stroke: white, LineWidth: 2})
+		r.DrawRect(1, 1, 1, 1, ports.Style{Stroke: white}) // no width: not stroked
+		r.DrawCircle(50, 60, 5, ports.Style{Fill: ports.Color{R: 1, G: 2, B: 3, A: 128}})
+		r.DrawLine(0, 0, 100, 0, ports.Style{Stroke: white, LineWidth: 1})
+		r.DrawLine(0, 0, 100, 0, ports.Style{Fill: white}) // not stroked
+		r.DrawPolygon([]ports.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 8}}, ports.Style{Fill: white, Stroke: white, LineWidth: 3})
+		r.DrawPolygon([]ports.Point{{X: 0, Y: 0}}, ports.Style{Fill: white})
+		r.DrawImage(img, 1, 2, 8, 4)
+		r.DrawImage(img, 5, 6, 8, 4)
+		r.Restore()
+		r.DrawText("Score: 1,230", 15, 31, ports.TextStyle{Font: ports.Font{Family: "Arial", Size: 20}, Color: white})
+		r.DrawText("PAUSED", 400, 300, ports.TextStyle{Font: ports.Font{Family: "Arial", Size: 20, Bold: true}, Color: white, Align: ports.AlignCenter})
+		r.Clear(ports.Color{})                           // erases only
+		r.Clear(ports.Color{R: 255, G: 0, B: 0, A: 128}) // replaces
+	}
+	newCanvas := func(ctx js.Value) *Canvas {
+		return &Canvas{
+			ctx:       NewJSContext(ctx),
+			w:         800,
+			h:         600,
+			scale:     2,
+			loadImage: func(image.Image) interface{} { return "img" },
+		}
+	}
+
+	directCtx := newLoggingContext()
+	draw(newCanvas(directCtx))
+
+	batchCtx := newLoggingContext()
+	batch := NewBatch(newCanvas(batchCtx))
+	draw(batch)
+	if got := loggedCalls(batchCtx); len(got) != 0 {
+		t.Fatalf("Batch drew before Flush: %v", got)
+	}
+	batch.Flush()
+
+	want, got := loggedCalls(directCtx), loggedCalls(batchCtx)
+	if len(got) != len(want) {
+		t.Fatalf("Flush() made %d calls, want %d:\n%v\nwant\n%v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	if len(batch.Commands()) != 0 {
+		t.Error("Flush() kept the frame")
+	}
+}
+
+func TestBatchImages(t *testing.T) {
+	batch := NewBatch(&Canvas{
+		ctx:       NewJSContext(newLoggingContext()),
+		w:         800,
+		h:         600,
+		loadImage: func(image.Image) interface{} { return "img" },
+	})
+
+	for i := 0; i <= maxImages; i++ {
+		batch.DrawImage(image.NewRGBA(image.Rect(0, 0, 1, 1)), 0, 0, 1, 1)
+		batch.DrawImage(sliceImage{pix: []color.RGBA{{A: 255}}}, 0, 0, 1, 1)
+		batch.Flush()
+	}
+
+	// the images of past frames are dropped once the cache is full
+	if n := batch.jsImages.Length(); n > maxImages+2 {
+		t.Errorf("images kept = %d, want at most %d", n, maxImages+2)
+	}
+	if len(batch.imgIdx) > maxImages {
+		t.Errorf("indexed images = %d, want at most %d", len(batch.imgIdx), maxImages)
+	}
+}
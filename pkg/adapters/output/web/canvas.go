@@ -3,58 +3,402 @@
 package web
 
 import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"reflect"
 	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
 )
 
+// Canvas draws the logical court (w x h units) scaled to the element's CSS size
+// and backed by a devicePixelRatio-sized store, so text and edges stay sharp.
+//
+// Every Set and Call crosses the wasm/JS boundary, so the canvas remembers the
+// context state it set (mirroring save/restore) and skips a Set that would not
+// change it, builds each color and font string once, and caches MeasureText.
 type Canvas struct {
-	ctx JSContext
-	w   float64
-	h   float64
+	ctx     JSContext
+	element JSContext
+	w       float64
+	h       float64
+	scale   float64
+
+	state  ctxState
+	stack  []ctxState
+	colors map[ports.Color]string
+	fonts  map[ports.Font]string
+	widths map[textKey]float64
+
+	images    map[image.Image]interface{}
+	loadImage func(img image.Image) interface{}
+}
+
+// ctxState is the context state last set by the canvas; empty strings and
+// NaN are unknown and always set.
+type ctxState struct {
+	fill, stroke, font, align string
+	lineWidth, alpha          float64
+}
+
+var unknownState = ctxState{lineWidth: math.NaN(), alpha: math.NaN()}
+
+type textKey struct {
+	text string
+	font ports.Font
 }
 
-func NewRenderer() *Canvas {
+// maxWidths bounds the MeasureText cache; it is emptied when full.
+const maxWidths = 512
+
+// maxImages bounds the image caches, of the canvas and of the JavaScript side
+// of Batch and WebGL; they are emptied when full.
+const maxImages = 64
+
+// cachedByIdentity reports whether img can be cached. The caches are keyed by
+// the image itself, so an image changed in place keeps its first upload, and
+// an image of a type that cannot be compared, which would panic as a key, is
+// uploaded on every draw.
+func cachedByIdentity(img image.Image) bool {
+	return img != nil && reflect.TypeOf(img).Comparable()
+}
+
+func NewRenderer(w, h float64) *Canvas {
 	doc := js.Global().Get("document")
 	canvasElement := doc.Call("getElementById", "gameCanvas")
-	return NewCanvas(canvasElement)
+	return NewCanvas(canvasElement, w, h)
 }
 
-func NewCanvas(canvasElement js.Value) *Canvas {
+func NewCanvas(canvasElement js.Value, w, h float64) *Canvas {
 	ctx := canvasElement.Call("getContext", "2d")
-	return &Canvas{
-		ctx: NewJSContext(ctx),
-		w:   canvasElement.Get("width").Float(),
-		h:   canvasElement.Get("height").Float(),
+	c := &Canvas{
+		ctx:       NewJSContext(ctx),
+		element:   NewJSContext(canvasElement),
+		w:         w,
+		h:         h,
+		scale:     1,
+		state:     unknownState,
+		loadImage: newImageSource,
 	}
+
+	c.Fit()
+	return c
 }
 
-func (c *Canvas) Clear() {
-	c.ctx.Set("fillStyle", "black")
-	c.ctx.Call("fillRect", 0, 0, c.w, c.h)
+// Fit resizes the backing store to the element's current CSS size and pixel ratio.
+func (c *Canvas) Fit() {
+	dpr := js.Global().Get("devicePixelRatio").Float()
+	c.Resize(c.element.Get("clientWidth").Float(), c.element.Get("clientHeight").Float(), dpr)
 }
 
-func (c *Canvas) DrawBall(x, y, size float64) {
-	c.ctx.Set("fillStyle", "white")
-	c.ctx.Call("fillRect", x, y, size, size)
+// WatchResize refits the canvas whenever the window is resized or zoomed.
+func (c *Canvas) WatchResize() {
+	js.Global().Call("addEventListener", "resize", js.FuncOf(func(this js.Value, args []js.Value) any {
+		c.Fit()
+		return nil
+	}))
 }
 
-func (c *Canvas) DrawPaddle(x, y, w, h float64) {
-	c.ctx.Set("fillStyle", "white")
-	c.ctx.Call("fillRect", x, y, w, h)
+// Resize sets the backing store to cssW x cssH CSS pixels at the given pixel ratio.
+func (c *Canvas) Resize(cssW, cssH, dpr float64) {
+	width, height, scale := calcBackingStore(cssW, cssH, dpr, c.w)
+	if scale <= 0 {
+		return
+	}
+
+	c.element.Set("width", width)
+	c.element.Set("height", height)
+	c.scale = scale
+
+	// resizing the element resets the context
+	c.forgetState()
 }
 
-func (c *Canvas) DrawText(text string, x, y float64) {
-	c.ctx.Set("fillStyle", "white")
-	c.ctx.Set("font", "20px Arial")
-	c.ctx.Call("fillText", text, x, y)
+// forgetState is called when the context state changed behind the canvas.
+func (c *Canvas) forgetState() {
+	c.state = unknownState
+	c.stack = c.stack[:0]
+}
+
+// Clear starts a frame: it resets the court transform and fills the background.
+// A background that is not opaque replaces the previous frame instead of
+// blending with it; a transparent one only erases it, e.g. on a layer.
+func (c *Canvas) Clear(color ports.Color) {
+	c.ctx.Call("setTransform", c.scale, 0, 0, c.scale, 0, 0)
+	if color.A < 255 {
+		c.ctx.Call("clearRect", 0, 0, c.w, c.h)
+	}
+	if visible(color) {
+		c.setFill(color)
+		c.ctx.Call("fillRect", 0, 0, c.w, c.h)
+	}
+}
+
+func (c *Canvas) DrawRect(x, y, w, h float64, style ports.Style) {
+	if visible(style.Fill) {
+		c.setFill(style.Fill)
+		c.ctx.Call("fillRect", x, y, w, h)
+	}
+
+	if c.setStroke(style) {
+		c.ctx.Call("strokeRect", x, y, w, h)
+	}
+}
+
+func (c *Canvas) DrawCircle(x, y, radius float64, style ports.Style) {
+	c.ctx.Call("beginPath")
+	c.ctx.Call("arc", x, y, radius, 0, 2*math.Pi)
+	c.paintPath(style)
+}
+
+func (c *Canvas) DrawLine(x1, y1, x2, y2 float64, style ports.Style) {
+	if !c.setStroke(style) {
+		return
+	}
+
+	c.ctx.Call("beginPath")
+	c.ctx.Call("moveTo", x1, y1)
+	c.ctx.Call("lineTo", x2, y2)
+	c.ctx.Call("stroke")
+}
+
+func (c *Canvas) DrawPolygon(points []ports.Point, style ports.Style) {
+	if len(points) < 2 {
+		return
+	}
+
+	c.ctx.Call("beginPath")
+	c.ctx.Call("moveTo", points[0].X, points[0].Y)
+	for _, p := range points[1:] {
+		c.ctx.Call("lineTo", p.X, p.Y)
+	}
+	c.ctx.Call("closePath")
+	c.paintPath(style)
 }
 
-func (c *Canvas) DrawDebugText(text string, x, y float64) {
-	c.ctx.Set("font", "12px monospace")
-	c.ctx.Set("fillStyle", "#FFFF00")
+// DrawImage uploads img once to an offscreen canvas and reuses it on the next
+// frames; see cachedByIdentity.
+func (c *Canvas) DrawImage(img image.Image, x, y, w, h float64) {
+	c.ctx.Call("drawImage", c.imageSource(img), x, y, w, h)
+}
+
+func (c *Canvas) imageSource(img image.Image) interface{} {
+	if c.loadImage == nil {
+		c.loadImage = newImageSource
+	}
+	if !cachedByIdentity(img) {
+		return c.loadImage(img)
+	}
+
+	src, ok := c.images[img]
+	if !ok {
+		if c.images == nil || len(c.images) >= maxImages {
+			c.images = make(map[image.Image]interface{})
+		}
+		src = c.loadImage(img)
+		c.images[img] = src
+	}
+
+	return src
+}
+
+func (c *Canvas) DrawText(text string, x, y float64, style ports.TextStyle) {
+	c.setFill(style.Color)
+	c.setFont(style.Font)
+	if align := alignCSS(style.Align); align != c.state.align {
+		c.ctx.Set("textAlign", align)
+		c.state.align = align
+	}
 	c.ctx.Call("fillText", text, x, y)
 }
 
-func (c *Canvas) MeasureText(text string) float64 {
-	metrics := c.ctx.Call("measureText", text)
-	return metrics.Get("width").Float()
+// MeasureText asks the browser once per text and font.
+func (c *Canvas) MeasureText(text string, font ports.Font) float64 {
+	key := textKey{text: text, font: font}
+	if width, ok := c.widths[key]; ok {
+		return width
+	}
+
+	if c.widths == nil || len(c.widths) >= maxWidths {
+		c.widths = make(map[textKey]float64)
+	}
+
+	c.setFont(font)
+	width := c.ctx.Call("measureText", text).Get("width").Float()
+	c.widths[key] = width
+	return width
+}
+
+func (c *Canvas) SetAlpha(alpha float64) {
+	if alpha == c.state.alpha {
+		return
+	}
+
+	c.ctx.Set("globalAlpha", alpha)
+	c.state.alpha = alpha
+}
+
+func (c *Canvas) Save() {
+	c.ctx.Call("save")
+	c.stack = append(c.stack, c.state)
+}
+
+func (c *Canvas) Restore() {
+	c.ctx.Call("restore")
+	if len(c.stack) == 0 {
+		c.state = unknownState
+		return
+	}
+
+	c.state = c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+func (c *Canvas) Translate(x, y float64) {
+	c.ctx.Call("translate", x, y)
+}
+
+func (c *Canvas) Scale(x, y float64) {
+	c.ctx.Call("scale", x, y)
+}
+
+func (c *Canvas) Rotate(angle float64) {
+	c.ctx.Call("rotate", angle)
+}
+
+func (c *Canvas) paintPath(style ports.Style) {
+	if visible(style.Fill) {
+		c.setFill(style.Fill)
+		c.ctx.Call("fill")
+	}
+
+	if c.setStroke(style) {
+		c.ctx.Call("stroke")
+	}
+}
+
+func (c *Canvas) setStroke(style ports.Style) bool {
+	if !visible(style.Stroke) || style.LineWidth <= 0 {
+		return false
+	}
+
+	if css := c.colorCSS(style.Stroke); css != c.state.stroke {
+		c.ctx.Set("strokeStyle", css)
+		c.state.stroke = css
+	}
+	if style.LineWidth != c.state.lineWidth {
+		c.ctx.Set("lineWidth", style.LineWidth)
+		c.state.lineWidth = style.LineWidth
+	}
+	return true
+}
+
+func (c *Canvas) setFill(color ports.Color) {
+	if css := c.colorCSS(color); css != c.state.fill {
+		c.ctx.Set("fillStyle", css)
+		c.state.fill = css
+	}
+}
+
+func (c *Canvas) setFont(font ports.Font) {
+	if css := c.fontCSS(font); css != c.state.font {
+		c.ctx.Set("font", css)
+		c.state.font = css
+	}
+}
+
+// colorCSS formats each color once.
+func (c *Canvas) colorCSS(color ports.Color) string {
+	css, ok := c.colors[color]
+	if !ok {
+		if c.colors == nil {
+			c.colors = make(map[ports.Color]string)
+		}
+		css = colorCSS(color)
+		c.colors[color] = css
+	}
+
+	return css
+}
+
+// fontCSS formats each font once.
+func (c *Canvas) fontCSS(font ports.Font) string {
+	css, ok := c.fonts[font]
+	if !ok {
+		if c.fonts == nil {
+			c.fonts = make(map[ports.Font]string)
+		}
+		css = fontCSS(font)
+		c.fonts[font] = css
+	}
+
+	return css
+}
+
+// calcBackingStore returns the backing store size in device pixels and the
+// logical-to-device scale; the element keeps the aspect ratio of the court.
+func calcBackingStore(cssW, cssH, dpr, logicalW float64) (int, int, float64) {
+	if cssW <= 0 || cssH <= 0 || logicalW <= 0 {
+		return 0, 0, 0
+	}
+
+	if dpr <= 0 {
+		dpr = 1
+	}
+
+	width := math.Round(cssW * dpr)
+	height := math.Round(cssH * dpr)
+	return int(width), int(height), width / logicalW
+}
+
+func visible(color ports.Color) bool {
+	return color.A > 0
+}
+
+func colorCSS(color ports.Color) string {
+	if color.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", color.R, color.G, color.B)
+	}
+
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3g)", color.R, color.G, color.B, float64(color.A)/255)
+}
+
+func fontCSS(font ports.Font) string {
+	if font.Bold {
+		return fmt.Sprintf("bold %gpx %s", font.Size, font.Family)
+	}
+
+	return fmt.Sprintf("%gpx %s", font.Size, font.Family)
+}
+
+func alignCSS(align ports.TextAlign) string {
+	switch align {
+	case ports.AlignCenter:
+		return "center"
+	case ports.AlignRight:
+		return "right"
+	default:
+		return "left"
+	}
+}
+
+// newImageSource copies the pixels of img into an offscreen canvas usable by drawImage.
+func newImageSource(img image.Image) interface{} {
+	bounds := img.Bounds()
+	rgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	bytes := js.Global().Get("Uint8Array").New(len(rgba.Pix))
+	js.CopyBytesToJS(bytes, rgba.Pix)
+	pixels := js.Global().Get("Uint8ClampedArray").New(bytes.Get("buffer"))
+
+	data := js.Global().Get("ImageData").New(pixels, bounds.Dx(), bounds.Dy())
+	offscreen := js.Global().Get("document").Call("createElement", "canvas")
+	offscreen.Set("width", bounds.Dx())
+	offscreen.Set("height", bounds.Dy())
+	offscreen.Call("getContext", "2d").Call("putImageData", data, 0, 0)
+
+	return offscreen
 }
@@ -3,6 +3,8 @@
 package web
 
 import (
+	"image"
+	"image/color"
 	"syscall/js"
 	"testing"
 
@@ -163,27 +165,55 @@ func TestNewCanvas(t *testing.T) {
 
 func TestCanvasClear(t *testing.T) {
 	tests := []struct {
-		name   string
-		width  float64
-		height float64
+		name      string
+		width     float64
+		height    float64
+		color     ports.Color
+		wantStyle string
+		wantCalls []string
 	}{
 		{
-			name:   "Clear standard canvas",
-			width:  800.0,
-			height: 600.0,
+			name:      "Clear standard canvas",
+			width:     800.0,
+			height:    600.0,
+			color:     ports.RGB(0, 0, 0),
+			wantStyle: "#000000",
+			wantCalls: []string{"setTransform", "fillRect"},
 		},
 		{
-			name:   "Clear large canvas",
-			width:  1920.0,
-			height: 1080.0,
+			name:      "Clear large canvas",
+			width:     1920.0,
+			height:    1080.0,
+			color:     ports.RGB(255, 255, 255),
+			wantStyle: "#ffffff",
+			wantCalls: []string{"setTransform", "fillRect"},
+		},
+		{
+			name:      "Translucent background replaces the frame",
+			width:     800.0,
+			height:    600.0,
+			color:     ports.Color{R: 255, A: 128},
+			wantStyle: "rgba(255,0,0,0.502)",
+			wantCalls: []string{"setTransform", "clearRect", "fillRect"},
+		},
+		{
+			name:      "Transparent background only erases",
+			width:     800.0,
+			height:    600.0,
+			color:     ports.Color{},
+			wantCalls: []string{"setTransform", "clearRect"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCtx := newMockJSContext(t)
-			mockCtx.expectSet("fillStyle", "black")
-			mockCtx.expectCall("fillRect")
+			if tt.wantStyle != "" {
+				mockCtx.expectSet("fillStyle", tt.wantStyle)
+			}
+			for _, call := range tt.wantCalls {
+				mockCtx.expectCall(call)
+			}
 
 			canvas := &Canvas{
 				ctx: mockCtx,
@@ -191,194 +221,285 @@ func TestCanvasClear(t *testing.T) {
 				h:   tt.height,
 			}
 
-			canvas.Clear()
+			canvas.Clear(tt.color)
 
 			mockCtx.verify()
 		})
 	}
 }
 
-func TestCanvasDrawBall(t *testing.T) {
+func TestCalcBackingStore(t *testing.T) {
 	tests := []struct {
-		name string
-		x    float64
-		y    float64
-		size float64
+		name       string
+		cssW       float64
+		cssH       float64
+		dpr        float64
+		wantWidth  int
+		wantHeight int
+		wantScale  float64
 	}{
 		{
-			name: "Ball at origin",
-			x:    0.0,
-			y:    0.0,
-			size: 10.0,
+			name:       "Logical size at 1x",
+			cssW:       800,
+			cssH:       600,
+			dpr:        1,
+			wantWidth:  800,
+			wantHeight: 600,
+			wantScale:  1,
+		},
+		{
+			name:       "Small window on a retina display",
+			cssW:       480,
+			cssH:       360,
+			dpr:        2,
+			wantWidth:  960,
+			wantHeight: 720,
+			wantScale:  1.2,
 		},
 		{
-			name: "Ball at center",
-			x:    400.0,
-			y:    300.0,
-			size: 15.0,
+			name:       "Large window",
+			cssW:       1600,
+			cssH:       1200,
+			dpr:        1,
+			wantWidth:  1600,
+			wantHeight: 1200,
+			wantScale:  2,
 		},
 		{
-			name: "Ball with large size",
-			x:    100.0,
-			y:    100.0,
-			size: 20.0,
+			name:       "Missing pixel ratio defaults to 1",
+			cssW:       400,
+			cssH:       300,
+			dpr:        0,
+			wantWidth:  400,
+			wantHeight: 300,
+			wantScale:  0.5,
+		},
+		{
+			name: "Hidden canvas",
+			cssW: 0,
+			cssH: 0,
+			dpr:  2,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockCtx := newMockJSContext(t)
-			mockCtx.expectSet("fillStyle", "white")
-			mockCtx.expectCall("fillRect")
-
-			canvas := &Canvas{
-				ctx: mockCtx,
-				w:   800.0,
-				h:   600.0,
+			width, height, scale := calcBackingStore(tt.cssW, tt.cssH, tt.dpr, 800)
+			if width != tt.wantWidth || height != tt.wantHeight || scale != tt.wantScale {
+				t.Errorf("calcBackingStore() = (%v, %v, %v), want (%v, %v, %v)",
+					width, height, scale, tt.wantWidth, tt.wantHeight, tt.wantScale)
 			}
+		})
+	}
+}
 
-			canvas.DrawBall(tt.x, tt.y, tt.size)
+func TestCanvasResize(t *testing.T) {
+	mockElement := newMockJSContext(t)
+	mockElement.expectSet("width", 1200).expectSet("height", 900)
 
-			mockCtx.verify()
-		})
+	canvas := &Canvas{ctx: newMockJSContext(t), element: mockElement, w: 800.0, h: 600.0, scale: 1}
+	canvas.Resize(600, 450, 2)
+
+	if canvas.scale != 1.5 {
+		t.Errorf("Resize() scale = %v, want 1.5", canvas.scale)
+	}
+	mockElement.verify()
+
+	// A hidden canvas keeps the previous store
+	canvas.Resize(0, 0, 2)
+	if canvas.scale != 1.5 {
+		t.Errorf("Resize() hidden scale = %v, want 1.5", canvas.scale)
 	}
 }
 
-func TestCanvasDrawPaddle(t *testing.T) {
+func TestCanvasDrawRect(t *testing.T) {
+	white := ports.RGB(255, 255, 255)
+
 	tests := []struct {
-		name string
-		x    float64
-		y    float64
-		w    float64
-		h    float64
+		name      string
+		style     ports.Style
+		wantSets  []mockCall
+		wantCalls []string
 	}{
 		{
-			name: "Standard paddle",
-			x:    10.0,
-			y:    270.0,
-			w:    10.0,
-			h:    60.0,
+			name:      "Filled rect",
+			style:     ports.Style{Fill: white},
+			wantSets:  []mockCall{{args: []interface{}{"fillStyle", "#ffffff"}}},
+			wantCalls: []string{"fillRect"},
+		},
+		{
+			name:  "Stroked rect",
+			style: ports.Style{Stroke: white, LineWidth: 2},
+			wantSets: []mockCall{
+				{args: []interface{}{"strokeStyle", "#ffffff"}},
+				{args: []interface{}{"lineWidth", 2.0}},
+			},
+			wantCalls: []string{"strokeRect"},
 		},
 		{
-			name: "Wide paddle",
-			x:    5.0,
-			y:    250.0,
-			w:    15.0,
-			h:    80.0,
+			name:  "Filled and stroked rect",
+			style: ports.Style{Fill: ports.Color{R: 255, A: 128}, Stroke: white, LineWidth: 1},
+			wantSets: []mockCall{
+				{args: []interface{}{"fillStyle", "rgba(255,0,0,0.502)"}},
+				{args: []interface{}{"strokeStyle", "#ffffff"}},
+				{args: []interface{}{"lineWidth", 1.0}},
+			},
+			wantCalls: []string{"fillRect", "strokeRect"},
 		},
 		{
-			name: "Tall paddle",
-			x:    10.0,
-			y:    200.0,
-			w:    8.0,
-			h:    100.0,
+			name:  "Stroke without width is skipped",
+			style: ports.Style{Stroke: white},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCtx := newMockJSContext(t)
-			mockCtx.expectSet("fillStyle", "white")
-			mockCtx.expectCall("fillRect")
-
-			canvas := &Canvas{
-				ctx: mockCtx,
-				w:   800.0,
-				h:   600.0,
+			for _, set := range tt.wantSets {
+				mockCtx.expectSet(set.args[0].(string), set.args[1])
+			}
+			for _, call := range tt.wantCalls {
+				mockCtx.expectCall(call)
 			}
 
-			canvas.DrawPaddle(tt.x, tt.y, tt.w, tt.h)
+			canvas := &Canvas{ctx: mockCtx, w: 800.0, h: 600.0}
+			canvas.DrawRect(10, 20, 30, 40, tt.style)
 
 			mockCtx.verify()
 		})
 	}
 }
 
-func TestCanvasDrawText(t *testing.T) {
+func TestCanvasDrawPaths(t *testing.T) {
+	fill := ports.Style{Fill: ports.RGB(255, 255, 255)}
+	stroke := ports.Style{Stroke: ports.RGB(255, 255, 255), LineWidth: 1}
+	triangle := []ports.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 10}}
+
+	fillSet := []mockCall{{args: []interface{}{"fillStyle", "#ffffff"}}}
+	strokeSet := []mockCall{
+		{args: []interface{}{"strokeStyle", "#ffffff"}},
+		{args: []interface{}{"lineWidth", 1.0}},
+	}
+
 	tests := []struct {
-		name string
-		text string
-		x    float64
-		y    float64
+		name      string
+		draw      func(c *Canvas)
+		wantSets  []mockCall
+		wantCalls []string
 	}{
 		{
-			name: "Score text",
-			text: "Score: 100",
-			x:    30.0,
-			y:    30.0,
+			name:      "Filled circle",
+			draw:      func(c *Canvas) { c.DrawCircle(400, 300, 8, fill) },
+			wantSets:  fillSet,
+			wantCalls: []string{"beginPath", "arc", "fill"},
 		},
 		{
-			name: "Lives text",
-			text: "Lives: 3",
-			x:    680.0,
-			y:    30.0,
+			name:      "Line",
+			draw:      func(c *Canvas) { c.DrawLine(0, 0, 800, 600, stroke) },
+			wantSets:  strokeSet,
+			wantCalls: []string{"beginPath", "moveTo", "lineTo", "stroke"},
 		},
 		{
-			name: "Game over text",
-			text: "GAME OVER",
-			x:    300.0,
-			y:    300.0,
+			name: "Line without stroke is skipped",
+			draw: func(c *Canvas) { c.DrawLine(0, 0, 800, 600, fill) },
 		},
 		{
-			name: "Empty text",
-			text: "",
-			x:    100.0,
-			y:    100.0,
+			name:      "Stroked triangle",
+			draw:      func(c *Canvas) { c.DrawPolygon(triangle, stroke) },
+			wantSets:  strokeSet,
+			wantCalls: []string{"beginPath", "moveTo", "lineTo", "lineTo", "closePath", "stroke"},
+		},
+		{
+			name: "Degenerate polygon is skipped",
+			draw: func(c *Canvas) { c.DrawPolygon(triangle[:1], fill) },
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCtx := newMockJSContext(t)
-			mockCtx.expectSet("fillStyle", "white")
-			mockCtx.expectSet("font", "20px Arial")
-			mockCtx.expectCall("fillText")
-
-			canvas := &Canvas{
-				ctx: mockCtx,
-				w:   800.0,
-				h:   600.0,
+			for _, set := range tt.wantSets {
+				mockCtx.expectSet(set.args[0].(string), set.args[1])
+			}
+			for _, call := range tt.wantCalls {
+				mockCtx.expectCall(call)
 			}
 
-			canvas.DrawText(tt.text, tt.x, tt.y)
+			canvas := &Canvas{ctx: mockCtx, w: 800.0, h: 600.0}
+			tt.draw(canvas)
 
 			mockCtx.verify()
 		})
 	}
 }
 
-func TestCanvasDrawDebugText(t *testing.T) {
+func TestCanvasDrawImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	loads := 0
+
+	mockCtx := newMockJSContext(t)
+	mockCtx.expectCall("drawImage").expectCall("drawImage")
+
+	canvas := &Canvas{
+		ctx: mockCtx,
+		w:   800.0,
+		h:   600.0,
+		loadImage: func(image.Image) interface{} {
+			loads++
+			return nil
+		},
+	}
+
+	canvas.DrawImage(img, 0, 0, 4, 4)
+	canvas.DrawImage(img, 10, 10, 8, 8)
+
+	if loads != 1 {
+		t.Errorf("loadImage calls = %d, want 1 (cached)", loads)
+	}
+	mockCtx.verify()
+}
+
+func TestCanvasDrawText(t *testing.T) {
 	tests := []struct {
-		name string
-		text string
-		x    float64
-		y    float64
+		name      string
+		text      string
+		style     ports.TextStyle
+		wantColor string
+		wantFont  string
+		wantAlign string
 	}{
 		{
-			name: "FPS debug info",
-			text: "FPS: 60",
-			x:    10.0,
-			y:    80.0,
+			name:      "Score text",
+			text:      "Score: 100",
+			style:     ports.TextStyle{Font: ports.Font{Family: "Arial", Size: 20}, Color: ports.RGB(255, 255, 255)},
+			wantColor: "#ffffff",
+			wantFont:  "20px Arial",
+			wantAlign: "left",
 		},
 		{
-			name: "Level debug info",
-			text: "Level: 5",
-			x:    10.0,
-			y:    95.0,
+			name:      "Debug text",
+			text:      "FPS: 60",
+			style:     ports.TextStyle{Font: ports.Font{Family: "monospace", Size: 12}, Color: ports.RGB(255, 255, 0)},
+			wantColor: "#ffff00",
+			wantFont:  "12px monospace",
+			wantAlign: "left",
 		},
 		{
-			name: "Position debug info",
-			text: "Position: [400.0, 300.0]",
-			x:    10.0,
-			y:    110.0,
+			name:      "Centered bold title",
+			text:      "GAME OVER",
+			style:     ports.TextStyle{Font: ports.Font{Family: "Arial", Size: 32, Bold: true}, Color: ports.RGB(255, 255, 255), Align: ports.AlignCenter},
+			wantColor: "#ffffff",
+			wantFont:  "bold 32px Arial",
+			wantAlign: "center",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCtx := newMockJSContext(t)
-			mockCtx.expectSet("font", "12px monospace")
-			mockCtx.expectSet("fillStyle", "#FFFF00")
+			mockCtx.expectSet("fillStyle", tt.wantColor)
+			mockCtx.expectSet("font", tt.wantFont)
+			mockCtx.expectSet("textAlign", tt.wantAlign)
 			mockCtx.expectCall("fillText")
 
 			canvas := &Canvas{
@@ -387,13 +508,33 @@ func TestCanvasDrawDebugText(t *testing.T) {
 				h:   600.0,
 			}
 
-			canvas.DrawDebugText(tt.text, tt.x, tt.y)
+			canvas.DrawText(tt.text, 100, 100, tt.style)
 
 			mockCtx.verify()
 		})
 	}
 }
 
+func TestCanvasStateAndTransforms(t *testing.T) {
+	mockCtx := newMockJSContext(t)
+	mockCtx.expectCall("save").
+		expectCall("translate").
+		expectCall("rotate").
+		expectCall("scale").
+		expectCall("restore")
+	mockCtx.expectSet("globalAlpha", 0.5)
+
+	canvas := &Canvas{ctx: mockCtx, w: 800.0, h: 600.0}
+	canvas.Save()
+	canvas.SetAlpha(0.5)
+	canvas.Translate(400, 300)
+	canvas.Rotate(0.25)
+	canvas.Scale(2, 2)
+	canvas.Restore()
+
+	mockCtx.verify()
+}
+
 func TestCanvasMeasureText(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -424,6 +565,7 @@ func TestCanvasMeasureText(t *testing.T) {
 			mockMetrics.withFloatReturn(tt.expectedWidth)
 
 			mockCtx := newMockJSContext(t)
+			mockCtx.expectSet("font", "20px Arial")
 			mockCtx.expectCall("measureText")
 			mockCtx.withReturnContext(mockMetrics)
 
@@ -433,7 +575,7 @@ func TestCanvasMeasureText(t *testing.T) {
 				h:   600.0,
 			}
 
-			width := canvas.MeasureText(tt.text)
+			width := canvas.MeasureText(tt.text, ports.Font{Family: "Arial", Size: 20})
 
 			if width != tt.expectedWidth {
 				t.Errorf("MeasureText() = %v, want %v", width, tt.expectedWidth)
@@ -460,3 +602,43 @@ func TestCanvasImplementsRenderer(t *testing.T) {
 		})
 	}
 }
+
+// sliceImage is an image of a type that cannot be compared, so it cannot key
+// a map.
+type sliceImage struct {
+	pix []color.RGBA
+}
+
+func (sliceImage) ColorModel() color.Model   { return color.RGBAModel }
+func (sliceImage) Bounds() image.Rectangle   { return image.Rect(0, 0, 1, 1) }
+func (s sliceImage) At(x, y int) color.Color { return s.pix[0] }
+
+func TestCanvasImageCache(t *testing.T) {
+	loads := 0
+	canvas := &Canvas{
+		ctx: NewJSContext(newLoggingContext()),
+		w:   800.0,
+		h:   600.0,
+		loadImage: func(image.Image) interface{} {
+			loads++
+			return "img"
+		},
+	}
+
+	// a new image every frame does not grow the cache for ever
+	for i := 0; i <= maxImages; i++ {
+		canvas.DrawImage(image.NewRGBA(image.Rect(0, 0, 1, 1)), 0, 0, 1, 1)
+	}
+	if len(canvas.images) > maxImages {
+		t.Errorf("cached images = %d, want at most %d", len(canvas.images), maxImages)
+	}
+
+	// an image that cannot be compared is drawn, uploaded each time
+	loads = 0
+	img := sliceImage{pix: []color.RGBA{{R: 255, A: 255}}}
+	canvas.DrawImage(img, 0, 0, 1, 1)
+	canvas.DrawImage(img, 0, 0, 1, 1)
+	if loads != 2 {
+		t.Errorf("loadImage calls = %d, want 2 (not cached)", loads)
+	}
+}
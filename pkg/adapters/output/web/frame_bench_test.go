@@ -0,0 +1,164 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"testing"
+
+	"github.com/psaraiva/squash/internal/app"
+	"github.com/psaraiva/squash/internal/ports"
+	inputweb "github.com/psaraiva/squash/pkg/adapters/input/web"
+)
+
+// countingContext counts the calls that would cross into JavaScript.
+type countingContext struct {
+	calls   int
+	methods map[string]int
+}
+
+func newCountingContext() *countingContext {
+	return &countingContext{methods: make(map[string]int)}
+}
+
+func (c *countingContext) Call(method string, args ...interface{}) JSContext {
+	c.calls++
+	c.methods[method]++
+	return c
+}
+
+func (c *countingContext) Get(key string) JSContext {
+	c.calls++
+	return c
+}
+
+func (c *countingContext) Set(key string, value interface{}) {
+	c.calls++
+	c.methods[key+"="]++
+}
+
+func (c *countingContext) Float() float64 {
+	return 100
+}
+
+func newFrameGame(state app.GameState) *app.Squash {
+	cfg := app.NewDefaultConfig()
+	cfg.DeltaTime = 0.016
+	cfg.Debug = true
+
+	g := app.NewSquash(app.CourtWidth, app.CourtHeight, cfg)
+	g.State = state
+	g.Score = 1230
+	return g
+}
+
+// paintFrames draws frames of a paused game, where nothing moves, and returns
+// the JS calls of the last one.
+func paintFrames(r ports.Renderer, flush func(), ctx *countingContext, frames int) *countingContext {
+	g := newFrameGame(app.StatePaused)
+	for i := 0; i < frames; i++ {
+		*ctx = *newCountingContext()
+		inputweb.PaintGame(r, g, inputweb.ThemeClassic, nil)
+		flush()
+	}
+
+	return ctx
+}
+
+func TestCanvasFrameJSCalls(t *testing.T) {
+	ctx := newCountingContext()
+	canvas := &Canvas{ctx: ctx, w: 800, h: 600, scale: 1, state: unknownState}
+
+	first := *paintFrames(canvas, func() {}, ctx, 1)
+	next := *paintFrames(canvas, func() {}, ctx, 2)
+
+	if first.methods["measureText"] == 0 {
+		t.Fatal("the first frame measured no text")
+	}
+	if next.methods["measureText"] != 0 {
+		t.Errorf("measureText calls on the next frames = %d, want 0 (cached)", next.methods["measureText"])
+	}
+	// the HUD and the debug lines share two fonts and colors
+	if got := next.methods["font="]; got > 2 {
+		t.Errorf("font sets per frame = %d, want at most 2", got)
+	}
+	if next.calls >= first.calls {
+		t.Errorf("calls per frame = %d, want fewer than the first frame (%d)", next.calls, first.calls)
+	}
+}
+
+func TestBatchFrameJSCalls(t *testing.T) {
+	ctx := newCountingContext()
+	batch := NewBatch(&Canvas{ctx: ctx, w: 800, h: 600, scale: 1, state: unknownState})
+
+	next := paintFrames(batch, batch.Flush, ctx, 3)
+	if next.calls != 1 || next.methods[replayMethod] != 1 {
+		t.Errorf("calls per frame = %d (%v), want 1 replay call", next.calls, next.methods)
+	}
+}
+
+func TestWebGLFrameJSCalls(t *testing.T) {
+	ctx := newCountingContext()
+	g := newWebGL(ctx, 800, 600)
+
+	next := paintFrames(g, g.Flush, ctx, 3)
+	if next.calls != 1 || next.methods[drawMethod] != 1 {
+		t.Errorf("calls per frame = %d (%v), want 1 draw call", next.calls, next.methods)
+	}
+}
+
+// Reports the JS calls of a frame in play with the debug overlay, after the
+// first frame has filled the caches; the moving ball still changes the
+// debug lines, which are measured again:
+//
+//	GOOS=js GOARCH=wasm go test ./pkg/adapters/output/web -bench Frame
+func BenchmarkCanvasFrame(b *testing.B) {
+	ctx := newCountingContext()
+	canvas := &Canvas{ctx: ctx, w: 800, h: 600, scale: 1, state: unknownState}
+	g := newFrameGame(app.StatePlaying)
+	inputweb.PaintGame(canvas, g, inputweb.ThemeClassic, nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	ctx.calls = 0
+	for i := 0; i < b.N; i++ {
+		g.Update()
+		inputweb.PaintGame(canvas, g, inputweb.ThemeClassic, nil)
+	}
+	b.ReportMetric(float64(ctx.calls)/float64(b.N), "jscalls/frame")
+}
+
+func BenchmarkBatchFrame(b *testing.B) {
+	ctx := newCountingContext()
+	batch := NewBatch(&Canvas{ctx: ctx, w: 800, h: 600, scale: 1, state: unknownState})
+	g := newFrameGame(app.StatePlaying)
+	inputweb.PaintGame(batch, g, inputweb.ThemeClassic, nil)
+	batch.Flush()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	ctx.calls = 0
+	for i := 0; i < b.N; i++ {
+		g.Update()
+		inputweb.PaintGame(batch, g, inputweb.ThemeClassic, nil)
+		batch.Flush()
+	}
+	b.ReportMetric(float64(ctx.calls)/float64(b.N), "jscalls/frame")
+}
+
+func BenchmarkWebGLFrame(b *testing.B) {
+	ctx := newCountingContext()
+	g := newWebGL(ctx, 800, 600)
+	game := newFrameGame(app.StatePlaying)
+	inputweb.PaintGame(g, game, inputweb.ThemeClassic, nil)
+	g.Flush()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	ctx.calls = 0
+	for i := 0; i < b.N; i++ {
+		game.Update()
+		inputweb.PaintGame(g, game, inputweb.ThemeClassic, nil)
+		g.Flush()
+	}
+	b.ReportMetric(float64(ctx.calls)/float64(b.N), "jscalls/frame")
+}
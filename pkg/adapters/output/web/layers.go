@@ -0,0 +1,104 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+// BackgroundCanvasID is the canvas stacked behind the game canvas; the page
+// draws the game without layers when it has none.
+const BackgroundCanvasID = "backgroundCanvas"
+
+// Layers draws the background layer on a canvas stacked behind the game
+// canvas and the other layers on the game canvas, which is cleared to
+// transparent every frame. The background is drawn again only when its key
+// changes or after Invalidate, so the court costs nothing per frame and the
+// browser composes both canvases.
+type Layers struct {
+	ports.Renderer // the surface of the current layer
+
+	back  *Canvas
+	front *Canvas
+	draw  ports.Renderer // front, or a Batch over it
+	batch *Batch
+
+	key     any
+	valid   bool
+	cleared bool
+}
+
+// NewLayers draws the background on back and the other layers on front.
+func NewLayers(back, front *Canvas) *Layers {
+	return &Layers{Renderer: front, back: back, front: front, draw: front}
+}
+
+// NewPageLayers stacks the background canvas of the page behind front; it
+// returns nil when the page has no background canvas.
+func NewPageLayers(front *Canvas) *Layers {
+	element := js.Global().Get("document").Call("getElementById", BackgroundCanvasID)
+	if element.IsNull() {
+		return nil
+	}
+
+	return NewLayers(NewCanvas(element, front.w, front.h), front)
+}
+
+// WithBatch draws the game canvas layers with one call into JavaScript per
+// frame, on Flush.
+func (l *Layers) WithBatch() *Layers {
+	l.batch = NewBatch(l.front)
+	l.draw = l.batch
+	return l
+}
+
+// Flush draws the batched frame, if any.
+func (l *Layers) Flush() {
+	if l.batch != nil {
+		l.batch.Flush()
+	}
+}
+
+// BeginLayer implements ports.LayeredRenderer.
+func (l *Layers) BeginLayer(layer ports.Layer, key any) bool {
+	if layer == ports.LayerBackground {
+		l.cleared = false
+		if l.valid && key != nil && key == l.key {
+			l.Renderer = l.draw
+			return false
+		}
+
+		l.key, l.valid = key, true
+		l.Renderer = l.back
+		return true
+	}
+
+	l.Renderer = l.draw
+	if !l.cleared {
+		l.draw.Clear(ports.Color{})
+		l.cleared = true
+	}
+	return true
+}
+
+// Invalidate draws the background again on the next frame.
+func (l *Layers) Invalidate() {
+	l.valid = false
+}
+
+// Fit refits both canvases, which clears them.
+func (l *Layers) Fit() {
+	l.back.Fit()
+	l.front.Fit()
+	l.Invalidate()
+}
+
+// WatchResize refits the layers whenever the window is resized or zoomed.
+func (l *Layers) WatchResize() {
+	js.Global().Call("addEventListener", "resize", js.FuncOf(func(this js.Value, args []js.Value) any {
+		l.Fit()
+		return nil
+	}))
+}
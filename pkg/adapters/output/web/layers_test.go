@@ -0,0 +1,96 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"fmt"
+	"slices"
+	"syscall/js"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+)
+
+func newLoggingCanvas(ctx js.Value) *Canvas {
+	return &Canvas{ctx: NewJSContext(ctx), w: 800, h: 600, scale: 1, state: unknownState}
+}
+
+func TestLayers(t *testing.T) {
+	white := ports.RGB(255, 255, 255)
+
+	// frame draws like PaintGame: a static court and a moving ball
+	frame := func(r ports.LayeredRenderer, key string, x float64) {
+		if r.BeginLayer(ports.LayerBackground, key) {
+			r.Clear(ports.RGB(0, 0, 0))
+			r.DrawRect(0, 0, 800, 600, ports.Style{Stroke: white, LineWidth: 2})
+		}
+		r.BeginLayer(ports.LayerEntities, nil)
+		r.DrawRect(x, 10, 10, 10, ports.Style{Fill: white})
+		r.BeginLayer(ports.LayerHUD, nil)
+		r.DrawRect(0, 0, 1, 1, ports.Style{Fill: white})
+	}
+
+	tests := []struct {
+		name  string
+		batch bool
+	}{
+		{name: "Direct"},
+		{name: "Batched", batch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backCtx, frontCtx := newLoggingContext(), newLoggingContext()
+			layers := NewLayers(newLoggingCanvas(backCtx), newLoggingCanvas(frontCtx))
+			if tt.batch {
+				layers.WithBatch()
+			}
+
+			steps := []struct {
+				name       string
+				key        string
+				invalidate bool
+				wantBack   int // strokeRect calls of the court so far
+			}{
+				{name: "First frame draws the court", key: "classic", wantBack: 1},
+				{name: "Same key keeps it", key: "classic", wantBack: 1},
+				{name: "New key draws it again", key: "neon", wantBack: 2},
+				{name: "Invalidate draws it again", key: "neon", invalidate: true, wantBack: 3},
+			}
+
+			for i, step := range steps {
+				if step.invalidate {
+					layers.Invalidate()
+				}
+				frame(layers, step.key, float64(10*i))
+				layers.Flush()
+
+				back, front := loggedCalls(backCtx), loggedCalls(frontCtx)
+				if got := count(back, "strokeRect(0,0,800,600)"); got != step.wantBack {
+					t.Errorf("%s: court drawn %d times, want %d", step.name, got, step.wantBack)
+				}
+				// the game canvas is erased once per frame and never gets the court
+				if got := count(front, "clearRect(0,0,800,600)"); got != i+1 {
+					t.Errorf("%s: game canvas cleared %d times, want %d", step.name, got, i+1)
+				}
+				if count(front, "strokeRect(0,0,800,600)") != 0 {
+					t.Errorf("%s: the court was drawn on the game canvas", step.name)
+				}
+				if !slices.Contains(front, fmt.Sprintf("fillRect(%d,10,10,10)", 10*i)) {
+					t.Errorf("%s: ball not drawn on the game canvas: %v", step.name, front)
+				}
+			}
+		})
+	}
+}
+
+func count(calls []string, call string) int {
+	n := 0
+	for _, c := range calls {
+		if c == call {
+			n++
+		}
+	}
+
+	return n
+}
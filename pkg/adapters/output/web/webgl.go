@@ -0,0 +1,389 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"math"
+	"syscall/js"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/crt"
+	"github.com/psaraiva/squash/pkg/adapters/output/mesh"
+)
+
+var ErrNoWebGL = errors.New("webgl is not available")
+
+// drawMethod is installed on the WebGL context and draws a whole frame.
+const drawMethod = "squashDraw"
+
+// drawSource draws the frame written by WebGL.encode, a Float32Array with a
+// header and then the vertices: the number of spans, whether the frame is
+// cleared and its background, the CRT scanlines, glow, curvature, scanline
+// period and glow spread in pixels, then the texture (-1 for the atlas), first
+// vertex and vertex count of every span. The program, the buffer and the
+// textures are made on the first call and kept on the context; the textures
+// of imgs are deleted when another array of images is passed. It returns 1,
+// or 0 when the program does not compile or link.
+//
+// With the CRT look the frame is drawn on a texture, then on the canvas by a
+// post-processing program that bends it, makes it glow and adds scanlines;
+// when that program does not compile the frame is drawn without it.
+const drawSource = `
+const gl = this;
+let s = gl.squashState;
+if (!s) {
+	const shader = (type, src) => {
+		const sh = gl.createShader(type);
+		gl.shaderSource(sh, src.join("\n"));
+		gl.compileShader(sh);
+		return gl.getShaderParameter(sh, gl.COMPILE_STATUS) ? sh : null;
+	};
+	const link = (vertex, fragment, attribs) => {
+		const vs = shader(gl.VERTEX_SHADER, vertex), fs = shader(gl.FRAGMENT_SHADER, fragment);
+		if (!vs || !fs) return null;
+		const program = gl.createProgram();
+		gl.attachShader(program, vs);
+		gl.attachShader(program, fs);
+		attribs.forEach((name, i) => gl.bindAttribLocation(program, i, name));
+		gl.linkProgram(program);
+		return gl.getProgramParameter(program, gl.LINK_STATUS) ? program : null;
+	};
+	const program = link([
+		"attribute vec2 pos; attribute vec2 uv; attribute vec4 color;",
+		"uniform vec2 size; varying vec2 vUV; varying vec4 vColor;",
+		"void main() {",
+		"	gl_Position = vec4(pos / size * vec2(2.0, -2.0) + vec2(-1.0, 1.0), 0.0, 1.0);",
+		"	vUV = uv; vColor = color;",
+		"}",
+	], [
+		"precision mediump float;",
+		"uniform sampler2D tex; varying vec2 vUV; varying vec4 vColor;",
+		"void main() { gl_FragColor = texture2D(tex, vUV) * vColor; }",
+	], ["pos", "uv", "color"]);
+	if (!program) return 0;
+	gl.useProgram(program);
+
+	const texture = (filter, upload) => {
+		const t = gl.createTexture();
+		gl.bindTexture(gl.TEXTURE_2D, t);
+		upload();
+		gl.texParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE);
+		gl.texParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE);
+		gl.texParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, filter);
+		gl.texParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, filter);
+		return t;
+	};
+	s = gl.squashState = {
+		size: gl.getUniformLocation(program, "size"),
+		atlas: texture(gl.NEAREST, () => gl.texImage2D(gl.TEXTURE_2D, 0, gl.RGBA, aw, ah, 0, gl.RGBA, gl.UNSIGNED_BYTE, atlas)),
+		image: (img) => texture(gl.LINEAR, () => gl.texImage2D(gl.TEXTURE_2D, 0, gl.RGBA, gl.RGBA, gl.UNSIGNED_BYTE, img)),
+		textures: [],
+		program,
+		buffer: gl.createBuffer(),
+		bind: () => {
+			gl.useProgram(program);
+			gl.bindBuffer(gl.ARRAY_BUFFER, s.buffer);
+			gl.enableVertexAttribArray(1);
+			gl.enableVertexAttribArray(2);
+			gl.vertexAttribPointer(0, 2, gl.FLOAT, false, 32, 0);
+			gl.vertexAttribPointer(1, 2, gl.FLOAT, false, 32, 8);
+			gl.vertexAttribPointer(2, 4, gl.FLOAT, false, 32, 16);
+			gl.enable(gl.BLEND);
+		},
+		link,
+		texture,
+	};
+
+	gl.enableVertexAttribArray(0);
+	gl.blendFuncSeparate(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA, gl.ONE, gl.ONE_MINUS_SRC_ALPHA);
+	s.bind();
+}
+
+const f = new Float32Array(bytes.buffer, 0, n);
+const spans = f[0], head = 11 + 3 * spans;
+const width = gl.canvas.width, height = gl.canvas.height;
+if ((f[6] || f[7] || f[8]) && s.post === undefined) {
+	const program = s.link([
+		"attribute vec2 pos; varying vec2 vUV;",
+		"void main() { gl_Position = vec4(pos, 0.0, 1.0); vUV = pos * 0.5 + 0.5; }",
+	], [
+		"precision mediump float;",
+		"uniform sampler2D frame; uniform vec4 crt; uniform vec2 spread; varying vec2 vUV;",
+		"void main() {",
+		"	vec2 c = vUV * 2.0 - 1.0;",
+		"	vec2 q = c * (1.0 + crt.z * dot(c, c));",
+		"	if (abs(q.x) > 1.0 || abs(q.y) > 1.0) { gl_FragColor = vec4(0.0, 0.0, 0.0, 1.0); return; }",
+		"	vec2 uv = q * 0.5 + 0.5;",
+		"	vec4 color = texture2D(frame, uv);",
+		"	vec3 halo = texture2D(frame, uv + vec2(spread.x, 0.0)).rgb + texture2D(frame, uv - vec2(spread.x, 0.0)).rgb",
+		"		+ texture2D(frame, uv + vec2(0.0, spread.y)).rgb + texture2D(frame, uv - vec2(0.0, spread.y)).rgb;",
+		"	color.rgb += halo * 0.25 * crt.y;",
+		"	if (mod(gl_FragCoord.y, crt.w) < crt.w * 0.5) color.rgb *= 1.0 - crt.x;",
+		"	gl_FragColor = vec4(color.rgb, 1.0);",
+		"}",
+	], ["pos"]);
+	s.post = program && {
+		program,
+		crt: gl.getUniformLocation(program, "crt"),
+		spread: gl.getUniformLocation(program, "spread"),
+		quad: gl.createBuffer(),
+		framebuffer: gl.createFramebuffer(),
+		width: 0,
+		height: 0,
+	};
+	if (s.post) {
+		gl.bindBuffer(gl.ARRAY_BUFFER, s.post.quad);
+		gl.bufferData(gl.ARRAY_BUFFER, new Float32Array([-1, -1, 1, -1, 1, 1, -1, -1, 1, 1, -1, 1]), gl.STATIC_DRAW);
+		gl.bindBuffer(gl.ARRAY_BUFFER, s.buffer);
+	}
+}
+const crt = (f[6] || f[7] || f[8]) && s.post;
+if (crt) {
+	const p = s.post;
+	if (p.width !== width || p.height !== height) {
+		if (p.frame) gl.deleteTexture(p.frame);
+		p.frame = s.texture(gl.LINEAR, () => gl.texImage2D(gl.TEXTURE_2D, 0, gl.RGBA, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, null));
+		gl.bindFramebuffer(gl.FRAMEBUFFER, p.framebuffer);
+		gl.framebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, p.frame, 0);
+		p.width = width;
+		p.height = height;
+	}
+	gl.bindFramebuffer(gl.FRAMEBUFFER, p.framebuffer);
+}
+
+gl.viewport(0, 0, width, height);
+gl.uniform2f(s.size, width, height);
+if (f[1]) {
+	gl.clearColor(f[2], f[3], f[4], f[5]);
+	gl.clear(gl.COLOR_BUFFER_BIT);
+}
+gl.bufferData(gl.ARRAY_BUFFER, f.subarray(head), gl.STREAM_DRAW);
+if (s.imgs !== imgs) {
+	s.textures.forEach((t) => gl.deleteTexture(t));
+	s.textures = [];
+	s.imgs = imgs;
+}
+for (let i = 11; i < head; i += 3) {
+	const tex = f[i];
+	gl.bindTexture(gl.TEXTURE_2D, tex < 0 ? s.atlas : (s.textures[tex] || (s.textures[tex] = s.image(imgs[tex]))));
+	gl.drawArrays(gl.TRIANGLES, f[i + 1], f[i + 2]);
+}
+
+if (crt) {
+	const p = s.post;
+	gl.bindFramebuffer(gl.FRAMEBUFFER, null);
+	gl.useProgram(p.program);
+	gl.bindBuffer(gl.ARRAY_BUFFER, p.quad);
+	gl.disableVertexAttribArray(1);
+	gl.disableVertexAttribArray(2);
+	gl.vertexAttribPointer(0, 2, gl.FLOAT, false, 8, 0);
+	gl.disable(gl.BLEND);
+	gl.uniform4f(p.crt, f[6], f[7], f[8], f[9]);
+	gl.uniform2f(p.spread, f[10] / width, f[10] / height);
+	gl.bindTexture(gl.TEXTURE_2D, p.frame);
+	gl.drawArrays(gl.TRIANGLES, 0, 6);
+	s.bind();
+}
+return 1;`
+
+// WebGL draws on a WebGL canvas: the frame is recorded as triangles by a
+// mesh.Mesh and drawn with a single call into JavaScript on Flush, one draw
+// call per texture. Text uses the bundled bitmap font of the raster renderer,
+// from a texture atlas, instead of the fonts of the page. It is a crt.Shader:
+// the CRT look is drawn by a post-processing pass.
+type WebGL struct {
+	*mesh.Mesh
+	gl      JSContext
+	element JSContext
+	w       float64
+	crt     crt.Options
+
+	nums      []float32
+	bytes     []byte
+	jsBytes   js.Value
+	jsAtlas   js.Value
+	imgIdx    map[image.Image]int
+	jsImages  js.Value
+	loadImage func(img image.Image) interface{}
+	installed bool
+	lost      bool
+}
+
+// NewPageWebGL draws on the game canvas of the page.
+func NewPageWebGL(w, h float64) (*WebGL, error) {
+	doc := js.Global().Get("document")
+	return NewWebGL(doc.Call("getElementById", "gameCanvas"), w, h)
+}
+
+// NewWebGL draws on canvasElement; it returns ErrNoWebGL when the browser, or
+// the canvas, has no WebGL context, or when its shaders do not compile.
+func NewWebGL(canvasElement js.Value, w, h float64) (*WebGL, error) {
+	ctx := canvasElement.Call("getContext", "webgl", map[string]interface{}{"premultipliedAlpha": false})
+	if ctx.IsNull() || ctx.IsUndefined() {
+		return nil, ErrNoWebGL
+	}
+
+	g := newWebGL(NewJSContext(ctx), w, h)
+	g.element = NewJSContext(canvasElement)
+	g.Fit()
+	if !g.compile() {
+		return nil, ErrNoWebGL
+	}
+
+	g.watchContext()
+	return g, nil
+}
+
+func newWebGL(ctx JSContext, w, h float64) *WebGL {
+	atlas := mesh.Atlas()
+	jsAtlas := js.Global().Get("Uint8Array").New(len(atlas.Pix))
+	js.CopyBytesToJS(jsAtlas, atlas.Pix)
+
+	return &WebGL{
+		Mesh:      mesh.NewMesh(w, h, int(w), int(h)),
+		gl:        ctx,
+		w:         w,
+		jsAtlas:   jsAtlas,
+		imgIdx:    make(map[image.Image]int),
+		jsImages:  js.Global().Get("Array").New(),
+		loadImage: newImageSource,
+	}
+}
+
+// Fit sizes the drawing buffer to the element size on screen, as Canvas.Fit does.
+func (g *WebGL) Fit() {
+	dpr := js.Global().Get("devicePixelRatio").Float()
+	width, height, scale := calcBackingStore(g.element.Get("clientWidth").Float(), g.element.Get("clientHeight").Float(), dpr, g.w)
+	if scale <= 0 {
+		return
+	}
+
+	g.element.Set("width", width)
+	g.element.Set("height", height)
+	g.Resize(width, height)
+}
+
+// WatchResize refits the canvas whenever the window is resized or zoomed.
+func (g *WebGL) WatchResize() {
+	js.Global().Call("addEventListener", "resize", js.FuncOf(func(this js.Value, args []js.Value) any {
+		g.Fit()
+		return nil
+	}))
+}
+
+// compile draws a blank frame, which makes the program of the context; it
+// reports false when the shaders do not compile or link.
+func (g *WebGL) compile() bool {
+	g.Clear(ports.Color{})
+	return g.flush()
+}
+
+// watchContext stops drawing while the browser has taken the WebGL context
+// away, e.g. after a GPU reset, and makes the program and the textures again
+// once it is restored: the objects of the lost context are gone.
+func (g *WebGL) watchContext() {
+	g.element.Call("addEventListener", "webglcontextlost", js.FuncOf(func(this js.Value, args []js.Value) any {
+		args[0].Call("preventDefault") // the context is restored only when the loss is handled
+		g.lost = true
+		return nil
+	}))
+	g.element.Call("addEventListener", "webglcontextrestored", js.FuncOf(func(this js.Value, args []js.Value) any {
+		g.gl.Set("squashState", js.Undefined())
+		g.lost = false
+		return nil
+	}))
+}
+
+// SetCRT implements crt.Shader.
+func (g *WebGL) SetCRT(opts crt.Options) {
+	g.crt = opts
+}
+
+// Flush draws the recorded frame and starts a new one.
+func (g *WebGL) Flush() {
+	g.flush()
+}
+
+// flush draws the frame; it reports false when the program could not be made.
+func (g *WebGL) flush() bool {
+	g.encode()
+	g.Reset()
+	if g.lost || g.nums[0] == 0 && g.nums[1] == 0 {
+		return true
+	}
+
+	if !g.installed {
+		fn := js.Global().Get("Function").New("bytes", "n", "imgs", "atlas", "aw", "ah", drawSource)
+		g.gl.Set(drawMethod, fn)
+		g.installed = true
+	}
+
+	size := len(g.nums) * 4
+	if cap(g.bytes) < size {
+		g.bytes = make([]byte, size, 2*size)
+		g.jsBytes = js.Global().Get("Uint8Array").New(cap(g.bytes))
+	}
+	g.bytes = g.bytes[:size]
+	for i, v := range g.nums {
+		binary.LittleEndian.PutUint32(g.bytes[i*4:], math.Float32bits(v))
+	}
+	js.CopyBytesToJS(g.jsBytes, g.bytes)
+
+	atlas := mesh.Atlas().Bounds().Size()
+	return g.gl.Call(drawMethod, g.jsBytes, len(g.nums), g.jsImages, g.jsAtlas, atlas.X, atlas.Y).Float() == 1
+}
+
+// encode writes the header and the vertices of the frame to g.nums.
+func (g *WebGL) encode() {
+	spans := g.Spans()
+	bg, cleared := g.Background()
+
+	g.nums = append(g.nums[:0], float32(len(spans)), 0, 0, 0, 0, 0)
+	if cleared {
+		g.nums[1] = 1
+		g.nums[2], g.nums[3], g.nums[4], g.nums[5] = float32(bg.R)/255, float32(bg.G)/255, float32(bg.B)/255, float32(bg.A)/255
+	}
+
+	// the frame refers to the images by index, so they are dropped between
+	// frames, their textures with them
+	if g.jsImages.Length() >= maxImages {
+		clear(g.imgIdx)
+		g.jsImages = js.Global().Get("Array").New()
+	}
+
+	width, _ := g.Size()
+	px := float64(width) / g.w
+	g.nums = append(g.nums, float32(g.crt.Scanlines), float32(g.crt.Glow), float32(g.crt.Curvature),
+		float32(crt.ScanlinePeriod*px), float32(crt.GlowSpread*px))
+	for _, span := range spans {
+		g.nums = append(g.nums, float32(g.texture(span.Image)), float32(span.First), float32(span.Count))
+	}
+	g.nums = append(g.nums, g.Vertices()...)
+}
+
+// texture is the index of img in the images of the page, or -1 for the atlas;
+// a new image is uploaded once, see cachedByIdentity.
+func (g *WebGL) texture(img image.Image) int {
+	if img == nil {
+		return -1
+	}
+
+	if !cachedByIdentity(img) {
+		g.jsImages.Call("push", g.loadImage(img))
+		return g.jsImages.Length() - 1
+	}
+
+	i, ok := g.imgIdx[img]
+	if !ok {
+		i = g.jsImages.Length()
+		g.jsImages.Call("push", g.loadImage(img))
+		g.imgIdx[img] = i
+	}
+
+	return i
+}
+
+var _ crt.Shader = (*WebGL)(nil)
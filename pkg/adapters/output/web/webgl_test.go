@@ -0,0 +1,293 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+	"syscall/js"
+	"testing"
+
+	"github.com/psaraiva/squash/internal/ports"
+	"github.com/psaraiva/squash/pkg/adapters/output/crt"
+	"github.com/psaraiva/squash/pkg/adapters/output/mesh"
+)
+
+// newGLContext returns a stand-in for a WebGL context: it logs every method
+// call as "name(args)", resolves the constants to their names and keeps the
+// properties set on it, such as the installed draw method and its state.
+func newGLContext() js.Value {
+	return js.Global().Get("Function").New(`
+const calls = [];
+return new Proxy({ calls, canvas: { width: 800, height: 600 }, squashState: undefined }, {
+	get(target, key) {
+		if (key in target) return target[key];
+		if (typeof key === "string" && key === key.toUpperCase()) return key;
+		return (...args) => {
+			calls.push(String(key) + "(" + args.map((a) => ArrayBuffer.isView(a) ? "[" + a.length + "]" : a).join(",") + ")");
+			return String(key);
+		};
+	},
+	set(target, key, value) {
+		target[key] = value;
+		return true;
+	},
+});`).Invoke()
+}
+
+func countPrefix(calls []string, prefix string) int {
+	n := 0
+	for _, c := range calls {
+		if strings.HasPrefix(c, prefix) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestWebGLFlush(t *testing.T) {
+	white := ports.RGB(255, 255, 255)
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	ctx := newGLContext()
+	g := newWebGL(NewJSContext(ctx), 800, 600)
+	g.loadImage = func(image.Image) interface{} { return "img" }
+
+	frame := func() {
+		g.Clear(ports.RGB(255, 0, 0))
+		g.DrawRect(10, 10, 20, 20, ports.Style{Fill: white})
+		g.DrawImage(img, 50, 50, 10, 10)
+		g.DrawText("Hi", 100, 100, ports.TextStyle{Font: ports.Font{Size: 20}, Color: white})
+		g.Flush()
+	}
+
+	frame()
+	frame()
+	calls := loggedCalls(ctx)
+
+	for _, want := range []string{
+		"clearColor(1,0,0,1)",
+		"clear(COLOR_BUFFER_BIT)",
+		"viewport(0,0,800,600)",
+		"bindTexture(TEXTURE_2D,createTexture)",
+		"drawArrays(TRIANGLES,0,6)",
+		"drawArrays(TRIANGLES,6,6)",
+		"drawArrays(TRIANGLES,12,12)",
+	} {
+		if !slices.Contains(calls, want) {
+			t.Errorf("no %s in %v", want, calls)
+		}
+	}
+
+	// the program and the textures are made once
+	if got := countPrefix(calls, "createProgram("); got != 1 {
+		t.Errorf("createProgram calls = %d, want 1", got)
+	}
+	if got := countPrefix(calls, "texImage2D("); got != 2 {
+		t.Errorf("texImage2D calls = %d, want 2 (atlas and image)", got)
+	}
+	if got := countPrefix(calls, "drawArrays("); got != 6 {
+		t.Errorf("drawArrays calls = %d, want 3 per frame", got)
+	}
+
+	// the atlas is uploaded at its size, one texel per dot
+	atlas := mesh.Atlas()
+	size := atlas.Bounds().Size()
+	want := fmt.Sprintf("texImage2D(TEXTURE_2D,0,RGBA,%d,%d,0,RGBA,UNSIGNED_BYTE,[%d])", size.X, size.Y, len(atlas.Pix))
+	if !slices.Contains(calls, want) {
+		t.Errorf("no %s in %v", want, calls)
+	}
+}
+
+func TestWebGLImages(t *testing.T) {
+	ctx := newGLContext()
+	g := newWebGL(NewJSContext(ctx), 800, 600)
+	g.loadImage = func(image.Image) interface{} { return "img" }
+
+	// two images a frame, until the cache is full
+	for i := 0; i < maxImages/2; i++ {
+		g.DrawImage(image.NewRGBA(image.Rect(0, 0, 1, 1)), 0, 0, 1, 1)
+		g.DrawImage(sliceImage{pix: []color.RGBA{{A: 255}}}, 0, 0, 1, 1)
+		g.Flush()
+	}
+	if got := countPrefix(loggedCalls(ctx), "deleteTexture("); got != 0 {
+		t.Fatalf("deleteTexture calls = %d before the cache is full", got)
+	}
+
+	// the next frame drops the images, and deletes their textures
+	g.DrawImage(image.NewRGBA(image.Rect(0, 0, 1, 1)), 0, 0, 1, 1)
+	g.Flush()
+	if got := countPrefix(loggedCalls(ctx), "deleteTexture("); got != maxImages {
+		t.Errorf("deleteTexture calls = %d, want %d", got, maxImages)
+	}
+	if n := g.jsImages.Length(); n != 1 || len(g.imgIdx) != 1 {
+		t.Errorf("images kept = %d, indexed = %d, want 1", n, len(g.imgIdx))
+	}
+}
+
+func TestWebGLCRT(t *testing.T) {
+	ctx := newGLContext()
+	g := newWebGL(NewJSContext(ctx), 800, 600)
+
+	frame := func() {
+		g.Clear(ports.RGB(0, 0, 0))
+		g.DrawRect(10, 10, 20, 20, ports.Style{Fill: ports.RGB(255, 255, 255)})
+		g.Flush()
+	}
+
+	frame()
+	if calls := loggedCalls(ctx); countPrefix(calls, "bindFramebuffer(") != 0 {
+		t.Fatalf("the frame is drawn off screen without the CRT look: %v", calls)
+	}
+
+	g.SetCRT(crt.Options{Scanlines: 0.5, Glow: 0.25, Curvature: 0.125})
+	frame()
+	frame()
+	calls := loggedCalls(ctx)
+
+	for _, want := range []string{
+		"bindFramebuffer(FRAMEBUFFER,createFramebuffer)",
+		"framebufferTexture2D(FRAMEBUFFER,COLOR_ATTACHMENT0,TEXTURE_2D,createTexture,0)",
+		"texImage2D(TEXTURE_2D,0,RGBA,800,600,0,RGBA,UNSIGNED_BYTE,)",
+		"bindFramebuffer(FRAMEBUFFER,)",
+		fmt.Sprintf("uniform4f(getUniformLocation,0.5,0.25,0.125,%d)", crt.ScanlinePeriod),
+		fmt.Sprintf("uniform2f(getUniformLocation,%g,%g)", float64(crt.GlowSpread)/800, float64(crt.GlowSpread)/600),
+	} {
+		if !slices.Contains(calls, want) {
+			t.Errorf("no %s in %v", want, calls)
+		}
+	}
+
+	// the post-processing program and its frame texture are made once
+	if got := countPrefix(calls, "createProgram("); got != 2 {
+		t.Errorf("createProgram calls = %d, want 2", got)
+	}
+	if got := countPrefix(calls, "createFramebuffer("); got != 1 {
+		t.Errorf("createFramebuffer calls = %d, want 1", got)
+	}
+	// one draw for the rect and one for the screen, per CRT frame
+	if got := countPrefix(calls, "drawArrays("); got != 5 {
+		t.Errorf("drawArrays calls = %d, want 5", got)
+	}
+}
+
+func TestWebGLFlushEmpty(t *testing.T) {
+	ctx := newCountingContext()
+	g := newWebGL(ctx, 800, 600)
+
+	g.Flush()
+	if ctx.calls != 0 {
+		t.Errorf("calls = %d for an empty frame, want 0", ctx.calls)
+	}
+}
+
+func TestWebGLCompile(t *testing.T) {
+	ctx := newGLContext()
+	g := newWebGL(NewJSContext(ctx), 800, 600)
+
+	if !g.compile() {
+		t.Fatalf("compile() = false, want true")
+	}
+	if calls := loggedCalls(ctx); !slices.Contains(calls, "clear(COLOR_BUFFER_BIT)") {
+		t.Errorf("no blank frame in %v", calls)
+	}
+}
+
+func TestWebGLCompileError(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+	}{
+		{name: "Shader does not compile", method: "getShaderParameter"},
+		{name: "Program does not link", method: "getProgramParameter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newGLContext()
+			ctx.Set(tt.method, js.FuncOf(func(this js.Value, args []js.Value) any { return false }))
+			g := newWebGL(NewJSContext(ctx), 800, 600)
+
+			if g.compile() {
+				t.Errorf("compile() = true, want false")
+			}
+			if calls := loggedCalls(ctx); countPrefix(calls, "drawArrays(") != 0 || countPrefix(calls, "clear(") != 0 {
+				t.Errorf("a frame was drawn without a program: %v", calls)
+			}
+		})
+	}
+}
+
+func TestWebGLCRTCompileError(t *testing.T) {
+	ctx := newGLContext()
+	links := 0
+	ctx.Set("getProgramParameter", js.FuncOf(func(this js.Value, args []js.Value) any {
+		links++
+		return links == 1 // only the program of the shapes links
+	}))
+	g := newWebGL(NewJSContext(ctx), 800, 600)
+	g.SetCRT(crt.DefaultOptions())
+
+	for range 2 {
+		g.Clear(ports.RGB(0, 0, 0))
+		g.DrawRect(10, 10, 20, 20, ports.Style{Fill: ports.RGB(255, 255, 255)})
+		g.Flush()
+	}
+
+	// the frames are drawn on the canvas, without the CRT look
+	calls := loggedCalls(ctx)
+	if got := countPrefix(calls, "bindFramebuffer("); got != 0 {
+		t.Errorf("bindFramebuffer calls = %d, want 0", got)
+	}
+	if got := countPrefix(calls, "drawArrays("); got != 2 {
+		t.Errorf("drawArrays calls = %d, want 2", got)
+	}
+	if links != 2 {
+		t.Errorf("getProgramParameter calls = %d, want 2 (the post-processing program is tried once)", links)
+	}
+}
+
+func TestWebGLContextLost(t *testing.T) {
+	ctx := newGLContext()
+	element := js.Global().Get("Function").New(`
+return {
+	listeners: {},
+	addEventListener(type, fn) { this.listeners[type] = fn; },
+};`).Invoke()
+	event := js.Global().Get("Function").New(`return { preventDefault() { this.prevented = true; } };`).Invoke()
+
+	g := newWebGL(NewJSContext(ctx), 800, 600)
+	g.element = NewJSContext(element)
+	g.watchContext()
+
+	frame := func() {
+		g.Clear(ports.RGB(0, 0, 0))
+		g.DrawRect(10, 10, 20, 20, ports.Style{Fill: ports.RGB(255, 255, 255)})
+		g.Flush()
+	}
+
+	frame()
+	element.Get("listeners").Call("webglcontextlost", event)
+	if !event.Get("prevented").Truthy() {
+		t.Errorf("the context loss was not handled, the browser will not restore it")
+	}
+	frame()
+	if got := countPrefix(loggedCalls(ctx), "drawArrays("); got != 1 {
+		t.Errorf("drawArrays calls = %d while the context is lost, want 1", got)
+	}
+
+	// the program is made again on the restored context
+	element.Get("listeners").Call("webglcontextrestored", event)
+	frame()
+	calls := loggedCalls(ctx)
+	if got := countPrefix(calls, "createProgram("); got != 2 {
+		t.Errorf("createProgram calls = %d, want 2", got)
+	}
+	if got := countPrefix(calls, "drawArrays("); got != 2 {
+		t.Errorf("drawArrays calls = %d, want 2", got)
+	}
+}
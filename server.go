@@ -7,6 +7,6 @@ import (
 
 func main() {
 	fs := http.FileServer(http.Dir("./bin/web"))
-	log.Print("Servidor rodando na porta 8080...")
+	log.Print("Server running on port 8080...")
 	log.Fatal(http.ListenAndServe(":8080", fs))
 }